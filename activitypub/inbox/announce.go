@@ -36,5 +36,5 @@ func handleAnnounceRequest(c context.Context, activity vocab.ActivityStreamsAnno
 		return errors.Wrap(err, "unable to save inbound share/re-post activity")
 	}
 
-	return handleEngagementActivity(events.FediverseEngagementRepost, isLiveNotification, actorReference, events.FediverseEngagementRepost)
+	return handleEngagementActivity(events.FediverseEngagementRepost, isLiveNotification, actorReference, objectIRI, events.FediverseEngagementRepost)
 }
@@ -8,29 +8,52 @@ import (
 	"github.com/owncast/owncast/core/chat"
 	"github.com/owncast/owncast/core/chat/events"
 	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/webhooks"
 )
 
-func handleEngagementActivity(eventType events.EventType, isLiveNotification bool, actorReference vocab.ActivityStreamsActorProperty, action string) error {
-	// Do nothing if displaying engagement actions has been turned off.
-	if !data.GetFederationShowEngagement() {
-		return nil
-	}
+func handleEngagementActivity(eventType events.EventType, isLiveNotification bool, actorReference vocab.ActivityStreamsActorProperty, objectIRI string, action string) error {
+	sendWebhooks := len(data.GetWebhooksForEvent(eventType)) > 0
+	// Engagement is only shown in chat if it is turned on and chat is enabled.
+	showInChat := data.GetFederationShowEngagement() && !data.GetChatDisabled()
 
-	// Do nothing if chat is disabled
-	if data.GetChatDisabled() {
+	// Resolving the actor is a request to their server, so skip it when
+	// nothing would use it.
+	if !sendWebhooks && !showInChat {
 		return nil
 	}
 
 	// Get actor of the action
 	actor, _ := resolvers.GetResolvedActorFromActorProperty(actorReference)
 
-	// Send chat message
 	actorName := actor.Name
 	if actorName == "" {
 		actorName = actor.Username
 	}
 	actorIRI := actorReference.Begin().GetIRI().String()
 
+	var image *string
+	if actor.Image != nil {
+		s := actor.Image.String()
+		image = &s
+	}
+
+	if sendWebhooks {
+		webhooks.SendFediverseEvent(eventType, webhooks.WebhookFediverseEngagement{
+			Name:               actorName,
+			Username:           actor.FullUsername,
+			Image:              image,
+			ActorIRI:           actorIRI,
+			ObjectIRI:          objectIRI,
+			IsLiveNotification: isLiveNotification,
+		})
+	}
+
+	if !showInChat {
+		return nil
+	}
+
+	// Send chat message
+
 	userPrefix := fmt.Sprintf("%s ", actorName)
 	var suffix string
 	if isLiveNotification && action == events.FediverseEngagementLike {
@@ -48,12 +71,6 @@ func handleEngagementActivity(eventType events.EventType, isLiveNotification boo
 	}
 	body := fmt.Sprintf("%s %s", userPrefix, suffix)
 
-	var image *string
-	if actor.Image != nil {
-		s := actor.Image.String()
-		image = &s
-	}
-
 	if err := chat.SendFediverseAction(eventType, actor.FullUsername, image, body, actorIRI); err != nil {
 		return err
 	}
@@ -6,11 +6,14 @@ import (
 	"time"
 
 	"github.com/go-fed/activity/streams/vocab"
+	"github.com/owncast/owncast/activitypub/apmodels"
 	"github.com/owncast/owncast/activitypub/persistence"
 	"github.com/owncast/owncast/activitypub/requests"
 	"github.com/owncast/owncast/activitypub/resolvers"
 	"github.com/owncast/owncast/core/chat/events"
 	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/webhooks"
+	"github.com/owncast/owncast/models"
 	"github.com/pkg/errors"
 
 	log "github.com/sirupsen/logrus"
@@ -43,6 +46,8 @@ func handleFollowInboxRequest(c context.Context, activity vocab.ActivityStreamsF
 			log.Errorln("unable to send follow accept", err)
 			return err
 		}
+	} else {
+		sendFollowRequestWebhook(followRequest)
 	}
 
 	// Save as an accepted activity
@@ -68,12 +73,34 @@ func handleFollowInboxRequest(c context.Context, activity vocab.ActivityStreamsF
 
 	// Send action to chat if it has not been previously handled.
 	if !hasPreviouslyhandled {
-		return handleEngagementActivity(events.FediverseEngagementFollow, false, actorReference, events.FediverseEngagementFollow)
+		return handleEngagementActivity(events.FediverseEngagementFollow, false, actorReference, objectIRI, events.FediverseEngagementFollow)
 	}
 
 	return nil
 }
 
+// sendFollowRequestWebhook will tell webhooks about a follow that needs to
+// be approved.
+func sendFollowRequestWebhook(follow apmodels.ActivityPubActor) {
+	// Username is the full username@account.tld of a follow request.
+	engagement := webhooks.WebhookFediverseEngagement{
+		Name:     follow.Name,
+		Username: follow.Username,
+	}
+	if engagement.Name == "" {
+		engagement.Name = follow.Username
+	}
+	if follow.ActorIri != nil {
+		engagement.ActorIRI = follow.ActorIri.String()
+	}
+	if follow.Image != nil {
+		image := follow.Image.String()
+		engagement.Image = &image
+	}
+
+	webhooks.SendFediverseEvent(models.FediverseFollowRequest, engagement)
+}
+
 func handleUnfollowRequest(c context.Context, activity vocab.ActivityStreamsUndo) error {
 	request := resolvers.MakeUnFollowRequest(c, activity)
 	if request == nil {
@@ -36,5 +36,5 @@ func handleLikeRequest(c context.Context, activity vocab.ActivityStreamsLike) er
 		return errors.Wrap(err, "unable to save inbound like activity")
 	}
 
-	return handleEngagementActivity(events.FediverseEngagementLike, isLiveNotification, actorReference, events.FediverseEngagementLike)
+	return handleEngagementActivity(events.FediverseEngagementLike, isLiveNotification, actorReference, objectIRI, events.FediverseEngagementLike)
 }
@@ -8,4 +8,10 @@ const (
 	// IndieAuth https://indieauth.spec.indieweb.org/.
 	IndieAuth Type = "indieauth"
 	Fediverse Type = "fediverse"
+	// OIDC https://openid.net/specs/openid-connect-core-1_0.html.
+	OIDC Type = "oidc"
+	// Password is a username and password registered with this server.
+	Password Type = "password"
+	// Passkey is a WebAuthn public key credential.
+	Passkey Type = "passkey"
 )
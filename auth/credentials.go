@@ -0,0 +1,137 @@
+package auth
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Credential is a secret used to log in that is verified by this server.
+type Credential struct {
+	Identifier string
+	Type       Type
+	UserID     string
+	Secret     string
+	SignCount  int
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+}
+
+// Method is a single way a user is able to log in.
+type Method struct {
+	Type       Type      `json:"type"`
+	Identifier string    `json:"identifier"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// ErrCredentialExists is returned when adding a credential whose identifier
+// is already in use.
+var ErrCredentialExists = errors.New("credential already exists")
+
+// AddCredential will save a new credential for a user.
+func AddCredential(userID, identifier string, credentialType Type, secret string) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	_, err := _datastore.DB.Exec("INSERT INTO auth_credentials(identifier, type, user_id, secret) VALUES(?, ?, ?, ?)", identifier, string(credentialType), userID, secret)
+	if err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed") {
+		return ErrCredentialExists
+	}
+
+	return err
+}
+
+// GetCredential will return a credential by its identifier, or nil if it
+// does not exist.
+func GetCredential(identifier string, credentialType Type) (*Credential, error) {
+	row := _datastore.DB.QueryRow("SELECT identifier, type, user_id, secret, sign_count, timestamp, last_used FROM auth_credentials WHERE identifier = ? AND type = ?", identifier, string(credentialType))
+	return getCredentialFromRow(row)
+}
+
+// GetCredentialForUser will return the credential of a type belonging to a
+// user, or nil if they do not have one.
+func GetCredentialForUser(userID string, credentialType Type) (*Credential, error) {
+	row := _datastore.DB.QueryRow("SELECT identifier, type, user_id, secret, sign_count, timestamp, last_used FROM auth_credentials WHERE user_id = ? AND type = ?", userID, string(credentialType))
+	return getCredentialFromRow(row)
+}
+
+// UpdateCredentialSecret will replace the secret of an existing credential.
+func UpdateCredentialSecret(identifier string, credentialType Type, secret string) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	_, err := _datastore.DB.Exec("UPDATE auth_credentials SET secret = ? WHERE identifier = ? AND type = ?", secret, identifier, string(credentialType))
+	return err
+}
+
+// SetCredentialAsUsed will record a successful login with a credential.
+func SetCredentialAsUsed(identifier string, credentialType Type, signCount int) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	_, err := _datastore.DB.Exec("UPDATE auth_credentials SET last_used = CURRENT_TIMESTAMP, sign_count = ? WHERE identifier = ? AND type = ?", signCount, identifier, string(credentialType))
+	return err
+}
+
+// RemoveAllForUser will remove every external auth link and credential of a
+// user so they can no longer log in.
+func RemoveAllForUser(userID string) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	tx, err := _datastore.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	if _, err := tx.Exec("DELETE FROM auth WHERE user_id = ?", userID); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("DELETE FROM auth_credentials WHERE user_id = ?", userID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetMethodsForUser will return every way a user is able to log in,
+// including both external auth and credentials.
+func GetMethodsForUser(userID string) ([]Method, error) {
+	rows, err := _datastore.DB.Query(`SELECT type, token, timestamp FROM auth WHERE user_id = ?
+		UNION ALL SELECT type, identifier, timestamp FROM auth_credentials WHERE user_id = ?
+		ORDER BY timestamp`, userID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	methods := []Method{}
+	for rows.Next() {
+		var method Method
+		if err := rows.Scan(&method.Type, &method.Identifier, &method.CreatedAt); err != nil {
+			return nil, err
+		}
+		methods = append(methods, method)
+	}
+
+	return methods, rows.Err()
+}
+
+func getCredentialFromRow(row *sql.Row) (*Credential, error) {
+	var credential Credential
+	var credentialType string
+
+	err := row.Scan(&credential.Identifier, &credentialType, &credential.UserID, &credential.Secret, &credential.SignCount, &credential.CreatedAt, &credential.LastUsedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	credential.Type = Type(credentialType)
+	return &credential, nil
+}
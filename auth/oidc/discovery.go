@@ -0,0 +1,53 @@
+package oidc
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// providerMetadata is the subset of the OpenID Provider discovery document
+// needed to log in.
+type providerMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+}
+
+// discover will fetch the discovery document of an issuer.
+// https://openid.net/specs/openid-connect-discovery-1_0.html
+func discover(issuer string) (*providerMetadata, error) {
+	issuer = strings.TrimRight(issuer, "/")
+
+	res, err := httpClient.Get(issuer + "/.well-known/openid-configuration")
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to fetch OpenID Connect discovery document")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("OpenID Connect discovery document returned status %d", res.StatusCode)
+	}
+
+	var metadata providerMetadata
+	if err := json.NewDecoder(res.Body).Decode(&metadata); err != nil {
+		return nil, errors.Wrap(err, "unable to parse OpenID Connect discovery document")
+	}
+
+	// The issuer in the document must match the one it was fetched from.
+	if strings.TrimRight(metadata.Issuer, "/") != issuer {
+		return nil, errors.Errorf("OpenID Connect issuer %s does not match the configured issuer %s", metadata.Issuer, issuer)
+	}
+
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" {
+		return nil, errors.New("OpenID Connect discovery document is missing required endpoints")
+	}
+
+	return &metadata, nil
+}
@@ -0,0 +1,341 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/models"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// How long a user has to complete logging in with the identity provider.
+const pendingRequestTimeout = 10 * time.Minute
+
+var (
+	pendingAuthRequests     = make(map[string]*Request)
+	pendingAuthRequestsLock sync.Mutex
+)
+
+// Request represents a single in-flight OpenID Connect login.
+type Request struct {
+	UserID             string
+	DisplayName        string
+	CurrentAccessToken string
+	State              string
+	Nonce              string
+	CodeVerifier       string
+	Callback           *url.URL
+	Redirect           *url.URL
+
+	config    models.OIDCConfiguration
+	provider  *providerMetadata
+	createdAt time.Time
+}
+
+// Identity is the verified identity returned by the identity provider.
+type Identity struct {
+	Issuer      string
+	Subject     string
+	DisplayName string
+}
+
+// AuthToken will return the value that uniquely identifies this identity.
+func (i Identity) AuthToken() string {
+	return i.Issuer + "#" + i.Subject
+}
+
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// StartAuthFlow will begin logging in with the configured identity provider
+// and return the URL to send the user to.
+func StartAuthFlow(userID, accessToken, displayName string) (*url.URL, error) {
+	config := data.GetOIDCConfig()
+	if !config.Enabled {
+		return nil, errors.New("OpenID Connect login is not enabled")
+	}
+
+	serverURL := data.GetServerURL()
+	if serverURL == "" {
+		return nil, errors.New("Owncast server URL must be set when using auth")
+	}
+
+	r, err := createAuthRequest(config, serverURL, userID, accessToken, displayName)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to generate OpenID Connect request")
+	}
+
+	pendingAuthRequestsLock.Lock()
+	defer pendingAuthRequestsLock.Unlock()
+
+	for state, pending := range pendingAuthRequests {
+		if time.Since(pending.createdAt) > pendingRequestTimeout {
+			delete(pendingAuthRequests, state)
+		}
+	}
+	pendingAuthRequests[r.State] = r
+
+	return r.Redirect, nil
+}
+
+// HandleCallbackCode will exchange the code returned by the identity
+// provider for the identity of the user.
+func HandleCallbackCode(code, state string) (*Request, *Identity, error) {
+	pendingAuthRequestsLock.Lock()
+	request, exists := pendingAuthRequests[state]
+	delete(pendingAuthRequests, state)
+	pendingAuthRequestsLock.Unlock()
+
+	if !exists || time.Since(request.createdAt) > pendingRequestTimeout {
+		return nil, nil, errors.New("no auth requests pending")
+	}
+
+	token, err := exchangeCode(request, code)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	claims, err := verifyIDToken(request, token.IDToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	identity := &Identity{
+		Issuer:      request.provider.Issuer,
+		Subject:     claims["sub"].(string),
+		DisplayName: getDisplayName(request.config, claims),
+	}
+
+	// Not every provider includes profile claims in the ID token.
+	if identity.DisplayName == "" && request.provider.UserinfoEndpoint != "" && token.AccessToken != "" {
+		userinfo, err := getUserinfo(request.provider.UserinfoEndpoint, token.AccessToken)
+		if err != nil {
+			log.Debugln("unable to fetch OpenID Connect userinfo", err)
+		} else if userinfo["sub"] == identity.Subject {
+			identity.DisplayName = getDisplayName(request.config, userinfo)
+		}
+	}
+
+	return request, identity, nil
+}
+
+func createAuthRequest(config models.OIDCConfiguration, serverURL, userID, accessToken, displayName string) (*Request, error) {
+	provider, err := discover(config.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	authURL, err := url.Parse(provider.AuthorizationEndpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse authorization endpoint")
+	}
+
+	baseServerURL, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse local owncast base server URL")
+	}
+
+	callbackURL := *baseServerURL
+	callbackURL.Path = "/api/auth/oidc/callback"
+
+	codeVerifier := randomString(32)
+	state := randomString(16)
+	nonce := randomString(16)
+
+	scopes := []string{"openid", "profile"}
+	for _, scope := range config.Scopes {
+		if scope != "openid" && scope != "profile" {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	q := authURL.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", config.ClientID)
+	q.Set("redirect_uri", callbackURL.String())
+	q.Set("scope", strings.Join(scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", createCodeChallenge(codeVerifier))
+	q.Set("code_challenge_method", "S256")
+	authURL.RawQuery = q.Encode()
+
+	return &Request{
+		UserID:             userID,
+		DisplayName:        displayName,
+		CurrentAccessToken: accessToken,
+		State:              state,
+		Nonce:              nonce,
+		CodeVerifier:       codeVerifier,
+		Callback:           &callbackURL,
+		Redirect:           authURL,
+		config:             config,
+		provider:           provider,
+		createdAt:          time.Now(),
+	}, nil
+}
+
+func exchangeCode(request *Request, code string) (*tokenResponse, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", request.Callback.String())
+	form.Set("code_verifier", request.CodeVerifier)
+	form.Set("client_id", request.config.ClientID)
+
+	r, err := http.NewRequest(http.MethodPost, request.provider.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("Accept", "application/json")
+	r.SetBasicAuth(url.QueryEscape(request.config.ClientID), url.QueryEscape(request.config.ClientSecret))
+
+	res, err := httpClient.Do(r)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var response tokenResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, errors.Wrap(err, "unable to parse OpenID Connect token response")
+	}
+
+	if response.Error != "" {
+		return nil, fmt.Errorf("OpenID Connect error: %s - %s", response.Error, response.ErrorDescription)
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 || response.IDToken == "" {
+		return nil, errors.Errorf("OpenID Connect token request failed with status %d", res.StatusCode)
+	}
+
+	return &response, nil
+}
+
+// verifyIDToken will validate the claims of an ID token. The token is
+// received directly from the token endpoint over TLS so, as allowed by
+// OpenID Connect Core 3.1.3.7, the TLS connection is relied on in place of
+// checking the token signature.
+func verifyIDToken(request *Request, idToken string) (map[string]interface{}, error) {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed ID token")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to decode ID token")
+	}
+
+	var claims map[string]interface{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, errors.Wrap(err, "unable to parse ID token")
+	}
+
+	if iss, _ := claims["iss"].(string); strings.TrimRight(iss, "/") != strings.TrimRight(request.provider.Issuer, "/") {
+		return nil, errors.New("ID token was not issued by the configured issuer")
+	}
+
+	if !hasAudience(claims["aud"], request.config.ClientID) {
+		return nil, errors.New("ID token was not issued for this server")
+	}
+
+	if exp, ok := claims["exp"].(float64); !ok || time.Now().After(time.Unix(int64(exp), 0)) {
+		return nil, errors.New("ID token has expired")
+	}
+
+	if nonce, _ := claims["nonce"].(string); nonce != request.Nonce {
+		return nil, errors.New("ID token does not match the login request")
+	}
+
+	if sub, _ := claims["sub"].(string); sub == "" {
+		return nil, errors.New("ID token is missing the subject")
+	}
+
+	return claims, nil
+}
+
+func hasAudience(aud interface{}, clientID string) bool {
+	switch aud := aud.(type) {
+	case string:
+		return aud == clientID
+	case []interface{}:
+		for _, a := range aud {
+			if a == clientID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func getUserinfo(endpoint, accessToken string) (map[string]interface{}, error) {
+	r, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	r.Header.Set("Authorization", "Bearer "+accessToken)
+	r.Header.Set("Accept", "application/json")
+
+	res, err := httpClient.Do(r)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("userinfo request failed with status %d", res.StatusCode)
+	}
+
+	var claims map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// getDisplayName will return the display name from the configured claim,
+// falling back to the standard profile claims.
+func getDisplayName(config models.OIDCConfiguration, claims map[string]interface{}) string {
+	for _, claim := range []string{config.DisplayNameClaim, "preferred_username", "name", "nickname"} {
+		if claim == "" {
+			continue
+		}
+
+		if name, ok := claims[claim].(string); ok && strings.TrimSpace(name) != "" {
+			return strings.TrimSpace(name)
+		}
+	}
+
+	return ""
+}
+
+func createCodeChallenge(codeVerifier string) string {
+	hash := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+func randomString(length int) string {
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		log.Panicln("unable to generate random string", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
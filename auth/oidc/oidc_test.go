@@ -0,0 +1,121 @@
+package oidc
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/models"
+)
+
+// newMockProvider will start a minimal OpenID Provider that issues an ID
+// token for any code, using the nonce from the most recent login.
+func newMockProvider(t *testing.T, clientID string, nonce *string, codeVerifier *string) *httptest.Server {
+	var server *httptest.Server
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(providerMetadata{
+			Issuer:                server.URL,
+			AuthorizationEndpoint: server.URL + "/authorize",
+			TokenEndpoint:         server.URL + "/token",
+		})
+	})
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		id, secret, _ := r.BasicAuth()
+		if id != clientID || secret != "secret" || r.FormValue("code") != "code" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(tokenResponse{Error: "invalid_grant"})
+			return
+		}
+		*codeVerifier = r.FormValue("code_verifier")
+
+		claims, _ := json.Marshal(map[string]interface{}{
+			"iss":                server.URL,
+			"sub":                "user-1",
+			"aud":                clientID,
+			"exp":                time.Now().Add(time.Minute).Unix(),
+			"nonce":              *nonce,
+			"preferred_username": "jane",
+		})
+		idToken := "e30." + base64.RawURLEncoding.EncodeToString(claims) + "."
+		_ = json.NewEncoder(w).Encode(tokenResponse{AccessToken: "access", IDToken: idToken})
+	})
+
+	server = httptest.NewServer(mux)
+	return server
+}
+
+func TestAuthFlow(t *testing.T) {
+	var nonce, codeVerifier string
+	provider := newMockProvider(t, "owncast", &nonce, &codeVerifier)
+	defer provider.Close()
+
+	config := models.OIDCConfiguration{
+		Enabled:      true,
+		Issuer:       provider.URL,
+		ClientID:     "owncast",
+		ClientSecret: "secret",
+	}
+
+	request, err := createAuthRequest(config, "https://owncast.example", "user", "token", "anon")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q := request.Redirect.Query()
+	if q.Get("code_challenge") != createCodeChallenge(request.CodeVerifier) || q.Get("code_challenge_method") != "S256" {
+		t.Error("expected a PKCE challenge in the authorization request")
+	}
+	if q.Get("redirect_uri") != "https://owncast.example/api/auth/oidc/callback" {
+		t.Errorf("unexpected redirect uri %s", q.Get("redirect_uri"))
+	}
+
+	nonce = request.Nonce
+	pendingAuthRequests[request.State] = request
+
+	if _, _, err := HandleCallbackCode("code", "unknown"); err == nil {
+		t.Error("expected an unknown state to be rejected")
+	}
+
+	_, identity, err := HandleCallbackCode("code", request.State)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if codeVerifier != request.CodeVerifier {
+		t.Error("expected the code verifier to be sent to the token endpoint")
+	}
+
+	if identity.Subject != "user-1" || identity.DisplayName != "jane" || identity.AuthToken() != provider.URL+"#user-1" {
+		t.Errorf("unexpected identity %+v", identity)
+	}
+
+	// A state can only be used once.
+	if _, _, err := HandleCallbackCode("code", request.State); err == nil {
+		t.Error("expected a used state to be rejected")
+	}
+}
+
+func TestVerifyIDTokenNonce(t *testing.T) {
+	var nonce, codeVerifier string
+	provider := newMockProvider(t, "owncast", &nonce, &codeVerifier)
+	defer provider.Close()
+
+	config := models.OIDCConfiguration{Issuer: provider.URL, ClientID: "owncast", ClientSecret: "secret"}
+	request, err := createAuthRequest(config, "https://owncast.example", "user", "token", "anon")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nonce = "a different login"
+	pendingAuthRequests[request.State] = request
+
+	if _, _, err := HandleCallbackCode("code", request.State); err == nil {
+		t.Error("expected an ID token for a different login to be rejected")
+	}
+}
@@ -0,0 +1,210 @@
+package password
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/owncast/owncast/auth"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/time/rate"
+)
+
+const (
+	minPasswordLength = 8
+	// bcrypt ignores anything after 72 bytes.
+	maxPasswordLength = 72
+)
+
+// Login attempts are limited per username, and per IP address so many
+// usernames can not be tried at once, to slow down password guessing.
+const (
+	loginAttemptsPerMinute          = 5
+	loginAttemptsPerIPAddressMinute = 20
+	maxTrackedLoginLimiters         = 10000
+)
+
+var (
+	usernamePattern = regexp.MustCompile(`^[a-zA-Z0-9_.-]{3,32}$`)
+
+	// ErrInvalidLogin is returned for an unknown username or wrong password.
+	ErrInvalidLogin = errors.New("invalid username or password")
+	// ErrTooManyAttempts is returned when a username has had too many failed logins.
+	ErrTooManyAttempts = errors.New("too many login attempts, try again later")
+
+	usernameLoginLimiters  = newLoginLimiters(loginAttemptsPerMinute)
+	ipAddressLoginLimiters = newLoginLimiters(loginAttemptsPerIPAddressMinute)
+
+	// Compared against when the username does not exist so both cases take
+	// the same amount of time.
+	dummyHash = []byte("$2a$10$dQ2YK5.mNlueCUAFpv9X3uXSEQiT9X68tTTYjZZFdBpH489msBlDe")
+)
+
+// Register will add a username and password to an existing user so they
+// can log in to it again later.
+func Register(userID, username, password string) error {
+	username = normalizeUsername(username)
+	if !usernamePattern.MatchString(username) {
+		return errors.New("username must be 3 to 32 letters, numbers, dots, dashes or underscores")
+	}
+
+	if err := validatePassword(password); err != nil {
+		return err
+	}
+
+	if existing, err := auth.GetCredentialForUser(userID, auth.Password); err != nil {
+		return err
+	} else if existing != nil {
+		return errors.New("a username and password has already been registered for this user")
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	if err := auth.AddCredential(userID, username, auth.Password, string(hash)); err == auth.ErrCredentialExists {
+		return errors.New("username is already taken")
+	} else if err != nil {
+		return errors.Wrap(err, "unable to save credentials")
+	}
+
+	return nil
+}
+
+// Login will verify a username and password, sent from an IP address, and
+// return the ID of the user they belong to.
+func Login(username, password, ipAddress string) (string, error) {
+	username = normalizeUsername(username)
+
+	if !ipAddressLoginLimiters.allow(ipAddress, time.Now()) || !usernameLoginLimiters.allow(username, time.Now()) {
+		return "", ErrTooManyAttempts
+	}
+
+	credential, err := auth.GetCredential(username, auth.Password)
+	if err != nil {
+		return "", err
+	}
+
+	if credential == nil {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return "", ErrInvalidLogin
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(credential.Secret), []byte(password)); err != nil {
+		return "", ErrInvalidLogin
+	}
+
+	if err := auth.SetCredentialAsUsed(credential.Identifier, auth.Password, 0); err != nil {
+		return "", err
+	}
+
+	return credential.UserID, nil
+}
+
+// ChangePassword will replace the password of a user after verifying their
+// current one.
+func ChangePassword(userID, currentPassword, newPassword string) error {
+	credential, err := auth.GetCredentialForUser(userID, auth.Password)
+	if err != nil {
+		return err
+	}
+
+	if credential == nil {
+		return errors.New("no username and password has been registered for this user")
+	}
+
+	if !usernameLoginLimiters.allow(credential.Identifier, time.Now()) {
+		return ErrTooManyAttempts
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(credential.Secret), []byte(currentPassword)); err != nil {
+		return errors.New("current password is incorrect")
+	}
+
+	if err := validatePassword(newPassword); err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	return auth.UpdateCredentialSecret(credential.Identifier, auth.Password, string(hash))
+}
+
+func validatePassword(password string) error {
+	if len(password) < minPasswordLength {
+		return errors.Errorf("password must be at least %d characters", minPasswordLength)
+	}
+
+	if len(password) > maxPasswordLength {
+		return errors.Errorf("password must be %d bytes or less", maxPasswordLength)
+	}
+
+	return nil
+}
+
+// Usernames are case insensitive.
+func normalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+// loginLimiters limits login attempts for each of a number of keys, such as
+// usernames, keeping the most recently used limiters.
+type loginLimiters struct {
+	perMinute int
+	limiters  map[string]*loginLimiter
+	lock      sync.Mutex
+}
+
+type loginLimiter struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+func newLoginLimiters(perMinute int) *loginLimiters {
+	return &loginLimiters{perMinute: perMinute, limiters: make(map[string]*loginLimiter)}
+}
+
+// allow will return if another login attempt can be made for a key.
+func (l *loginLimiters) allow(key string, now time.Time) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	tracked, ok := l.limiters[key]
+	if !ok {
+		if len(l.limiters) >= maxTrackedLoginLimiters {
+			l.evict(now)
+		}
+
+		tracked = &loginLimiter{limiter: rate.NewLimiter(rate.Every(time.Minute/time.Duration(l.perMinute)), l.perMinute)}
+		l.limiters[key] = tracked
+	}
+
+	tracked.lastUsed = now
+	return tracked.limiter.AllowN(now, 1)
+}
+
+// evict will forget the limiters that have refilled, which behave the same
+// as new ones. If all of them are in use the least recently used is removed.
+func (l *loginLimiters) evict(now time.Time) {
+	var oldestKey string
+	var oldest time.Time
+	for key, tracked := range l.limiters {
+		if now.Sub(tracked.lastUsed) >= time.Minute {
+			delete(l.limiters, key)
+			continue
+		}
+		if oldestKey == "" || tracked.lastUsed.Before(oldest) {
+			oldestKey, oldest = key, tracked.lastUsed
+		}
+	}
+
+	if len(l.limiters) >= maxTrackedLoginLimiters {
+		delete(l.limiters, oldestKey)
+	}
+}
@@ -0,0 +1,153 @@
+package password
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/auth"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
+)
+
+func TestMain(m *testing.M) {
+	dbFile, err := os.CreateTemp(os.TempDir(), "owncast-test-db.db")
+	if err != nil {
+		panic(err)
+	}
+
+	data.SetupPersistence(dbFile.Name())
+	user.SetupUsers()
+	auth.Setup(data.GetDatastore())
+
+	os.Exit(m.Run())
+}
+
+func TestRegisterAndLogin(t *testing.T) {
+	u, _, err := user.CreateAnonymousUser("regular")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Register(u.ID, "Regular", "short"); err == nil {
+		t.Error("expected a short password to be rejected")
+	}
+
+	if err := Register(u.ID, "Regular", "correct horse"); err != nil {
+		t.Fatal(err)
+	}
+
+	other, _, err := user.CreateAnonymousUser("someone else")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Register(other.ID, "regular", "battery staple"); err == nil {
+		t.Error("expected usernames to be unique regardless of case")
+	}
+
+	if _, err := Login("regular", "wrong password", "203.0.113.1"); err != ErrInvalidLogin {
+		t.Error("expected a wrong password to be rejected", err)
+	}
+
+	if _, err := Login("nobody", "correct horse", "203.0.113.1"); err != ErrInvalidLogin {
+		t.Error("expected an unknown username to be rejected", err)
+	}
+
+	userID, err := Login(" REGULAR ", "correct horse", "203.0.113.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if userID != u.ID {
+		t.Errorf("expected to log in to %s, got %s", u.ID, userID)
+	}
+
+	// Other login methods can be linked to the same user.
+	if err := auth.AddAuth(u.ID, "https://regular.example/", auth.IndieAuth); err != nil {
+		t.Fatal(err)
+	}
+
+	methods, err := auth.GetMethodsForUser(u.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	types := map[auth.Type]string{}
+	for _, method := range methods {
+		types[method.Type] = method.Identifier
+	}
+
+	if len(methods) != 2 || types[auth.Password] != "regular" || types[auth.IndieAuth] != "https://regular.example/" {
+		t.Errorf("unexpected login methods %+v", methods)
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	u, _, err := user.CreateAnonymousUser("changer")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Register(u.ID, "changer", "first password"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ChangePassword(u.ID, "not the password", "second password"); err == nil {
+		t.Error("expected the current password to be required")
+	}
+
+	if err := ChangePassword(u.ID, "first password", "second password"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Login("changer", "second password", "203.0.113.1"); err != nil {
+		t.Error("expected to log in with the new password", err)
+	}
+}
+
+func TestLoginAttemptsAreLimited(t *testing.T) {
+	for i := 0; i < loginAttemptsPerMinute; i++ {
+		if _, err := Login("limited", "guess", "203.0.113.2"); err != ErrInvalidLogin {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := Login("limited", "guess", "203.0.113.2"); err != ErrTooManyAttempts {
+		t.Error("expected repeated logins to be limited", err)
+	}
+
+	for i := 0; i < loginAttemptsPerIPAddressMinute; i++ {
+		if _, err := Login(fmt.Sprintf("sprayed%d", i), "guess", "203.0.113.3"); err != ErrInvalidLogin {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := Login("sprayed", "guess", "203.0.113.3"); err != ErrTooManyAttempts {
+		t.Error("expected logins to many usernames from one address to be limited", err)
+	}
+}
+
+func TestLoginLimitersEvictOldest(t *testing.T) {
+	limiters := newLoginLimiters(1)
+	start := time.Now()
+
+	if !limiters.allow("first", start) || limiters.allow("first", start) {
+		t.Fatal("expected a single attempt to be allowed")
+	}
+
+	for i := 1; i < maxTrackedLoginLimiters; i++ {
+		limiters.allow(fmt.Sprintf("key%d", i), start.Add(time.Second))
+	}
+
+	// A full set forgets the least recently used limiter rather than all of
+	// them.
+	limiters.allow("new", start.Add(2*time.Second))
+	if _, ok := limiters.limiters["first"]; ok {
+		t.Error("expected the least recently used limiter to be evicted")
+	}
+	if limiters.allow("key1", start.Add(2*time.Second)) {
+		t.Error("expected recently used limiters to be kept")
+	}
+}
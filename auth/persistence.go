@@ -36,6 +36,29 @@ func Setup(db *data.Datastore) {
 	if err != nil {
 		log.Fatalln(err)
 	}
+
+	createCredentialsTable()
+}
+
+func createCredentialsTable() {
+	// Credentials are secrets verified by this server, such as password
+	// hashes or passkey public keys. The identifier is the username for
+	// passwords and the credential ID for passkeys.
+	createTableSQL := `CREATE TABLE IF NOT EXISTS auth_credentials (
+		"identifier" TEXT NOT NULL,
+		"type" TEXT NOT NULL,
+		"user_id" TEXT NOT NULL,
+		"secret" TEXT NOT NULL,
+		"sign_count" INTEGER NOT NULL DEFAULT 0,
+		"timestamp" DATE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+		"last_used" DATETIME,
+		PRIMARY KEY (type, identifier),
+		FOREIGN KEY(user_id) REFERENCES users(id)
+	);CREATE INDEX IF NOT EXISTS auth_credentials_user_id ON auth_credentials (user_id);`
+
+	if _, err := _datastore.DB.Exec(createTableSQL); err != nil {
+		log.Fatalln(err)
+	}
 }
 
 // AddAuth will add an external authentication token and type for a user.
@@ -21,4 +21,7 @@ var (
 
 	// HLSStoragePath is the directory HLS video is written to.
 	HLSStoragePath = filepath.Join(DataDirectory, "hls")
+
+	// OfflineClipsDirectory is the directory alternate offline clips are read from.
+	OfflineClipsDirectory = filepath.Join(DataDirectory, "offline")
 )
@@ -32,7 +32,11 @@ type Defaults struct {
 	FederationUsername      string
 	FederationGoLiveMessage string
 
+	NotificationReconnectSuppressionMinutes int
+
 	ChatEstablishedUserModeTimeDuration time.Duration
+	ChatRetentionHours                  int
+	ChatRaidProtection                  models.RaidProtectionConfiguration
 }
 
 // GetDefaults will return default configuration values.
@@ -61,6 +65,18 @@ func GetDefaults() Defaults {
 		StreamKey:      "abc123",
 
 		ChatEstablishedUserModeTimeDuration: time.Minute * 15,
+		ChatRetentionHours:                  2,
+		ChatRaidProtection: models.RaidProtectionConfiguration{
+			Enabled:                   false,
+			WindowSeconds:             10,
+			NewUserThreshold:          20,
+			ConnectionThreshold:       50,
+			DuplicateMessageThreshold: 8,
+			CooldownSeconds:           300,
+			SlowModeDuration:          10,
+			BlockLinks:                true,
+			EstablishedUsersOnly:      true,
+		},
 
 		StreamVariants: []models.StreamOutputVariant{
 			{
@@ -73,5 +89,7 @@ func GetDefaults() Defaults {
 
 		FederationUsername:      "streamer",
 		FederationGoLiveMessage: "I've gone live!",
+
+		NotificationReconnectSuppressionMinutes: 10,
 	}
 }
@@ -0,0 +1,98 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/owncast/owncast/controllers"
+	"github.com/owncast/owncast/core"
+	"github.com/owncast/owncast/core/actions"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/router/middleware"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	defaultActionAuditLogLimit = 50
+	maxActionAuditLogLimit     = 500
+)
+
+// TriggerAction will perform a server action, such as an announcement or
+// disconnecting the broadcaster, and record it in the audit log.
+func TriggerAction(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	type triggerActionRequest struct {
+		Action     string                  `json:"action"`
+		Parameters models.ActionParameters `json:"parameters"`
+	}
+
+	var request triggerActionRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if !actions.IsValidAction(request.Action) {
+		controllers.BadRequestHandler(w, errors.New(request.Action+" is not a valid action"))
+		return
+	}
+
+	result, err := actions.Trigger(request.Action, request.Parameters, middleware.GetActor(r))
+	if errors.Is(err, actions.ErrRateLimited) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusTooManyRequests)
+		if err := json.NewEncoder(w).Encode(models.BaseAPIResponse{Success: false, Message: err.Error()}); err != nil {
+			log.Debugln(err)
+		}
+		return
+	} else if err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, result)
+}
+
+// GetActionAuditLog will return the most recent attempts to trigger actions.
+func GetActionAuditLog(w http.ResponseWriter, r *http.Request) {
+	limit := defaultActionAuditLogLimit
+	if l := r.URL.Query().Get("limit"); l != "" {
+		i, err := strconv.Atoi(l)
+		if err != nil || i < 1 {
+			controllers.BadRequestHandler(w, errors.New("limit must be a positive number"))
+			return
+		}
+		limit = i
+	}
+	if limit > maxActionAuditLogLimit {
+		limit = maxActionAuditLogLimit
+	}
+
+	entries, err := data.GetActionAuditLog(limit)
+	if err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, entries)
+}
+
+// GetOfflineClips will return the clips that can be shown while offline and
+// the one currently selected.
+func GetOfflineClips(w http.ResponseWriter, r *http.Request) {
+	type offlineClipsResponse struct {
+		Clips    []string `json:"clips"`
+		Selected string   `json:"selected"`
+	}
+
+	controllers.WriteResponse(w, offlineClipsResponse{
+		Clips:    core.GetOfflineClips(),
+		Selected: data.GetOfflineClip(),
+	})
+}
@@ -0,0 +1,54 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/owncast/owncast/controllers"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/models"
+)
+
+// SetOIDCConfiguration will set the OpenID Connect login configuration.
+func SetOIDCConfiguration(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	type request struct {
+		Value models.OIDCConfiguration `json:"value"`
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var config request
+	if err := decoder.Decode(&config); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update openid connect config with provided values")
+		return
+	}
+
+	config.Value.Issuer = strings.TrimSpace(config.Value.Issuer)
+	if config.Value.Enabled {
+		if !strings.HasPrefix(config.Value.Issuer, "https://") {
+			controllers.WriteSimpleResponse(w, false, "openid connect issuer must be an https url")
+			return
+		}
+
+		if config.Value.ClientID == "" {
+			controllers.WriteSimpleResponse(w, false, "openid connect client id is required")
+			return
+		}
+
+		if data.GetServerURL() == "" {
+			controllers.WriteSimpleResponse(w, false, "server url must be set to enable openid connect login")
+			return
+		}
+	}
+
+	if err := data.SetOIDCConfig(config.Value); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update openid connect config with provided values")
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "updated openid connect config with provided values")
+}
@@ -8,12 +8,17 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/owncast/owncast/controllers"
 	"github.com/owncast/owncast/core/chat"
 	"github.com/owncast/owncast/core/chat/events"
 	"github.com/owncast/owncast/core/data"
 	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/core/webhooks"
+	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/router/middleware"
 	"github.com/owncast/owncast/utils"
 	log "github.com/sirupsen/logrus"
 )
@@ -52,23 +57,51 @@ func UpdateMessageVisibility(w http.ResponseWriter, r *http.Request) {
 	controllers.WriteSimpleResponse(w, true, "changed")
 }
 
-// BanIPAddress will manually ban an IP address.
+// BanIPAddress will manually ban an IP address or a CIDR range, optionally
+// until a point in time.
 func BanIPAddress(w http.ResponseWriter, r *http.Request) {
 	if !requirePOST(w, r) {
 		return
 	}
 
-	configValue, success := getValueFromRequest(w, r)
-	if !success {
+	var request struct {
+		Value     string     `json:"value"`
+		Reason    string     `json:"reason"`
+		ExpiresAt *time.Time `json:"expiresAt"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
 		controllers.WriteSimpleResponse(w, false, "unable to ban IP address")
 		return
 	}
 
-	if err := data.BanIPAddress(configValue.Value.(string), "manually added"); err != nil {
+	if request.ExpiresAt != nil && !request.ExpiresAt.After(time.Now()) {
+		controllers.WriteSimpleResponse(w, false, "ban expiry must be in the future")
+		return
+	}
+
+	reason := strings.TrimSpace(request.Reason)
+	if reason == "" {
+		reason = "manually added"
+	}
+
+	if _, err := data.NormalizeIPAddressBan(request.Value); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	if err := data.BanIPAddress(request.Value, reason, middleware.GetActor(r), request.ExpiresAt); err != nil {
+		log.Errorln(err)
 		controllers.WriteSimpleResponse(w, false, "error saving IP address ban")
 		return
 	}
 
+	webhooks.SendIPAddressBanEvent(models.IPAddressBanned, webhooks.WebhookIPAddressBan{
+		IPAddress: request.Value,
+		Reason:    reason,
+		Actor:     middleware.GetActor(r),
+		ExpiresAt: request.ExpiresAt,
+	})
+
 	controllers.WriteSimpleResponse(w, true, "IP address banned")
 }
 
@@ -84,11 +117,17 @@ func UnBanIPAddress(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := data.RemoveIPAddressBan(configValue.Value.(string)); err != nil {
+	address := configValue.Value.(string)
+	if err := data.RemoveIPAddressBan(address); err != nil {
 		controllers.WriteSimpleResponse(w, false, "error removing IP address ban")
 		return
 	}
 
+	webhooks.SendIPAddressBanEvent(models.IPAddressUnbanned, webhooks.WebhookIPAddressBan{
+		IPAddress: address,
+		Actor:     middleware.GetActor(r),
+	})
+
 	controllers.WriteSimpleResponse(w, true, "IP address unbanned")
 }
 
@@ -148,30 +187,15 @@ func UpdateUserEnabled(w http.ResponseWriter, r *http.Request) {
 
 	// Forcefully disconnect the user from the chat
 	if !request.Enabled {
-		clients, err := chat.GetClientsForUser(request.UserID)
-		if len(clients) == 0 {
-			// Nothing to do
-			return
-		}
-
-		if err != nil {
-			log.Errorln("error fetching clients for user: ", err)
+		if err := chat.DisconnectUser(request.UserID, middleware.GetActor(r)); err != nil {
+			log.Errorln("error disconnecting user: ", err)
 			controllers.WriteSimpleResponse(w, false, err.Error())
 			return
 		}
+	}
 
-		chat.DisconnectClients(clients)
-		disconnectedUser := user.GetUserByID(request.UserID)
-		_ = chat.SendSystemAction(fmt.Sprintf("**%s** has been removed from chat.", disconnectedUser.DisplayName), true)
-
-		// Ban this user's IP address.
-		for _, client := range clients {
-			ipAddress := client.IPAddress
-			reason := fmt.Sprintf("Banning of %s", disconnectedUser.DisplayName)
-			if err := data.BanIPAddress(ipAddress, reason); err != nil {
-				log.Errorln("error banning IP address: ", err)
-			}
-		}
+	if u := user.GetUserByID(request.UserID); u != nil {
+		webhooks.SendUserEnabledEvent(u, request.Enabled, middleware.GetActor(r))
 	}
 
 	controllers.WriteSimpleResponse(w, true, fmt.Sprintf("%s enabled: %t", request.UserID, request.Enabled))
@@ -185,6 +209,18 @@ func GetDisabledUsers(w http.ResponseWriter, r *http.Request) {
 	controllers.WriteResponse(w, users)
 }
 
+// GetBanEvasionSuspects will return the new anonymous users that share an IP
+// address with a disabled user.
+func GetBanEvasionSuspects(w http.ResponseWriter, r *http.Request) {
+	suspects, err := chat.GetBanEvasionSuspects()
+	if err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, suspects)
+}
+
 // UpdateUserModerator will set the moderator status for a user ID.
 func UpdateUserModerator(w http.ResponseWriter, r *http.Request) {
 	type request struct {
@@ -216,9 +252,36 @@ func UpdateUserModerator(w http.ResponseWriter, r *http.Request) {
 		log.Debugln(err)
 	}
 
+	if u := user.GetUserByID(req.UserID); u != nil {
+		webhooks.SendModeratorEvent(u, req.IsModerator, middleware.GetActor(r))
+	}
+
 	controllers.WriteSimpleResponse(w, true, fmt.Sprintf("%s is moderator: %t", req.UserID, req.IsModerator))
 }
 
+// DeleteUserData will remove what is stored about a chat user and anonymize
+// their messages on their behalf.
+func DeleteUserData(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	var request struct {
+		UserID string `json:"userId"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if err := controllers.RemoveAllUserData(request.UserID); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "user data deleted")
+}
+
 // GetModerators will return a list of moderator users.
 func GetModerators(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -227,12 +290,25 @@ func GetModerators(w http.ResponseWriter, r *http.Request) {
 	controllers.WriteResponse(w, users)
 }
 
-// GetChatMessages returns all of the chat messages, unfiltered.
+// GetChatMessages returns all of the chat messages, unfiltered. A page of
+// messages can be requested, filtered or searched with query parameters.
 func GetChatMessages(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
-	messages := chat.GetChatModerationHistory()
-	controllers.WriteResponse(w, messages)
+	if len(r.URL.Query()) == 0 {
+		messages := chat.GetChatModerationHistory()
+		controllers.WriteResponse(w, messages)
+		return
+	}
+
+	query, err := controllers.GetChatHistoryQuery(r)
+	if err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+	query.IncludeHidden = true
+
+	controllers.WriteResponse(w, chat.GetChatHistoryPage(query))
 }
 
 // SendSystemMessage will send an official "SYSTEM" message to chat on behalf of your server.
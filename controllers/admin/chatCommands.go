@@ -0,0 +1,151 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/owncast/owncast/controllers"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/models"
+	"github.com/teris-io/shortid"
+)
+
+// GetChatCommands will return all the chat commands.
+func GetChatCommands(w http.ResponseWriter, r *http.Request) {
+	commands, err := data.GetChatCommands()
+	if err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, commands)
+}
+
+// CreateChatCommand will add a new chat command.
+func CreateChatCommand(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	var command models.ChatCommand
+	if err := json.NewDecoder(r.Body).Decode(&command); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	command.ID = shortid.MustGenerate()
+	command.CreatedAt = time.Now()
+
+	if err := validateChatCommand(&command); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if err := data.InsertChatCommand(command); err != nil {
+		controllers.BadRequestHandler(w, errors.New("a command with this trigger already exists"))
+		return
+	}
+
+	controllers.WriteResponse(w, command)
+}
+
+// UpdateChatCommand will change an existing chat command.
+func UpdateChatCommand(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	var command models.ChatCommand
+	if err := json.NewDecoder(r.Body).Decode(&command); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if err := validateChatCommand(&command); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if err := data.UpdateChatCommand(command); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "chat command updated")
+}
+
+// DeleteChatCommand will remove a chat command.
+func DeleteChatCommand(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	var request struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if err := data.DeleteChatCommand(request.ID); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "chat command deleted")
+}
+
+// SetChatCommandBot will set the integration that replies to chat commands.
+// An empty value replies as the system.
+func SetChatCommandBot(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	configValue, success := getValueFromRequest(w, r)
+	if !success {
+		return
+	}
+
+	id, ok := configValue.Value.(string)
+	if !ok {
+		controllers.WriteSimpleResponse(w, false, "unable to update chat command bot")
+		return
+	}
+
+	if id != "" {
+		if _, err := user.GetExternalAPIUserByID(id); err != nil {
+			controllers.WriteSimpleResponse(w, false, err.Error())
+			return
+		}
+	}
+
+	if err := data.SetChatCommandBotID(id); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "chat command bot updated")
+}
+
+func validateChatCommand(command *models.ChatCommand) error {
+	command.Trigger = models.NormalizeChatCommandTrigger(command.Trigger)
+	command.Response = strings.TrimSpace(command.Response)
+
+	if err := command.Validate(); err != nil {
+		return err
+	}
+
+	if command.RoleID != "" {
+		if _, err := user.GetRole(command.RoleID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -16,7 +16,9 @@ import (
 	"github.com/owncast/owncast/core/chat"
 	"github.com/owncast/owncast/core/data"
 	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/core/webhooks"
 	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/router/middleware"
 	"github.com/owncast/owncast/utils"
 	log "github.com/sirupsen/logrus"
 	"github.com/teris-io/shortid"
@@ -69,6 +71,7 @@ func SetStreamTitle(w http.ResponseWriter, r *http.Request) {
 	}
 
 	value := configValue.Value.(string)
+	previousValue := data.GetStreamTitle()
 
 	if err := data.SetStreamTitle(value); err != nil {
 		controllers.WriteSimpleResponse(w, false, err.Error())
@@ -77,6 +80,9 @@ func SetStreamTitle(w http.ResponseWriter, r *http.Request) {
 	if value != "" {
 		sendSystemChatAction(fmt.Sprintf("Stream title changed to **%s**", value), true)
 	}
+	if value != previousValue {
+		webhooks.SendStreamTitleChangedEvent(previousValue, value, middleware.GetActor(r))
+	}
 	controllers.WriteSimpleResponse(w, true, "changed")
 }
 
@@ -711,6 +717,132 @@ func SetChatJoinMessagesEnabled(w http.ResponseWriter, r *http.Request) {
 	controllers.WriteSimpleResponse(w, true, "chat join message status updated")
 }
 
+// SetChatRetentionHours will set how many hours of chat messages are kept.
+func SetChatRetentionHours(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	configValue, success := getValueFromRequest(w, r)
+	if !success {
+		return
+	}
+
+	hours, ok := configValue.Value.(float64)
+	if !ok || hours < 0 {
+		controllers.WriteSimpleResponse(w, false, "chat retention must be a number of hours, or 0 to keep all messages")
+		return
+	}
+
+	if err := data.SetChatRetentionHours(int(hours)); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "chat retention updated")
+}
+
+// SetChatSlowModeDuration will set how many seconds chat users must wait
+// between messages.
+func SetChatSlowModeDuration(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	configValue, success := getValueFromRequest(w, r)
+	if !success {
+		return
+	}
+
+	seconds, ok := configValue.Value.(float64)
+	if !ok || seconds < 0 {
+		controllers.WriteSimpleResponse(w, false, "slow mode must be a number of seconds, or 0 to disable it")
+		return
+	}
+
+	if err := data.SetChatSlowModeDuration(int(seconds)); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "chat slow mode updated")
+}
+
+// SetChatLinksRestricted will set if only moderators and roles with the
+// permission can post links in chat.
+func SetChatLinksRestricted(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	configValue, success := getValueFromRequest(w, r)
+	if !success {
+		return
+	}
+
+	restricted, ok := configValue.Value.(bool)
+	if !ok {
+		controllers.WriteSimpleResponse(w, false, "unable to update chat link restriction")
+		return
+	}
+
+	if err := data.SetChatLinksRestricted(restricted); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "chat link restriction updated")
+}
+
+// SetChatBanEvasionDetection will set if moderators are told about new
+// anonymous users connecting from the IP address of a disabled user.
+func SetChatBanEvasionDetection(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	configValue, success := getValueFromRequest(w, r)
+	if !success {
+		return
+	}
+
+	enabled, ok := configValue.Value.(bool)
+	if !ok {
+		controllers.WriteSimpleResponse(w, false, "unable to update ban evasion detection")
+		return
+	}
+
+	if err := data.SetChatBanEvasionDetectionEnabled(enabled); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "ban evasion detection updated")
+}
+
+// SetChatRestrictedEmoji will set the custom emoji that only moderators and
+// roles with the permission can use.
+func SetChatRestrictedEmoji(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	var request struct {
+		Value []string `json:"value"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update restricted emoji with provided values")
+		return
+	}
+
+	if err := data.SetChatRestrictedEmoji(request.Value); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "restricted emoji updated")
+}
+
 func requirePOST(w http.ResponseWriter, r *http.Request) bool {
 	if r.Method != controllers.POST {
 		controllers.WriteSimpleResponse(w, false, r.Method+" not supported")
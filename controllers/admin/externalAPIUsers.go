@@ -16,8 +16,14 @@ type deleteExternalAPIUserRequest struct {
 }
 
 type createExternalAPIUserRequest struct {
-	Name   string   `json:"name"`
-	Scopes []string `json:"scopes"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	AllowedIPs []string   `json:"allowedIPs"`
+}
+
+type rotateExternalAPIUserRequest struct {
+	Token string `json:"token"`
 }
 
 // CreateExternalAPIUser will generate a 3rd party access token.
@@ -35,6 +41,16 @@ func CreateExternalAPIUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !user.HasValidAllowedIPs(request.AllowedIPs) {
+		controllers.BadRequestHandler(w, errors.New("one or more invalid ip addresses provided"))
+		return
+	}
+
+	if request.ExpiresAt != nil && request.ExpiresAt.Before(time.Now()) {
+		controllers.BadRequestHandler(w, errors.New("expiry must be in the future"))
+		return
+	}
+
 	token, err := utils.GenerateAccessToken()
 	if err != nil {
 		controllers.InternalErrorHandler(w, err)
@@ -43,7 +59,7 @@ func CreateExternalAPIUser(w http.ResponseWriter, r *http.Request) {
 
 	color := utils.GenerateRandomDisplayColor()
 
-	if err := user.InsertExternalAPIUser(token, request.Name, color, request.Scopes); err != nil {
+	if err := user.InsertExternalAPIUser(token, request.Name, color, request.Scopes, request.ExpiresAt, request.AllowedIPs); err != nil {
 		controllers.InternalErrorHandler(w, err)
 		return
 	}
@@ -56,9 +72,44 @@ func CreateExternalAPIUser(w http.ResponseWriter, r *http.Request) {
 		Scopes:       request.Scopes,
 		CreatedAt:    time.Now(),
 		LastUsedAt:   nil,
+		ExpiresAt:    request.ExpiresAt,
+		AllowedIPs:   request.AllowedIPs,
 	})
 }
 
+// RotateExternalAPIUser will replace a 3rd party access token with a new one
+// without removing the integration.
+func RotateExternalAPIUser(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request rotateExternalAPIUserRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if request.Token == "" {
+		controllers.BadRequestHandler(w, errors.New("must provide a token"))
+		return
+	}
+
+	newToken, err := utils.GenerateAccessToken()
+	if err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	if err := user.RotateExternalAPIUserAccessToken(request.Token, newToken); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, map[string]string{"accessToken": newToken})
+}
+
 // GetExternalAPIUsers will return all 3rd party access tokens.
 func GetExternalAPIUsers(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -2,11 +2,17 @@ package admin
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"net/url"
 
 	"github.com/owncast/owncast/controllers"
 	"github.com/owncast/owncast/core/data"
 	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/notifications"
+	"github.com/owncast/owncast/notifications/email"
+	"github.com/owncast/owncast/notifications/mastodon"
+	"github.com/owncast/owncast/utils"
 )
 
 // SetDiscordNotificationConfiguration will set the discord notification configuration.
@@ -26,6 +32,11 @@ func SetDiscordNotificationConfiguration(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if err := notifications.ValidateTemplate(config.Value.GoLiveTemplate); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
 	if err := data.SetDiscordConfig(config.Value); err != nil {
 		controllers.WriteSimpleResponse(w, false, "unable to update discord config with provided values")
 		return
@@ -51,6 +62,11 @@ func SetBrowserNotificationConfiguration(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if err := notifications.ValidateTemplate(config.Value.GoLiveTemplate); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
 	if err := data.SetBrowserPushConfig(config.Value); err != nil {
 		controllers.WriteSimpleResponse(w, false, "unable to update browser push config with provided values")
 		return
@@ -76,6 +92,11 @@ func SetTwitterConfiguration(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := notifications.ValidateTemplate(config.Value.GoLiveTemplate); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
 	if err := data.SetTwitterConfiguration(config.Value); err != nil {
 		controllers.WriteSimpleResponse(w, false, "unable to update twitter config with provided values")
 		return
@@ -83,3 +104,437 @@ func SetTwitterConfiguration(w http.ResponseWriter, r *http.Request) {
 
 	controllers.WriteSimpleResponse(w, true, "updated twitter config with provided values")
 }
+
+// SetMatrixNotificationConfiguration will set the matrix notification configuration.
+func SetMatrixNotificationConfiguration(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	type request struct {
+		Value models.MatrixConfiguration `json:"value"`
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var config request
+	if err := decoder.Decode(&config); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update matrix config with provided values")
+		return
+	}
+
+	if config.Value.Enabled {
+		if _, err := url.ParseRequestURI(config.Value.Homeserver); err != nil {
+			controllers.WriteSimpleResponse(w, false, "a valid matrix homeserver url is required")
+			return
+		}
+
+		if config.Value.AccessToken == "" || len(config.Value.Rooms) == 0 {
+			controllers.WriteSimpleResponse(w, false, "a matrix access token and at least one room are required")
+			return
+		}
+
+		if err := notifications.ValidateTemplate(config.Value.GoLiveTemplate); err != nil {
+			controllers.WriteSimpleResponse(w, false, err.Error())
+			return
+		}
+	}
+
+	if err := data.SetMatrixConfig(config.Value); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update matrix config with provided values")
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "updated matrix config with provided values")
+}
+
+// SetEmailNotificationConfiguration will set the email notification configuration.
+func SetEmailNotificationConfiguration(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	type request struct {
+		Value models.EmailConfiguration `json:"value"`
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var config request
+	if err := decoder.Decode(&config); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update email config with provided values")
+		return
+	}
+
+	if config.Value.Enabled {
+		if err := email.Validate(config.Value); err != nil {
+			controllers.WriteSimpleResponse(w, false, err.Error())
+			return
+		}
+	}
+
+	for _, text := range []string{config.Value.GoLiveSubject, config.Value.GoLiveTemplate} {
+		if err := notifications.ValidateTemplate(text); err != nil {
+			controllers.WriteSimpleResponse(w, false, err.Error())
+			return
+		}
+	}
+
+	if err := data.SetEmailConfig(config.Value); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update email config with provided values")
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "updated email config with provided values")
+}
+
+// SetNtfyNotificationConfiguration will set the ntfy notification configuration.
+func SetNtfyNotificationConfiguration(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	type request struct {
+		Value models.NtfyConfiguration `json:"value"`
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var config request
+	if err := decoder.Decode(&config); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update ntfy config with provided values")
+		return
+	}
+
+	if config.Value.Enabled {
+		if config.Value.Topic == "" {
+			controllers.WriteSimpleResponse(w, false, "an ntfy topic is required")
+			return
+		}
+
+		if config.Value.Server != "" {
+			if _, err := url.ParseRequestURI(config.Value.Server); err != nil {
+				controllers.WriteSimpleResponse(w, false, "a valid ntfy server url is required")
+				return
+			}
+		}
+
+		if err := notifications.ValidateTemplate(config.Value.GoLiveTemplate); err != nil {
+			controllers.WriteSimpleResponse(w, false, err.Error())
+			return
+		}
+	}
+
+	if err := data.SetNtfyConfig(config.Value); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update ntfy config with provided values")
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "updated ntfy config with provided values")
+}
+
+// SetGotifyNotificationConfiguration will set the gotify notification configuration.
+func SetGotifyNotificationConfiguration(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	type request struct {
+		Value models.GotifyConfiguration `json:"value"`
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var config request
+	if err := decoder.Decode(&config); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update gotify config with provided values")
+		return
+	}
+
+	if config.Value.Enabled {
+		if _, err := url.ParseRequestURI(config.Value.Server); err != nil {
+			controllers.WriteSimpleResponse(w, false, "a valid gotify server url is required")
+			return
+		}
+
+		if config.Value.AppToken == "" {
+			controllers.WriteSimpleResponse(w, false, "a gotify application token is required")
+			return
+		}
+
+		if err := notifications.ValidateTemplate(config.Value.GoLiveTemplate); err != nil {
+			controllers.WriteSimpleResponse(w, false, err.Error())
+			return
+		}
+	}
+
+	if err := data.SetGotifyConfig(config.Value); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update gotify config with provided values")
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "updated gotify config with provided values")
+}
+
+// SetTelegramNotificationConfiguration will set the telegram notification configuration.
+func SetTelegramNotificationConfiguration(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	type request struct {
+		Value models.TelegramConfiguration `json:"value"`
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var config request
+	if err := decoder.Decode(&config); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update telegram config with provided values")
+		return
+	}
+
+	if config.Value.Enabled {
+		if config.Value.BotToken == "" || len(config.Value.ChatIDs) == 0 {
+			controllers.WriteSimpleResponse(w, false, "a telegram bot token and at least one chat are required")
+			return
+		}
+
+		if err := notifications.ValidateTemplate(config.Value.GoLiveTemplate); err != nil {
+			controllers.WriteSimpleResponse(w, false, err.Error())
+			return
+		}
+	}
+
+	if err := data.SetTelegramConfig(config.Value); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update telegram config with provided values")
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "updated telegram config with provided values")
+}
+
+// SetMastodonNotificationConfiguration will set the mastodon notification configuration.
+func SetMastodonNotificationConfiguration(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	type request struct {
+		Value models.MastodonConfiguration `json:"value"`
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var config request
+	if err := decoder.Decode(&config); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update mastodon config with provided values")
+		return
+	}
+
+	if config.Value.Enabled {
+		if _, err := url.ParseRequestURI(config.Value.Server); err != nil {
+			controllers.WriteSimpleResponse(w, false, "a valid mastodon server url is required")
+			return
+		}
+
+		if config.Value.AccessToken == "" {
+			controllers.WriteSimpleResponse(w, false, "a mastodon access token is required")
+			return
+		}
+
+		if _, valid := utils.FindInSlice(mastodon.Visibilities, config.Value.Visibility); config.Value.Visibility != "" && !valid {
+			controllers.WriteSimpleResponse(w, false, "invalid mastodon visibility: "+config.Value.Visibility)
+			return
+		}
+
+		if err := notifications.ValidateTemplate(config.Value.GoLiveTemplate); err != nil {
+			controllers.WriteSimpleResponse(w, false, err.Error())
+			return
+		}
+	}
+
+	if err := data.SetMastodonConfig(config.Value); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update mastodon config with provided values")
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "updated mastodon config with provided values")
+}
+
+// SendTestNotification will send the go-live notification to a single
+// notification channel using its saved configuration.
+func SendTestNotification(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	type request struct {
+		Channel string `json:"channel"`
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var req request
+	if err := decoder.Decode(&req); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to send test notification")
+		return
+	}
+
+	notifier, err := notifications.New(data.GetDatastore())
+	if err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	if err := notifier.SendTestNotification(req.Channel); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "sent test "+req.Channel+" notification")
+}
+
+// SetNotificationThrottleConfiguration will set how often go-live
+// notifications can be sent.
+func SetNotificationThrottleConfiguration(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	type request struct {
+		Value models.NotificationThrottleConfiguration `json:"value"`
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var config request
+	if err := decoder.Decode(&config); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update notification throttle with provided values")
+		return
+	}
+
+	if config.Value.ReconnectSuppressionMinutes < 0 {
+		controllers.WriteSimpleResponse(w, false, "reconnect suppression minutes can not be negative")
+		return
+	}
+
+	channels := notifications.GetChannelNames()
+	for channel, minutes := range config.Value.MinimumIntervals {
+		if _, valid := utils.FindInSlice(channels, channel); !valid {
+			controllers.WriteSimpleResponse(w, false, "invalid notification channel: "+channel)
+			return
+		}
+
+		if minutes < 0 {
+			controllers.WriteSimpleResponse(w, false, "minimum intervals can not be negative")
+			return
+		}
+	}
+
+	if err := data.SetNotificationThrottleConfig(config.Value); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update notification throttle with provided values")
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "updated notification throttle with provided values")
+}
+
+// PreviewNotification will render the go-live message of a notification
+// channel with the current stream details, using either the provided
+// template or the channel's saved one. Email previews include the subject.
+func PreviewNotification(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	type request struct {
+		Channel  string `json:"channel"`
+		Template string `json:"template"`
+		Subject  string `json:"subject"`
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var req request
+	if err := decoder.Decode(&req); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	message, err := notifications.PreviewGoLiveMessage(req.Channel, req.Template)
+	if err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	response := map[string]string{"message": message}
+	if req.Channel == "email" {
+		subject, err := notifications.PreviewEmailSubject(req.Subject)
+		if err != nil {
+			controllers.BadRequestHandler(w, err)
+			return
+		}
+		response["subject"] = subject
+	}
+
+	controllers.WriteResponse(w, response)
+}
+
+// GetBrowserPushStats will return how many browsers are subscribed to each
+// topic and how recent browser push notifications were delivered.
+func GetBrowserPushStats(w http.ResponseWriter, r *http.Request) {
+	subscribers, err := notifications.GetNotificationTopicCounts(notifications.BrowserPushNotification)
+	if err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	type response struct {
+		Subscribers map[string]int               `json:"subscribers"`
+		Deliveries  []models.BrowserPushDelivery `json:"deliveries"`
+	}
+
+	controllers.WriteResponse(w, response{
+		Subscribers: subscribers,
+		Deliveries:  data.GetBrowserPushDeliveries(),
+	})
+}
+
+// SendBrowserPushAnnouncement will push a message to every browser
+// subscribed to announcements or reminders.
+func SendBrowserPushAnnouncement(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	type request struct {
+		Topic string `json:"topic"`
+		Title string `json:"title"`
+		Body  string `json:"body"`
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var req request
+	if err := decoder.Decode(&req); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if req.Topic == "" {
+		req.Topic = notifications.AnnouncementsTopic
+	}
+
+	if req.Topic != notifications.AnnouncementsTopic && req.Topic != notifications.RemindersTopic {
+		controllers.BadRequestHandler(w, errors.New("only announcements and reminders can be sent"))
+		return
+	}
+
+	if req.Body == "" {
+		controllers.BadRequestHandler(w, errors.New("a message body is required"))
+		return
+	}
+
+	if req.Title == "" {
+		req.Title = data.GetServerName()
+	}
+
+	delivery, err := notifications.SendBrowserPushAnnouncement(req.Topic, req.Title, req.Body)
+	if err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, delivery)
+}
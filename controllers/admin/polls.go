@@ -0,0 +1,103 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/owncast/owncast/controllers"
+	"github.com/owncast/owncast/core/chat"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
+)
+
+type createPollRequest struct {
+	Question string   `json:"question"`
+	Options  []string `json:"options"`
+	Duration int      `json:"duration"` // seconds
+}
+
+type endPollRequest struct {
+	ID string `json:"id"`
+}
+
+// CreatePoll will start a new chat poll on behalf of the admin.
+func CreatePoll(w http.ResponseWriter, r *http.Request) {
+	createPoll(w, r, "admin")
+}
+
+// ModeratorCreatePoll will start a new chat poll on behalf of a moderator.
+func ModeratorCreatePoll(w http.ResponseWriter, r *http.Request) {
+	createdBy := ""
+	if u := user.GetUserByToken(r.URL.Query().Get("accessToken")); u != nil {
+		createdBy = u.DisplayName
+	}
+
+	createPoll(w, r, createdBy)
+}
+
+// ExternalCreatePoll will start a new chat poll on behalf of a 3rd party integration.
+func ExternalCreatePoll(integration user.ExternalAPIUser, w http.ResponseWriter, r *http.Request) {
+	createPoll(w, r, integration.DisplayName)
+}
+
+func createPoll(w http.ResponseWriter, r *http.Request, createdBy string) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	var request createPollRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	poll, err := chat.StartPoll(request.Question, request.Options, time.Duration(request.Duration)*time.Second, createdBy)
+	if err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, poll)
+}
+
+// EndPoll will close a running chat poll before its duration has elapsed.
+func EndPoll(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	var request endPollRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if err := chat.EndPoll(request.ID); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "poll ended")
+}
+
+// ExternalEndPoll will close a running chat poll on behalf of a 3rd party integration.
+func ExternalEndPoll(integration user.ExternalAPIUser, w http.ResponseWriter, r *http.Request) {
+	EndPoll(w, r)
+}
+
+// GetPolls will return all current and past chat polls with their results.
+func GetPolls(w http.ResponseWriter, r *http.Request) {
+	polls, err := data.GetPolls()
+	if err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, polls)
+}
+
+// ExternalGetPolls will return all current and past chat polls with their results.
+func ExternalGetPolls(integration user.ExternalAPIUser, w http.ResponseWriter, r *http.Request) {
+	GetPolls(w, r)
+}
@@ -0,0 +1,87 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/owncast/owncast/controllers"
+	"github.com/owncast/owncast/core/chat"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/router/middleware"
+	log "github.com/sirupsen/logrus"
+)
+
+// SetChatRaidProtection will set the thresholds that automatically protect
+// chat from a raid and the restrictions applied while it is protected.
+func SetChatRaidProtection(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	var request struct {
+		Value models.RaidProtectionConfiguration `json:"value"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update raid protection with provided values")
+		return
+	}
+
+	if err := request.Value.Validate(); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	if err := data.SetChatRaidProtectionConfig(request.Value); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "raid protection updated")
+}
+
+// GetRaidProtectionStatus will return if chat is being protected from a raid.
+func GetRaidProtectionStatus(w http.ResponseWriter, r *http.Request) {
+	controllers.WriteResponse(w, chat.GetRaidProtectionStatus())
+}
+
+// StartRaidProtection will protect chat from a raid for the configured
+// cooldown.
+func StartRaidProtection(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	var request struct {
+		Reason string `json:"reason"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&request) // a reason is optional
+
+	reason := request.Reason
+	if reason == "" {
+		reason = "started by " + middleware.GetActor(r)
+	}
+
+	if err := chat.StartRaidProtection(reason); err != nil {
+		log.Errorln(err)
+		controllers.WriteSimpleResponse(w, false, "unable to start raid protection")
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "raid protection started")
+}
+
+// EndRaidProtection will stop protecting chat before the cooldown ends.
+func EndRaidProtection(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	if err := chat.EndRaidProtection(); err != nil {
+		log.Errorln(err)
+		controllers.WriteSimpleResponse(w, false, "unable to end raid protection")
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "raid protection ended")
+}
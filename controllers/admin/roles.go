@@ -0,0 +1,141 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/owncast/owncast/controllers"
+	"github.com/owncast/owncast/core/chat"
+	"github.com/owncast/owncast/core/user"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetRoles will return all the chat user roles.
+func GetRoles(w http.ResponseWriter, r *http.Request) {
+	roles, err := user.GetRoles()
+	if err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, roles)
+}
+
+// CreateRole will define a new chat user role.
+func CreateRole(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	var request user.Role
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	role, err := user.CreateRole(request)
+	if err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, role)
+}
+
+// UpdateRole will change the name, color, badge or permissions of a role.
+func UpdateRole(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	var request user.Role
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if err := user.UpdateRole(request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	// The badges of everybody with this role have changed.
+	for _, u := range user.GetUsersWithRole(request.ID) {
+		if err := chat.SendConnectedClientInfoToUser(u.ID); err != nil {
+			log.Debugln(err)
+		}
+	}
+
+	controllers.WriteSimpleResponse(w, true, "role updated")
+}
+
+// DeleteRole will remove a role from every user and delete it.
+func DeleteRole(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	var request struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	users := user.GetUsersWithRole(request.ID)
+
+	if err := user.DeleteRole(request.ID); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	for _, u := range users {
+		if err := chat.SendConnectedClientInfoToUser(u.ID); err != nil {
+			log.Debugln(err)
+		}
+	}
+
+	controllers.WriteSimpleResponse(w, true, "role deleted")
+}
+
+// GetUsersWithRole will return the users assigned a single role.
+func GetUsersWithRole(w http.ResponseWriter, r *http.Request) {
+	roleID := r.URL.Query().Get("roleId")
+	if _, err := user.GetRole(roleID); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, user.GetUsersWithRole(roleID))
+}
+
+// UpdateUserRole will assign or unassign a role for a single user.
+func UpdateUserRole(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	var request struct {
+		UserID   string `json:"userId"`
+		RoleID   string `json:"roleId"`
+		Assigned bool   `json:"assigned"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if err := user.SetRole(request.UserID, request.RoleID, request.Assigned); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	// Update the clients for this user to know about the role change.
+	if err := chat.SendConnectedClientInfoToUser(request.UserID); err != nil {
+		log.Debugln(err)
+	}
+
+	controllers.WriteSimpleResponse(w, true, fmt.Sprintf("%s has role %s: %t", request.UserID, request.RoleID, request.Assigned))
+}
@@ -15,6 +15,38 @@ import (
 
 // GetServerConfig gets the config details of the server.
 func GetServerConfig(w http.ResponseWriter, r *http.Request) {
+	writeServerConfig(w, getServerConfig())
+}
+
+// GetServerConfigForIntegrations gets the config details of the server
+// without the stream key or any other secrets, which remain admin only.
+func GetServerConfigForIntegrations(w http.ResponseWriter, r *http.Request) {
+	response := getServerConfig()
+
+	response.StreamKey = ""
+	response.S3.AccessKey = ""
+	response.S3.Secret = ""
+	response.OIDC.ClientSecret = ""
+
+	notifications := &response.Notifications
+	notifications.Discord.Webhook = ""
+	notifications.Twitter.APIKey = ""
+	notifications.Twitter.APISecret = ""
+	notifications.Twitter.AccessToken = ""
+	notifications.Twitter.AccessTokenSecret = ""
+	notifications.Twitter.BearerToken = ""
+	notifications.Matrix.AccessToken = ""
+	notifications.Email.Username = ""
+	notifications.Email.Password = ""
+	notifications.Ntfy.AccessToken = ""
+	notifications.Gotify.AppToken = ""
+	notifications.Telegram.BotToken = ""
+	notifications.Mastodon.AccessToken = ""
+
+	writeServerConfig(w, response)
+}
+
+func getServerConfig() serverConfigAdminResponse {
 	ffmpeg := utils.ValidatedFfmpegPath(data.GetFfMpegPath())
 	usernameBlocklist := data.GetForbiddenUsernameList()
 	usernameSuggestions := data.GetSuggestedUsernamesList()
@@ -55,6 +87,13 @@ func GetServerConfig(w http.ResponseWriter, r *http.Request) {
 		ChatJoinMessagesEnabled: data.GetChatJoinMessagesEnabled(),
 		SocketHostOverride:      data.GetWebsocketOverrideHost(),
 		ChatEstablishedUserMode: data.GetChatEstbalishedUsersOnlyMode(),
+		ChatRetentionHours:      data.GetChatRetentionHours(),
+		ChatSlowModeDuration:    data.GetChatSlowModeDuration(),
+		ChatLinksRestricted:     data.GetChatLinksRestricted(),
+		ChatRestrictedEmoji:     data.GetChatRestrictedEmoji(),
+		ChatBanEvasionDetection: data.GetChatBanEvasionDetectionEnabled(),
+		ChatRaidProtection:      data.GetChatRaidProtectionConfig(),
+		ChatCommandBotID:        data.GetChatCommandBotID(),
 		VideoSettings: videoSettings{
 			VideoQualityVariants: videoQualityVariants,
 			LatencyLevel:         data.GetStreamLatencyLevel().Level,
@@ -78,12 +117,24 @@ func GetServerConfig(w http.ResponseWriter, r *http.Request) {
 			BlockedDomains: data.GetBlockedFederatedDomains(),
 		},
 		Notifications: notificationsConfigResponse{
-			Discord: data.GetDiscordConfig(),
-			Browser: data.GetBrowserPushConfig(),
-			Twitter: data.GetTwitterConfiguration(),
+			Discord:  data.GetDiscordConfig(),
+			Browser:  data.GetBrowserPushConfig(),
+			Twitter:  data.GetTwitterConfiguration(),
+			Matrix:   data.GetMatrixConfig(),
+			Email:    data.GetEmailConfig(),
+			Ntfy:     data.GetNtfyConfig(),
+			Gotify:   data.GetGotifyConfig(),
+			Telegram: data.GetTelegramConfig(),
+			Mastodon: data.GetMastodonConfig(),
+			Throttle: data.GetNotificationThrottleConfig(),
 		},
+		OIDC: data.GetOIDCConfig(),
 	}
 
+	return response
+}
+
+func writeServerConfig(w http.ResponseWriter, response serverConfigAdminResponse) {
 	w.Header().Set("Content-Type", "application/json")
 	middleware.DisableCache(w)
 
@@ -93,26 +144,34 @@ func GetServerConfig(w http.ResponseWriter, r *http.Request) {
 }
 
 type serverConfigAdminResponse struct {
-	InstanceDetails         webConfigResponse           `json:"instanceDetails"`
-	FFmpegPath              string                      `json:"ffmpegPath"`
-	StreamKey               string                      `json:"streamKey"`
-	WebServerPort           int                         `json:"webServerPort"`
-	WebServerIP             string                      `json:"webServerIP"`
-	RTMPServerPort          int                         `json:"rtmpServerPort"`
-	S3                      models.S3                   `json:"s3"`
-	VideoSettings           videoSettings               `json:"videoSettings"`
-	YP                      yp                          `json:"yp"`
-	ChatDisabled            bool                        `json:"chatDisabled"`
-	ChatJoinMessagesEnabled bool                        `json:"chatJoinMessagesEnabled"`
-	ChatEstablishedUserMode bool                        `json:"chatEstablishedUserMode"`
-	ExternalActions         []models.ExternalAction     `json:"externalActions"`
-	SupportedCodecs         []string                    `json:"supportedCodecs"`
-	VideoCodec              string                      `json:"videoCodec"`
-	ForbiddenUsernames      []string                    `json:"forbiddenUsernames"`
-	Federation              federationConfigResponse    `json:"federation"`
-	SuggestedUsernames      []string                    `json:"suggestedUsernames"`
-	SocketHostOverride      string                      `json:"socketHostOverride,omitempty"`
-	Notifications           notificationsConfigResponse `json:"notifications"`
+	InstanceDetails         webConfigResponse                  `json:"instanceDetails"`
+	FFmpegPath              string                             `json:"ffmpegPath"`
+	StreamKey               string                             `json:"streamKey"`
+	WebServerPort           int                                `json:"webServerPort"`
+	WebServerIP             string                             `json:"webServerIP"`
+	RTMPServerPort          int                                `json:"rtmpServerPort"`
+	S3                      models.S3                          `json:"s3"`
+	VideoSettings           videoSettings                      `json:"videoSettings"`
+	YP                      yp                                 `json:"yp"`
+	ChatDisabled            bool                               `json:"chatDisabled"`
+	ChatJoinMessagesEnabled bool                               `json:"chatJoinMessagesEnabled"`
+	ChatEstablishedUserMode bool                               `json:"chatEstablishedUserMode"`
+	ChatRetentionHours      int                                `json:"chatRetentionHours"`
+	ChatSlowModeDuration    int                                `json:"chatSlowModeDuration"`
+	ChatLinksRestricted     bool                               `json:"chatLinksRestricted"`
+	ChatRestrictedEmoji     []string                           `json:"chatRestrictedEmoji"`
+	ChatBanEvasionDetection bool                               `json:"chatBanEvasionDetection"`
+	ChatRaidProtection      models.RaidProtectionConfiguration `json:"chatRaidProtection"`
+	ChatCommandBotID        string                             `json:"chatCommandBotId,omitempty"`
+	ExternalActions         []models.ExternalAction            `json:"externalActions"`
+	SupportedCodecs         []string                           `json:"supportedCodecs"`
+	VideoCodec              string                             `json:"videoCodec"`
+	ForbiddenUsernames      []string                           `json:"forbiddenUsernames"`
+	Federation              federationConfigResponse           `json:"federation"`
+	SuggestedUsernames      []string                           `json:"suggestedUsernames"`
+	SocketHostOverride      string                             `json:"socketHostOverride,omitempty"`
+	Notifications           notificationsConfigResponse        `json:"notifications"`
+	OIDC                    models.OIDCConfiguration           `json:"oidc"`
 }
 
 type videoSettings struct {
@@ -150,7 +209,14 @@ type federationConfigResponse struct {
 }
 
 type notificationsConfigResponse struct {
-	Browser models.BrowserNotificationConfiguration `json:"browser"`
-	Discord models.DiscordConfiguration             `json:"discord"`
-	Twitter models.TwitterConfiguration             `json:"twitter"`
+	Browser  models.BrowserNotificationConfiguration  `json:"browser"`
+	Discord  models.DiscordConfiguration              `json:"discord"`
+	Twitter  models.TwitterConfiguration              `json:"twitter"`
+	Matrix   models.MatrixConfiguration               `json:"matrix"`
+	Email    models.EmailConfiguration                `json:"email"`
+	Ntfy     models.NtfyConfiguration                 `json:"ntfy"`
+	Gotify   models.GotifyConfiguration               `json:"gotify"`
+	Telegram models.TelegramConfiguration             `json:"telegram"`
+	Mastodon models.MastodonConfiguration             `json:"mastodon"`
+	Throttle models.NotificationThrottleConfiguration `json:"throttle"`
 }
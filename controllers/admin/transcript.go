@@ -0,0 +1,99 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/owncast/owncast/controllers"
+	"github.com/owncast/owncast/core/chat"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetBroadcasts will return all past and current broadcasts.
+func GetBroadcasts(w http.ResponseWriter, r *http.Request) {
+	broadcasts, err := data.GetBroadcasts()
+	if err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, broadcasts)
+}
+
+// GetChatTranscript will export the chat events of a single broadcast as
+// json, csv, vtt or srt. The most recent broadcast is used if none is given.
+func GetChatTranscript(w http.ResponseWriter, r *http.Request) {
+	broadcast, err := getRequestedBroadcast(r)
+	if err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	end := time.Now()
+	if broadcast.EndedAt != nil {
+		end = *broadcast.EndedAt
+	}
+
+	transcript := chat.GetChatTranscript(broadcast.StartedAt, end)
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+
+	var contentType string
+	switch format {
+	case "json":
+		contentType = "application/json"
+	case "csv":
+		contentType = "text/csv"
+	case "vtt":
+		contentType = "text/vtt"
+	case "srt":
+		contentType = "application/x-subrip"
+	default:
+		controllers.BadRequestHandler(w, fmt.Errorf("unsupported transcript format %s", format))
+		return
+	}
+
+	filename := fmt.Sprintf("chat-%s.%s", broadcast.StartedAt.Format("2006-01-02-150405"), format)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+
+	switch format {
+	case "json":
+		controllers.WriteResponse(w, struct {
+			Broadcast *models.Broadcast      `json:"broadcast"`
+			Events    []chat.TranscriptEntry `json:"events"`
+		}{broadcast, transcript})
+		return
+	case "csv":
+		err = chat.WriteTranscriptCSV(w, transcript)
+	case "vtt":
+		err = chat.WriteTranscriptWebVTT(w, transcript)
+	case "srt":
+		err = chat.WriteTranscriptSRT(w, transcript)
+	}
+
+	if err != nil {
+		log.Errorln("unable to write chat transcript", err)
+	}
+}
+
+func getRequestedBroadcast(r *http.Request) (*models.Broadcast, error) {
+	broadcastID := r.URL.Query().Get("broadcast")
+	if broadcastID == "" {
+		return data.GetLatestBroadcast()
+	}
+
+	id, err := strconv.Atoi(broadcastID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid broadcast id %s", broadcastID)
+	}
+
+	return data.GetBroadcast(id)
+}
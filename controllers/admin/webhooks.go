@@ -4,13 +4,20 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/owncast/owncast/controllers"
 	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/webhooks"
 	"github.com/owncast/owncast/models"
 )
 
+const (
+	defaultWebhookDeliveriesLimit = 50
+	maxWebhookDeliveriesLimit     = 200
+)
+
 type deleteWebhookRequest struct {
 	ID int `json:"id"`
 }
@@ -18,6 +25,7 @@ type deleteWebhookRequest struct {
 type createWebhookRequest struct {
 	URL    string             `json:"url"`
 	Events []models.EventType `json:"events"`
+	models.WebhookOptions
 }
 
 // CreateWebhook will add a single webhook.
@@ -29,27 +37,94 @@ func CreateWebhook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Verify all the scopes provided are valid
-	if !models.HasValidEvents(request.Events) {
-		controllers.BadRequestHandler(w, errors.New("one or more invalid event provided"))
+	if err := validateWebhookRequest(request); err != nil {
+		controllers.BadRequestHandler(w, err)
 		return
 	}
 
-	newWebhookID, err := data.InsertWebhook(request.URL, request.Events)
+	newWebhookID, secret, err := data.InsertWebhook(request.URL, request.Events, request.WebhookOptions)
 	if err != nil {
 		controllers.InternalErrorHandler(w, err)
 		return
 	}
 
-	controllers.WriteResponse(w, models.Webhook{
-		ID:        newWebhookID,
-		URL:       request.URL,
-		Events:    request.Events,
-		Timestamp: time.Now(),
-		LastUsed:  nil,
+	// The secret is only ever returned here and when it is rotated.
+	type createWebhookResponse struct {
+		models.Webhook
+		Secret string `json:"secret"`
+	}
+
+	controllers.WriteResponse(w, createWebhookResponse{
+		Webhook: models.Webhook{
+			ID:             newWebhookID,
+			URL:            request.URL,
+			Events:         request.Events,
+			Timestamp:      time.Now(),
+			LastUsed:       nil,
+			WebhookOptions: request.WebhookOptions,
+		},
+		Secret: secret,
 	})
 }
 
+// UpdateWebhook will change where a single webhook is sent, which events are
+// sent to it and its options.
+func UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != controllers.POST {
+		controllers.WriteSimpleResponse(w, false, r.Method+" not supported")
+		return
+	}
+
+	type updateWebhookRequest struct {
+		ID int `json:"id"`
+		createWebhookRequest
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request updateWebhookRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if err := validateWebhookRequest(request.createWebhookRequest); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if err := data.UpdateWebhook(request.ID, request.URL, request.Events, request.WebhookOptions); err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	webhook, err := data.GetWebhook(request.ID)
+	if err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, webhook)
+}
+
+func validateWebhookRequest(request createWebhookRequest) error {
+	// Verify all the scopes provided are valid
+	if !models.HasValidEvents(request.Events) {
+		return errors.New("one or more invalid event provided")
+	}
+
+	if err := request.WebhookOptions.Validate(); err != nil {
+		return err
+	}
+
+	if request.Template != "" {
+		if err := webhooks.ValidateTemplate(request.Template); err != nil {
+			return errors.New("invalid template: " + err.Error())
+		}
+	}
+
+	return nil
+}
+
 // GetWebhooks will return all webhooks.
 func GetWebhooks(w http.ResponseWriter, r *http.Request) {
 	webhooks, err := data.GetWebhooks()
@@ -82,3 +157,133 @@ func DeleteWebhook(w http.ResponseWriter, r *http.Request) {
 
 	controllers.WriteSimpleResponse(w, true, "deleted webhook")
 }
+
+// RotateWebhookSecret will replace the secret used to sign requests to a
+// single webhook.
+func RotateWebhookSecret(w http.ResponseWriter, r *http.Request) {
+	if r.Method != controllers.POST {
+		controllers.WriteSimpleResponse(w, false, r.Method+" not supported")
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request deleteWebhookRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	secret, err := data.RotateWebhookSecret(request.ID)
+	if err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	type rotateWebhookSecretResponse struct {
+		ID     int    `json:"id"`
+		Secret string `json:"secret"`
+	}
+
+	controllers.WriteResponse(w, rotateWebhookSecretResponse{
+		ID:     request.ID,
+		Secret: secret,
+	})
+}
+
+// GetWebhookDeliveries will return the most recent webhook deliveries,
+// optionally only those to a single webhook.
+func GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	webhookID := 0
+	if id := params.Get("webhookId"); id != "" {
+		i, err := strconv.Atoi(id)
+		if err != nil {
+			controllers.BadRequestHandler(w, errors.New("webhookId must be a number"))
+			return
+		}
+		webhookID = i
+	}
+
+	limit := defaultWebhookDeliveriesLimit
+	if l := params.Get("limit"); l != "" {
+		i, err := strconv.Atoi(l)
+		if err != nil || i < 1 {
+			controllers.BadRequestHandler(w, errors.New("limit must be a positive number"))
+			return
+		}
+		limit = i
+	}
+	if limit > maxWebhookDeliveriesLimit {
+		limit = maxWebhookDeliveriesLimit
+	}
+
+	deliveries, err := data.GetWebhookDeliveries(webhookID, limit)
+	if err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, deliveries)
+}
+
+// RedeliverWebhook will send the event of a previous webhook delivery again.
+func RedeliverWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != controllers.POST {
+		controllers.WriteSimpleResponse(w, false, r.Method+" not supported")
+		return
+	}
+
+	type redeliverWebhookRequest struct {
+		ID string `json:"id"`
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request redeliverWebhookRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	delivery, err := webhooks.Redeliver(request.ID)
+	if errors.Is(err, data.ErrWebhookDeliveryNotFound) {
+		controllers.BadRequestHandler(w, err)
+		return
+	} else if err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, delivery)
+}
+
+// SetWebhookEnabled will enable or disable sending events to a webhook.
+func SetWebhookEnabled(w http.ResponseWriter, r *http.Request) {
+	if r.Method != controllers.POST {
+		controllers.WriteSimpleResponse(w, false, r.Method+" not supported")
+		return
+	}
+
+	type setWebhookEnabledRequest struct {
+		ID      int  `json:"id"`
+		Enabled bool `json:"enabled"`
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request setWebhookEnabledRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if err := data.SetWebhookEnabled(request.ID, request.Enabled); err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	if request.Enabled {
+		controllers.WriteSimpleResponse(w, true, "webhook enabled")
+	} else {
+		controllers.WriteSimpleResponse(w, true, "webhook disabled")
+	}
+}
@@ -0,0 +1,102 @@
+package oidc
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+
+	"github.com/owncast/owncast/auth"
+	"github.com/owncast/owncast/auth/oidc"
+	"github.com/owncast/owncast/controllers"
+	"github.com/owncast/owncast/core/chat"
+	"github.com/owncast/owncast/core/user"
+	log "github.com/sirupsen/logrus"
+)
+
+// StartAuthFlow will begin the OpenID Connect flow for the current user.
+func StartAuthFlow(u user.User, w http.ResponseWriter, r *http.Request) {
+	type response struct {
+		Redirect string `json:"redirect"`
+	}
+
+	accessToken := r.URL.Query().Get("accessToken")
+
+	redirectURL, err := oidc.StartAuthFlow(u.ID, accessToken, u.DisplayName)
+	if err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	redirectResponse := response{
+		Redirect: redirectURL.String(),
+	}
+	controllers.WriteResponse(w, redirectResponse)
+}
+
+// HandleRedirect will handle the redirect from the identity provider to
+// continue the auth flow.
+func HandleRedirect(w http.ResponseWriter, r *http.Request) {
+	if providerError := r.URL.Query().Get("error"); providerError != "" {
+		log.Debugln("identity provider returned an error:", providerError)
+		msg := fmt.Sprintf("Unable to complete authentication. <a href=\"/\">Go back.</a><hr/> %s", html.EscapeString(providerError))
+		_ = controllers.WriteString(w, msg, http.StatusBadRequest)
+		return
+	}
+
+	state := r.URL.Query().Get("state")
+	code := r.URL.Query().Get("code")
+	request, identity, err := oidc.HandleCallbackCode(code, state)
+	if err != nil {
+		log.Debugln(err)
+		msg := fmt.Sprintf("Unable to complete authentication. <a href=\"/\">Go back.</a><hr/> %s", html.EscapeString(err.Error()))
+		_ = controllers.WriteString(w, msg, http.StatusBadRequest)
+		return
+	}
+
+	// Check if a user with this auth already exists, if so, log them in.
+	if u := auth.GetUserByAuth(identity.AuthToken(), auth.OIDC); u != nil {
+		log.Debugln("user with provided openid connect identity already exists, logging them in")
+
+		// Update the current user's access token to point to the existing user id.
+		if err := user.SetAccessTokenToOwner(request.CurrentAccessToken, u.ID); err != nil {
+			controllers.WriteSimpleResponse(w, false, err.Error())
+			return
+		}
+
+		loginMessage := fmt.Sprintf("**%s** is now authenticated as **%s**", request.DisplayName, u.DisplayName)
+		if err := chat.SendSystemAction(loginMessage, true); err != nil {
+			log.Errorln(err)
+		}
+
+		http.Redirect(w, r, "/", http.StatusTemporaryRedirect)
+
+		return
+	}
+
+	// Otherwise, save this as new auth.
+	log.Debug("openid connect identity does not already exist, saving it as a new one for the current user")
+	if err := auth.AddAuth(request.UserID, identity.AuthToken(), auth.OIDC); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	// Update the current user's authenticated flag so we can show it in
+	// the chat UI.
+	if err := user.SetUserAsAuthenticated(request.UserID); err != nil {
+		log.Errorln(err)
+	}
+
+	// Take the display name from the identity provider when nobody else
+	// is already using it.
+	if identity.DisplayName != "" && identity.DisplayName != request.DisplayName {
+		if available, err := user.IsDisplayNameAvailable(identity.DisplayName); err == nil && available {
+			if err := user.ChangeUsername(request.UserID, identity.DisplayName); err != nil {
+				log.Errorln(err)
+			} else if err := chat.SendConnectedClientInfoToUser(request.UserID); err != nil {
+				log.Errorln(err)
+			}
+		}
+	}
+
+	http.Redirect(w, r, "/", http.StatusTemporaryRedirect)
+}
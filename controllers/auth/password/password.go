@@ -0,0 +1,123 @@
+package password
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/owncast/owncast/auth/password"
+	"github.com/owncast/owncast/controllers"
+	"github.com/owncast/owncast/core/chat"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+type credentialsRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type changePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword"`
+	NewPassword     string `json:"newPassword"`
+}
+
+// Register will add a username and password to the current user so they
+// can log back in to it from another browser.
+func Register(u user.User, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		controllers.WriteSimpleResponse(w, false, r.Method+" not supported")
+		return
+	}
+
+	var request credentialsRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	if err := password.Register(u.ID, request.Username, request.Password); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	// Update the current user's authenticated flag so we can show it in
+	// the chat UI.
+	if err := user.SetUserAsAuthenticated(u.ID); err != nil {
+		log.Errorln(err)
+	}
+
+	if err := chat.SendConnectedClientInfoToUser(u.ID); err != nil {
+		log.Errorln(err)
+	}
+
+	controllers.WriteSimpleResponse(w, true, "registered")
+}
+
+// Login will verify a username and password and log the current browser in
+// to the user they belong to.
+func Login(u user.User, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		controllers.WriteSimpleResponse(w, false, r.Method+" not supported")
+		return
+	}
+
+	var request credentialsRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	userID, err := password.Login(request.Username, request.Password, utils.GetClientIPAddress(r))
+	if err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	if userID == u.ID {
+		controllers.WriteSimpleResponse(w, true, "already logged in")
+		return
+	}
+
+	existingUser := user.GetUserByID(userID)
+	if existingUser == nil || !existingUser.IsEnabled() {
+		controllers.WriteSimpleResponse(w, false, password.ErrInvalidLogin.Error())
+		return
+	}
+
+	// Update the current user's access token to point to the existing user id.
+	accessToken := r.URL.Query().Get("accessToken")
+	if err := user.SetAccessTokenToOwner(accessToken, userID); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	loginMessage := fmt.Sprintf("**%s** is now authenticated as **%s**", u.DisplayName, existingUser.DisplayName)
+	if err := chat.SendSystemAction(loginMessage, true); err != nil {
+		log.Errorln(err)
+	}
+
+	controllers.WriteSimpleResponse(w, true, "logged in")
+}
+
+// ChangePassword will replace the password of the current user.
+func ChangePassword(u user.User, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		controllers.WriteSimpleResponse(w, false, r.Method+" not supported")
+		return
+	}
+
+	var request changePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	if err := password.ChangePassword(u.ID, request.CurrentPassword, request.NewPassword); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "password changed")
+}
@@ -2,7 +2,11 @@ package controllers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/owncast/owncast/core/chat"
 	"github.com/owncast/owncast/core/user"
@@ -26,7 +30,19 @@ func getChatMessages(w http.ResponseWriter, r *http.Request) {
 
 	switch r.Method {
 	case http.MethodGet:
-		messages := chat.GetChatHistory()
+		query, err := GetChatHistoryQuery(r)
+		if err != nil {
+			BadRequestHandler(w, err)
+			return
+		}
+
+		// Only cursor pagination is available to the public. Filtering and
+		// search are for moderation.
+		messages := chat.GetChatHistoryPage(chat.HistoryQuery{
+			Before: query.Before,
+			After:  query.After,
+			Limit:  query.Limit,
+		})
 
 		if err := json.NewEncoder(w).Encode(messages); err != nil {
 			log.Debugln(err)
@@ -39,6 +55,54 @@ func getChatMessages(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetChatHistoryQuery will return the chat history pagination and filters
+// requested in the query string.
+func GetChatHistoryQuery(r *http.Request) (chat.HistoryQuery, error) {
+	params := r.URL.Query()
+
+	query := chat.HistoryQuery{
+		Before: params.Get("before"),
+		After:  params.Get("after"),
+		UserID: params.Get("userId"),
+		RoleID: params.Get("role"),
+		Search: params.Get("q"),
+	}
+
+	if limit := params.Get("limit"); limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil || l < 1 {
+			return query, errors.New("limit must be a positive number")
+		}
+		query.Limit = l
+	}
+
+	if since := params.Get("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return query, errors.New("since must be an RFC3339 timestamp")
+		}
+		query.Since = &t
+	}
+
+	if until := params.Get("until"); until != "" {
+		t, err := time.Parse(time.RFC3339, until)
+		if err != nil {
+			return query, errors.New("until must be an RFC3339 timestamp")
+		}
+		query.Until = &t
+	}
+
+	for _, types := range params["type"] {
+		for _, eventType := range strings.Split(types, ",") {
+			if eventType = strings.TrimSpace(eventType); eventType != "" {
+				query.EventTypes = append(query.EventTypes, eventType)
+			}
+		}
+	}
+
+	return query, nil
+}
+
 // RegisterAnonymousChatUser will register a new user.
 func RegisterAnonymousChatUser(w http.ResponseWriter, r *http.Request) {
 	if r.Method != POST {
@@ -72,6 +136,8 @@ func RegisterAnonymousChatUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	chat.RecordNewChatUser()
+
 	response := registerAnonymousUserResponse{
 		ID:          newUser.ID,
 		AccessToken: accessToken,
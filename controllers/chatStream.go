@@ -0,0 +1,163 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/owncast/owncast/core/chat"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/router/middleware"
+	log "github.com/sirupsen/logrus"
+)
+
+// How often a comment is sent to keep idle event streams open through proxies.
+const chatEventStreamKeepAlive = 30 * time.Second
+
+// The most messages sent when a client resumes an event stream.
+const chatEventStreamResumeLimit = 100
+
+// chatHistoryGapEvent is sent when resuming an event stream could not send
+// every message that was missed. After is the ID of the last message that
+// was sent, if any, and the rest can be fetched from the chat history.
+type chatHistoryGapEvent struct {
+	Type  string `json:"type"`
+	After string `json:"after,omitempty"`
+}
+
+const chatHistoryGap = "HISTORY_GAP"
+
+// GetChatEventStream will stream chat events as Server-Sent Events without
+// joining chat.
+func GetChatEventStream(w http.ResponseWriter, r *http.Request) {
+	middleware.EnableCors(w)
+
+	if data.GetChatDisabled() {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	streamChatEvents(w, r, true)
+}
+
+// ExternalGetChatEventStream will stream chat events as Server-Sent Events
+// to an integration, even if chat is disabled.
+func ExternalGetChatEventStream(integration user.ExternalAPIUser, w http.ResponseWriter, r *http.Request) {
+	middleware.EnableCors(w)
+	streamChatEvents(w, r, false)
+}
+
+func streamChatEvents(w http.ResponseWriter, r *http.Request, limited bool) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		InternalErrorHandler(w, errors.New("streaming is not supported"))
+		return
+	}
+
+	chatEvents, unsubscribe, err := chat.SubscribeToChatEvents(limited)
+	if err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// Browsers resume with a header, other clients may not be able to set one.
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("lastEventId")
+	}
+
+	if lastEventID != "" && !resumeChatEvents(w, lastEventID) {
+		return
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(chatEventStreamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case payload, ok := <-chatEvents:
+			if !ok {
+				// Too far behind to keep up.
+				return
+			}
+			if err := writeChatEvent(w, payload); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// resumeChatEvents will send the messages sent after an event, and a gap
+// event if they could not all be sent. It returns false if the client has
+// gone away.
+func resumeChatEvents(w http.ResponseWriter, lastEventID string) bool {
+	messages, complete := chat.GetChatEventsAfter(lastEventID, chatEventStreamResumeLimit)
+
+	var lastSentID string
+	for _, message := range messages {
+		if message == nil {
+			continue
+		}
+		payload, err := json.Marshal(message)
+		if err != nil {
+			log.Debugln(err)
+			continue
+		}
+		if err := writeChatEvent(w, payload); err != nil {
+			return false
+		}
+
+		var sent struct {
+			ID string `json:"id"`
+		}
+		_ = json.Unmarshal(payload, &sent)
+		lastSentID = sent.ID
+	}
+
+	if complete {
+		return true
+	}
+
+	payload, err := json.Marshal(chatHistoryGapEvent{Type: chatHistoryGap, After: lastSentID})
+	if err != nil {
+		log.Debugln(err)
+		return true
+	}
+	return writeChatEvent(w, payload) == nil
+}
+
+// writeChatEvent will write a single encoded chat event, using its ID as the
+// event ID so clients can resume from it.
+func writeChatEvent(w http.ResponseWriter, payload []byte) error {
+	var event struct {
+		ID string `json:"id"`
+	}
+	_ = json.Unmarshal(payload, &event)
+
+	if event.ID != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", event.ID); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "data: %s\n\n", payload)
+	return err
+}
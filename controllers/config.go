@@ -50,8 +50,14 @@ type notificationsConfigResponse struct {
 	Browser browserNotificationsConfigResponse `json:"browser"`
 }
 
+type oidcConfigResponse struct {
+	Enabled bool   `json:"enabled"`
+	Name    string `json:"name,omitempty"`
+}
+
 type authenticationConfigResponse struct {
-	IndieAuthEnabled bool `json:"indieAuthEnabled"`
+	IndieAuthEnabled bool               `json:"indieAuthEnabled"`
+	OIDC             oidcConfigResponse `json:"oidc"`
 }
 
 // GetWebConfig gets the status of the server.
@@ -102,8 +108,13 @@ func GetWebConfig(w http.ResponseWriter, r *http.Request) {
 		},
 	}
 
+	oidcConfig := data.GetOIDCConfig()
 	authenticationResponse := authenticationConfigResponse{
 		IndieAuthEnabled: data.GetServerURL() != "",
+		OIDC: oidcConfigResponse{
+			Enabled: oidcConfig.Enabled && data.GetServerURL() != "",
+			Name:    oidcConfig.Name,
+		},
 	}
 
 	configuration := webConfigResponse{
@@ -2,10 +2,15 @@ package controllers
 
 import (
 	"encoding/json"
+	"fmt"
+	"html"
 	"net/http"
+	"net/url"
 
+	"github.com/owncast/owncast/core/data"
 	"github.com/owncast/owncast/core/user"
 	"github.com/owncast/owncast/notifications"
+	"github.com/owncast/owncast/router/middleware"
 
 	"github.com/owncast/owncast/utils"
 
@@ -25,6 +30,9 @@ func RegisterForLiveNotifications(u user.User, w http.ResponseWriter, r *http.Re
 		Channel string `json:"channel"`
 		// Destination is the target of the notification in the above channel.
 		Destination string `json:"destination"`
+		// Topics are what a browser wants to be pushed. Go-live
+		// notifications are pushed if it is empty.
+		Topics []string `json:"topics"`
 	}
 
 	decoder := json.NewDecoder(r.Body)
@@ -36,16 +44,90 @@ func RegisterForLiveNotifications(u user.User, w http.ResponseWriter, r *http.Re
 	}
 
 	// Make sure the requested channel is one we want to handle.
-	validTypes := []string{notifications.BrowserPushNotification}
+	validTypes := []string{notifications.BrowserPushNotification, notifications.EmailNotification}
 	_, validChannel := utils.FindInSlice(validTypes, req.Channel)
 	if !validChannel {
 		WriteSimpleResponse(w, false, "invalid notification channel: "+req.Channel)
 		return
 	}
 
-	if err := notifications.AddNotification(req.Channel, req.Destination); err != nil {
+	// Email addresses are only added once they have been confirmed.
+	if req.Channel == notifications.EmailNotification {
+		if err := notifications.RequestEmailSubscription(req.Destination, u.ID, utils.GetClientIPAddress(r)); err != nil {
+			WriteSimpleResponse(w, false, err.Error())
+			return
+		}
+
+		WriteSimpleResponse(w, true, "check your email to confirm your subscription")
+		return
+	}
+
+	for _, topic := range req.Topics {
+		if _, validTopic := utils.FindInSlice(notifications.Topics, topic); !validTopic {
+			WriteSimpleResponse(w, false, "invalid notification topic: "+topic)
+			return
+		}
+	}
+
+	// Registering again replaces the topics a browser chose before.
+	if err := notifications.RemoveNotificationForChannel(req.Channel, req.Destination); err != nil {
+		log.Errorln(err)
+	}
+
+	if err := notifications.AddNotificationForTopics(req.Channel, req.Destination, u.ID, req.Topics); err != nil {
 		log.Errorln(err)
 		WriteSimpleResponse(w, false, "unable to save notification")
 		return
 	}
 }
+
+// ConfirmEmailNotifications will subscribe an email address to go-live
+// emails when the link sent to it is followed.
+func ConfirmEmailNotifications(w http.ResponseWriter, r *http.Request) {
+	if err := notifications.ConfirmEmailSubscription(r.URL.Query().Get("token")); err != nil {
+		writeEmailNotificationsPage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeEmailNotificationsPage(w, http.StatusOK, "You will be emailed when "+data.GetServerName()+" goes live.")
+}
+
+// UnsubscribeEmailNotifications will stop sending go-live emails to an
+// address. Following the link shows a form, so link scanners do not
+// unsubscribe anyone, and the form or a mail client's one-click unsubscribe
+// (RFC 8058) POST to it.
+func UnsubscribeEmailNotifications(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		writeEmailUnsubscribeForm(w, r.URL.Query().Get("token"))
+		return
+	case POST:
+	default:
+		WriteSimpleResponse(w, false, r.Method+" not supported")
+		return
+	}
+
+	if err := notifications.UnsubscribeEmail(r.URL.Query().Get("token")); err != nil {
+		writeEmailNotificationsPage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeEmailNotificationsPage(w, http.StatusOK, "You will no longer be emailed when "+data.GetServerName()+" goes live.")
+}
+
+func writeEmailUnsubscribeForm(w http.ResponseWriter, token string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	middleware.DisableCache(w)
+	serverName := html.EscapeString(data.GetServerName())
+	action := html.EscapeString("/api/notifications/email/unsubscribe?token=" + url.QueryEscape(token))
+	_, _ = fmt.Fprintf(w, "<!DOCTYPE html><html><head><meta charset=\"utf-8\"><title>%[1]s</title></head><body><form method=\"post\" action=\"%[2]s\"><p>Stop emailing me when %[1]s goes live?</p><button type=\"submit\">Unsubscribe</button></form></body></html>",
+		serverName, action)
+}
+
+func writeEmailNotificationsPage(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	middleware.DisableCache(w)
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintf(w, "<!DOCTYPE html><html><head><meta charset=\"utf-8\"><title>%[1]s</title></head><body><p>%[2]s</p><p><a href=\"/\">%[1]s</a></p></body></html>",
+		html.EscapeString(data.GetServerName()), html.EscapeString(message))
+}
@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/owncast/owncast/auth"
+	"github.com/owncast/owncast/core/chat"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/notifications"
+	log "github.com/sirupsen/logrus"
+)
+
+type userDataExport struct {
+	ExportedAt    time.Time                    `json:"exportedAt"`
+	User          *user.User                   `json:"user"`
+	Profile       *user.Profile                `json:"profile"`
+	LoginMethods  []auth.Method                `json:"loginMethods"`
+	AccessTokens  []time.Time                  `json:"accessTokensCreatedAt"`
+	Notifications []notifications.Registration `json:"notifications"`
+	IPAddresses   []user.SeenIPAddress         `json:"ipAddresses"`
+	PollVotes     []models.PollVote            `json:"pollVotes"`
+	Messages      []interface{}                `json:"messages"`
+}
+
+// ExportChatUserData will return everything stored about the current chat
+// user as a JSON download.
+func ExportChatUserData(u user.User, w http.ResponseWriter, r *http.Request) {
+	export, err := getUserDataExport(u.ID)
+	if err != nil {
+		log.Errorln(err)
+		InternalErrorHandler(w, errors.New("unable to export user data"))
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=chat-user-%s.json", u.ID))
+	WriteResponse(w, export)
+}
+
+// DeleteChatUserData will remove what is stored about the current chat user
+// and anonymize their messages.
+func DeleteChatUserData(u user.User, w http.ResponseWriter, r *http.Request) {
+	if r.Method != POST {
+		WriteSimpleResponse(w, false, r.Method+" not supported")
+		return
+	}
+
+	if err := RemoveAllUserData(u.ID); err != nil {
+		log.Errorln(err)
+		InternalErrorHandler(w, errors.New("unable to delete user data"))
+		return
+	}
+
+	WriteSimpleResponse(w, true, "user data deleted")
+}
+
+// RemoveAllUserData will remove the notification registrations, logins,
+// access tokens, profile and roles of a user and anonymize their messages.
+func RemoveAllUserData(userID string) error {
+	if err := notifications.RemoveNotificationsForUser(userID); err != nil {
+		return fmt.Errorf("unable to remove notification registrations: %w", err)
+	}
+
+	if err := auth.RemoveAllForUser(userID); err != nil {
+		return fmt.Errorf("unable to remove logins: %w", err)
+	}
+
+	// Access tokens are removed last so a failure can be retried by the user.
+	if err := user.DeleteUserData(userID); err != nil {
+		return err
+	}
+
+	if err := chat.UserDataDeleted(userID); err != nil {
+		log.Debugln(err)
+	}
+
+	return nil
+}
+
+func getUserDataExport(userID string) (*userDataExport, error) {
+	u := user.GetUserByID(userID)
+	if u == nil {
+		return nil, errors.New("user not found")
+	}
+
+	export := &userDataExport{
+		ExportedAt: time.Now(),
+		User:       u,
+		Messages:   chat.GetMessagesForUser(userID),
+	}
+
+	var err error
+	if export.Profile, err = user.GetProfile(userID); err != nil {
+		return nil, err
+	}
+
+	if export.LoginMethods, err = auth.GetMethodsForUser(userID); err != nil {
+		return nil, err
+	}
+
+	if export.AccessTokens, err = user.GetAccessTokenCreationTimes(userID); err != nil {
+		return nil, err
+	}
+
+	if export.IPAddresses, err = user.GetIPAddressesForUser(userID); err != nil {
+		return nil, err
+	}
+
+	if export.Notifications, err = notifications.GetNotificationsForUser(userID); err != nil {
+		return nil, err
+	}
+
+	if export.PollVotes, err = data.GetPollVotesForUser(userID); err != nil {
+		return nil, err
+	}
+
+	return export, nil
+}
@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/owncast/owncast/auth"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/router/middleware"
+	"github.com/owncast/owncast/utils"
+)
+
+type userProfileResponse struct {
+	ID            string       `json:"id"`
+	DisplayName   string       `json:"displayName"`
+	DisplayColor  int          `json:"displayColor"`
+	CreatedAt     time.Time    `json:"createdAt"`
+	Authenticated bool         `json:"authenticated"`
+	IsModerator   bool         `json:"isModerator"`
+	Roles         []user.Role  `json:"roles,omitempty"`
+	Profile       user.Profile `json:"profile"`
+}
+
+// GetUserProfile will return the public profile of a single chat user.
+func GetUserProfile(w http.ResponseWriter, r *http.Request) {
+	middleware.EnableCors(w)
+
+	userID, err := utils.ReadRestURLParameter(r, "userId")
+	if err != nil {
+		BadRequestHandler(w, err)
+		return
+	}
+
+	u := user.GetUserByID(userID)
+	if u == nil || !u.IsEnabled() {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(j{"error": "user not found"})
+		return
+	}
+
+	profile, err := user.GetProfile(u.ID)
+	if err != nil {
+		InternalErrorHandler(w, err)
+		return
+	}
+
+	WriteResponse(w, userProfileResponse{
+		ID:            u.ID,
+		DisplayName:   u.DisplayName,
+		DisplayColor:  u.DisplayColor,
+		CreatedAt:     u.CreatedAt,
+		Authenticated: u.Authenticated,
+		IsModerator:   u.IsModerator(),
+		Roles:         u.Roles,
+		Profile:       *profile,
+	})
+}
+
+// SetUserProfile will update the profile of the current chat user.
+func SetUserProfile(u user.User, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteSimpleResponse(w, false, r.Method+" not supported")
+		return
+	}
+
+	var profile user.Profile
+	if err := json.NewDecoder(r.Body).Decode(&profile); err != nil {
+		BadRequestHandler(w, err)
+		return
+	}
+
+	if err := user.SetProfile(u.ID, profile); err != nil {
+		BadRequestHandler(w, err)
+		return
+	}
+
+	WriteSimpleResponse(w, true, "profile updated")
+}
+
+// GetLoginMethods will return every way the current chat user is able to
+// log back in to their account.
+func GetLoginMethods(u user.User, w http.ResponseWriter, r *http.Request) {
+	methods, err := auth.GetMethodsForUser(u.ID)
+	if err != nil {
+		InternalErrorHandler(w, errors.New("unable to get login methods"))
+		return
+	}
+
+	WriteResponse(w, methods)
+}
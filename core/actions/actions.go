@@ -0,0 +1,194 @@
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/owncast/owncast/activitypub/outbox"
+	"github.com/owncast/owncast/core"
+	"github.com/owncast/owncast/core/chat"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/rtmp"
+	"github.com/owncast/owncast/models"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// Each actor can trigger each action a few times in a row before being
+	// limited to this many times a minute.
+	actionsPerMinute = 10
+	actionBurst      = 3
+	// Forget every limiter once there are this many rather than letting the
+	// map grow forever.
+	maxTrackedLimiters = 1000
+)
+
+// ErrRateLimited is returned when an action was triggered too often.
+var ErrRateLimited = errors.New("this action has been triggered too often, try again later")
+
+var (
+	limiters     = make(map[string]*rate.Limiter)
+	limitersLock sync.Mutex
+)
+
+var handlers = map[string]func(params models.ActionParameters, actor string) (string, error){
+	models.ActionAnnouncement:          announce,
+	models.ActionStartPoll:             startPoll,
+	models.ActionSwitchOfflineClip:     switchOfflineClip,
+	models.ActionSetTags:               setTags,
+	models.ActionSetChatDisabled:       setChatDisabled,
+	models.ActionDisconnectBroadcaster: disconnectBroadcaster,
+}
+
+// IsValidAction will return if an action exists.
+func IsValidAction(action string) bool {
+	_, ok := handlers[action]
+	return ok
+}
+
+// Trigger will perform an action on behalf of an actor and record the
+// attempt in the audit log. It returns a description of what was done.
+func Trigger(action string, params models.ActionParameters, actor string) (string, error) {
+	handler, ok := handlers[action]
+	if !ok {
+		return "", fmt.Errorf("%s is not a valid action", action)
+	}
+
+	var result string
+	var err error
+	if getLimiter(actor, action).Allow() {
+		result, err = handler(params, actor)
+	} else {
+		err = ErrRateLimited
+	}
+
+	audit(action, params, actor, result, err)
+
+	return result, err
+}
+
+func getLimiter(actor string, action string) *rate.Limiter {
+	limitersLock.Lock()
+	defer limitersLock.Unlock()
+
+	key := actor + "\x00" + action
+	if limiter, ok := limiters[key]; ok {
+		return limiter
+	}
+
+	if len(limiters) >= maxTrackedLimiters {
+		limiters = make(map[string]*rate.Limiter)
+	}
+
+	limiter := rate.NewLimiter(rate.Every(time.Minute/actionsPerMinute), actionBurst)
+	limiters[key] = limiter
+	return limiter
+}
+
+func audit(action string, params models.ActionParameters, actor string, result string, err error) {
+	parameters, _ := json.Marshal(params)
+
+	entry := models.ActionAuditEntry{
+		Action:     action,
+		Actor:      actor,
+		Parameters: parameters,
+		Success:    err == nil,
+		Result:     result,
+		Timestamp:  time.Now(),
+	}
+	if err != nil {
+		entry.Result = err.Error()
+	}
+
+	log.Infof("Action %s triggered by %s: %s", action, actor, entry.Result)
+
+	if err := data.InsertActionAuditEntry(entry); err != nil {
+		log.Errorln(err)
+	}
+}
+
+func announce(params models.ActionParameters, _ string) (string, error) {
+	text := strings.TrimSpace(params.Text)
+	if text == "" {
+		return "", errors.New("text is required")
+	}
+
+	if err := chat.SendSystemMessage(text, false); err != nil {
+		return "", err
+	}
+
+	return "announcement sent", nil
+}
+
+func startPoll(params models.ActionParameters, actor string) (string, error) {
+	poll, err := chat.StartPoll(params.Question, params.Options, time.Duration(params.Duration)*time.Second, actor)
+	if err != nil {
+		return "", err
+	}
+
+	return "poll " + poll.ID + " started", nil
+}
+
+func switchOfflineClip(params models.ActionParameters, _ string) (string, error) {
+	if err := core.SetOfflineClip(params.Clip); err != nil {
+		return "", err
+	}
+
+	if params.Clip == "" {
+		return "offline clip reset to the default", nil
+	}
+
+	return "offline clip changed to " + params.Clip, nil
+}
+
+func setTags(params models.ActionParameters, _ string) (string, error) {
+	tags := make([]string, 0, len(params.Tags))
+	for _, tag := range params.Tags {
+		if tag = strings.TrimSpace(strings.TrimLeft(tag, "#")); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	if err := data.SetServerMetadataTags(tags); err != nil {
+		return "", err
+	}
+
+	// Update Fediverse followers about this change.
+	if err := outbox.UpdateFollowersWithAccountUpdates(); err != nil {
+		return "", err
+	}
+
+	return "tags changed", nil
+}
+
+func setChatDisabled(params models.ActionParameters, _ string) (string, error) {
+	disabled := !data.GetChatDisabled()
+	if params.Disabled != nil {
+		disabled = *params.Disabled
+	}
+
+	if err := data.SetChatDisabled(disabled); err != nil {
+		return "", err
+	}
+
+	if disabled {
+		return "chat disabled", nil
+	}
+
+	return "chat enabled", nil
+}
+
+func disconnectBroadcaster(_ models.ActionParameters, _ string) (string, error) {
+	if !core.GetStatus().Online {
+		return "", errors.New("no inbound stream connected")
+	}
+
+	rtmp.Disconnect()
+
+	return "inbound stream disconnected", nil
+}
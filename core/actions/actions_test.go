@@ -0,0 +1,37 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/owncast/owncast/models"
+)
+
+func TestLimiterIsPerActorAndAction(t *testing.T) {
+	for i := 0; i < actionBurst; i++ {
+		if !getLimiter("deck", models.ActionAnnouncement).Allow() {
+			t.Fatalf("trigger %d should be allowed", i+1)
+		}
+	}
+
+	if getLimiter("deck", models.ActionAnnouncement).Allow() {
+		t.Error("trigger after the burst should be limited")
+	}
+
+	if !getLimiter("deck", models.ActionStartPoll).Allow() {
+		t.Error("a different action should not be limited")
+	}
+
+	if !getLimiter("automation", models.ActionAnnouncement).Allow() {
+		t.Error("a different actor should not be limited")
+	}
+}
+
+func TestIsValidAction(t *testing.T) {
+	if !IsValidAction(models.ActionDisconnectBroadcaster) {
+		t.Error("DISCONNECT_BROADCASTER should be valid")
+	}
+
+	if IsValidAction("REBOOT") {
+		t.Error("REBOOT should not be valid")
+	}
+}
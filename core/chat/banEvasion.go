@@ -0,0 +1,79 @@
+package chat
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// How long an IP address is tied to a user after they were last seen.
+	banEvasionIPAddressWindow = 7 * 24 * time.Hour
+	// Only users created this recently are considered possible ban evaders.
+	banEvasionNewUserWindow = 24 * time.Hour
+)
+
+// checkForBanEvasion will record the IP address of a connected client and
+// let moderators know if a new anonymous user is connecting from an address
+// recently used by a disabled user. Users are never banned automatically.
+func checkForBanEvasion(c *Client) {
+	if !data.GetChatBanEvasionDetectionEnabled() {
+		return
+	}
+
+	// When behind a proxy the first forwarded address is the client.
+	address := strings.TrimSpace(strings.Split(c.IPAddress, ",")[0])
+	if address == "" {
+		return
+	}
+
+	if err := user.SetIPAddressSeen(c.User.ID, address); err != nil {
+		log.Errorln(err)
+		return
+	}
+
+	if c.User.Authenticated || c.User.IsModerator() || time.Since(c.User.CreatedAt) > banEvasionNewUserWindow {
+		return
+	}
+
+	disabledUsers := user.GetDisabledUsersSeenFromIPAddress(address, time.Now().Add(-banEvasionIPAddressWindow))
+	if len(disabledUsers) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(disabledUsers))
+	for _, disabledUser := range disabledUsers {
+		names = append(names, disabledUser.DisplayName)
+	}
+
+	log.Infoln("Possible ban evasion by", c.User.ID, c.User.DisplayName, "sharing an IP address with", strings.Join(names, ", "))
+
+	text := fmt.Sprintf("**%s** may be evading a ban. They are connecting from the same IP address as the removed user(s) **%s**.", c.User.DisplayName, strings.Join(names, "**, **"))
+	for _, moderator := range user.GetModeratorUsers() {
+		if err := SendActionToUser(moderator.ID, text); err != nil {
+			log.Debugln(err)
+		}
+	}
+}
+
+// GetBanEvasionSuspects will return the new anonymous users that have
+// recently connected from the same IP address as a disabled user.
+func GetBanEvasionSuspects() ([]user.BanEvasionSuspect, error) {
+	return user.GetBanEvasionSuspects(time.Now().Add(-banEvasionNewUserWindow), time.Now().Add(-banEvasionIPAddressWindow))
+}
+
+func pruneUserIPAddresses() {
+	if err := user.RemoveIPAddressesSeenBefore(time.Now().Add(-banEvasionIPAddressWindow)); err != nil {
+		log.Debugln("unable to remove old user ip addresses", err)
+	}
+}
+
+func pruneExpiredIPAddressBans() {
+	if err := data.RemoveExpiredIPAddressBans(); err != nil {
+		log.Debugln("unable to remove expired ip address bans", err)
+	}
+}
@@ -0,0 +1,126 @@
+package chat
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/pubsub"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/core/webhooks"
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// Chat events are shared between Owncast nodes so that clients connected to
+// any node see the same chat. Commands that target a single user are shared
+// too, as the user may be connected to a different node.
+const (
+	broadcastTopic      = "chat.broadcast"
+	userTopic           = "chat.user"
+	raidProtectionTopic = "chat.raidprotection"
+)
+
+const (
+	refreshUserCommand    = "refresh"
+	actionUserCommand     = "action"
+	disconnectUserCommand = "disconnect"
+)
+
+// broadcastMessage is a chat event broadcast by a node. Origin is the node
+// that sent it, which has already delivered it to its own clients.
+type broadcastMessage struct {
+	Origin  string          `json:"origin"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+type userCommand struct {
+	Command string `json:"command"`
+	UserID  string `json:"userId"`
+	Text    string `json:"text,omitempty"`
+	// Actor is who requested the command, if anybody.
+	Actor string `json:"actor,omitempty"`
+}
+
+func setupBus(s *Server) {
+	pubsub.Subscribe(broadcastTopic, s.broadcastReceived)
+	pubsub.Subscribe(userTopic, s.userCommandReceived)
+	pubsub.Subscribe(raidProtectionTopic, raidProtectionReceived)
+}
+
+func (s *Server) broadcastReceived(payload []byte) {
+	var message broadcastMessage
+	if err := json.Unmarshal(payload, &message); err != nil {
+		log.Errorln("error unmarshalling chat broadcast", err)
+		return
+	}
+
+	if message.Origin == pubsub.NodeID {
+		return
+	}
+
+	s.broadcastLocal(message.Payload)
+}
+
+func publishUserCommand(command userCommand) error {
+	payload, err := json.Marshal(command)
+	if err != nil {
+		return err
+	}
+
+	return pubsub.Publish(userTopic, payload)
+}
+
+func (s *Server) userCommandReceived(payload []byte) {
+	var command userCommand
+	if err := json.Unmarshal(payload, &command); err != nil {
+		log.Errorln("error unmarshalling user command", err)
+		return
+	}
+
+	// Nothing to do if the user is not connected to this node.
+	clients, err := GetClientsForUser(command.UserID)
+	if err != nil {
+		return
+	}
+
+	switch command.Command {
+	case refreshUserCommand:
+		// Get an updated reference to the user.
+		u := user.GetUserByID(command.UserID)
+		if u == nil {
+			return
+		}
+
+		for _, client := range clients {
+			// Update the client's reference to its user.
+			client.User = u
+			// Send the update to the client.
+			client.sendConnectedClientInfo()
+		}
+
+	case actionUserCommand:
+		for _, client := range clients {
+			s.sendActionToClient(client, command.Text)
+		}
+
+	case disconnectUserCommand:
+		s.DisconnectClients(clients)
+
+		// Ban this user's IP address.
+		reason := fmt.Sprintf("Banning of %s", clients[0].User.DisplayName)
+		for _, client := range clients {
+			address := client.IPAddress
+			if err := data.BanIPAddress(address, reason, command.Actor, nil); err != nil {
+				log.Errorln("error banning IP address: ", err)
+				continue
+			}
+
+			webhooks.SendIPAddressBanEvent(models.IPAddressBanned, webhooks.WebhookIPAddressBan{
+				IPAddress: address,
+				Reason:    reason,
+				Actor:     command.Actor,
+			})
+		}
+	}
+}
@@ -25,9 +25,12 @@ func Start(getStatusFunc func() models.Status) error {
 
 	getStatus = getStatusFunc
 	_server = NewChat()
+	setupBus(_server)
 
 	go _server.Run()
 
+	restorePolls()
+
 	log.Traceln("Chat server started with max connection count of", _server.maxSocketConnectionLimit)
 
 	chatMessagesSentCounter = promauto.NewGauge(prometheus.GaugeOpts{
@@ -0,0 +1,142 @@
+package chat
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// When each chat command was last used, keyed by command ID.
+	_chatCommandLastUsed = map[string]time.Time{}
+	_chatCommandLock     = sync.Mutex{}
+)
+
+// handleChatCommand will reply to a chat message that begins with the
+// trigger of a chat command.
+func handleChatCommand(event events.UserMessageEvent) {
+	fields := strings.Fields(event.RawBody)
+	if len(fields) == 0 || !strings.HasPrefix(fields[0], "!") {
+		return
+	}
+
+	command, err := data.GetChatCommandForTrigger(models.NormalizeChatCommandTrigger(fields[0]))
+	if err != nil {
+		if err != data.ErrChatCommandNotFound {
+			log.Errorln("unable to get chat command", err)
+		}
+		return
+	}
+
+	if !command.Enabled {
+		return
+	}
+
+	u := event.User
+	if command.RoleID != "" && !u.IsModerator() && !u.HasRole(command.RoleID) {
+		return
+	}
+
+	if !useChatCommand(command, u.IsModerator()) {
+		return
+	}
+
+	// Display names are chosen by users so must not be rendered as HTML.
+	response := RenderChatCommandResponse(command.Response, html.EscapeString(u.DisplayName), getStatus())
+	if err := sendChatCommandResponse(response); err != nil {
+		log.Errorln("unable to reply to chat command", command.Trigger, err)
+	}
+}
+
+// useChatCommand will return if the command is not cooling down, marking it
+// as used if so. Moderators are not limited by cooldowns.
+func useChatCommand(command *models.ChatCommand, isModerator bool) bool {
+	_chatCommandLock.Lock()
+	defer _chatCommandLock.Unlock()
+
+	cooldown := time.Duration(command.CooldownSeconds) * time.Second
+	if lastUsed, ok := _chatCommandLastUsed[command.ID]; ok && time.Since(lastUsed) < cooldown && !isModerator {
+		return false
+	}
+
+	_chatCommandLastUsed[command.ID] = time.Now()
+	return true
+}
+
+// RenderChatCommandResponse will replace the variables in a chat command
+// response with their current values.
+func RenderChatCommandResponse(response string, displayName string, status models.Status) string {
+	uptime := "offline"
+	if status.Online && status.LastConnectTime != nil {
+		uptime = formatUptime(time.Since(status.LastConnectTime.Time))
+	}
+
+	replacer := strings.NewReplacer(
+		"{user}", displayName,
+		"{serverName}", data.GetServerName(),
+		"{streamTitle}", status.StreamTitle,
+		"{viewerCount}", strconv.Itoa(status.ViewerCount),
+		"{uptime}", uptime,
+	)
+
+	return replacer.Replace(response)
+}
+
+func formatUptime(d time.Duration) string {
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+
+	if hours == 0 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+
+	return fmt.Sprintf("%dh %dm", hours, minutes)
+}
+
+// sendChatCommandResponse will reply as the configured bot integration, or
+// as the system if there is none.
+func sendChatCommandResponse(text string) error {
+	botID := data.GetChatCommandBotID()
+	if botID == "" {
+		return SendSystemAction(text, false)
+	}
+
+	bot, err := user.GetExternalAPIUserByID(botID)
+	if err != nil {
+		log.Warnln("chat command bot", botID, "not found, replying as the system")
+		return SendSystemAction(text, false)
+	}
+
+	message := events.UserMessageEvent{
+		MessageEvent: events.MessageEvent{
+			Body: text,
+		},
+	}
+	message.SetDefaults()
+	message.RenderBody()
+	message.Type = events.MessageSent
+	message.User = &user.User{
+		ID:           bot.ID,
+		DisplayName:  bot.DisplayName,
+		DisplayColor: bot.DisplayColor,
+		CreatedAt:    bot.CreatedAt,
+		IsBot:        true,
+	}
+
+	if err := Broadcast(&message); err != nil {
+		return err
+	}
+
+	SaveUserMessage(message)
+
+	return nil
+}
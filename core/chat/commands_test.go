@@ -0,0 +1,50 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/models"
+)
+
+func TestChatCommandCooldown(t *testing.T) {
+	command := &models.ChatCommand{ID: "cooldown-test", CooldownSeconds: 60}
+
+	if !useChatCommand(command, false) {
+		t.Error("expected the first use of a command to be allowed")
+	}
+
+	if useChatCommand(command, false) {
+		t.Error("expected the command to be cooling down")
+	}
+
+	if !useChatCommand(command, true) {
+		t.Error("expected moderators to not be limited by the cooldown")
+	}
+}
+
+func TestFormatUptime(t *testing.T) {
+	if uptime := formatUptime(42 * time.Minute); uptime != "42m" {
+		t.Errorf("expected 42m, got %s", uptime)
+	}
+
+	if uptime := formatUptime(2*time.Hour + 5*time.Minute + 30*time.Second); uptime != "2h 5m" {
+		t.Errorf("expected 2h 5m, got %s", uptime)
+	}
+}
+
+func TestChatCommandTriggers(t *testing.T) {
+	if trigger := models.NormalizeChatCommandTrigger(" Socials "); trigger != "!socials" {
+		t.Errorf("expected !socials, got %s", trigger)
+	}
+
+	valid := models.ChatCommand{Trigger: "!schedule", Response: "Tuesdays"}
+	if err := valid.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	invalid := models.ChatCommand{Trigger: "!two words", Response: "Tuesdays"}
+	if err := invalid.Validate(); err == nil {
+		t.Error("expected a trigger with a space to be rejected")
+	}
+}
@@ -0,0 +1,140 @@
+package chat
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Read-only consumers, such as overlays and archival tools, can receive the
+// same events broadcast to chat clients without joining chat.
+
+// Each subscriber can fall this many events behind before it is dropped.
+const eventStreamBufferSize = 256
+
+// When the most recent events were sent, so subscribers can resume from
+// events that are not saved to the chat history.
+const eventStreamRecentEvents = 1000
+
+var (
+	_eventStreamSubscribers = map[chan []byte]struct{}{}
+	_eventStreamLock        = sync.Mutex{}
+
+	_recentEventTimes = map[string]time.Time{}
+	_recentEventIDs   = make([]string, 0, eventStreamRecentEvents)
+)
+
+// ErrTooManyEventStreamSubscribers is returned when there are as many event
+// stream subscribers as allowed chat connections.
+var ErrTooManyEventStreamSubscribers = errors.New("too many chat event stream subscribers")
+
+// SubscribeToChatEvents will return a channel of encoded chat events and a
+// function to stop receiving them. The channel is closed if the subscriber
+// falls too far behind. Limited subscriptions count towards the maximum
+// number of chat connections.
+func SubscribeToChatEvents(limited bool) (<-chan []byte, func(), error) {
+	_eventStreamLock.Lock()
+	defer _eventStreamLock.Unlock()
+
+	if limited && int64(len(_eventStreamSubscribers)) >= _server.maxSocketConnectionLimit {
+		return nil, nil, ErrTooManyEventStreamSubscribers
+	}
+
+	subscriber := make(chan []byte, eventStreamBufferSize)
+	_eventStreamSubscribers[subscriber] = struct{}{}
+
+	unsubscribe := func() {
+		_eventStreamLock.Lock()
+		defer _eventStreamLock.Unlock()
+
+		if _, ok := _eventStreamSubscribers[subscriber]; ok {
+			delete(_eventStreamSubscribers, subscriber)
+			close(subscriber)
+		}
+	}
+
+	return subscriber, unsubscribe, nil
+}
+
+func sendToEventStreamSubscribers(data []byte) {
+	_eventStreamLock.Lock()
+	defer _eventStreamLock.Unlock()
+
+	rememberEventTime(data)
+
+	for subscriber := range _eventStreamSubscribers {
+		select {
+		case subscriber <- data:
+		default:
+			delete(_eventStreamSubscribers, subscriber)
+			close(subscriber)
+		}
+	}
+}
+
+func rememberEventTime(data []byte) {
+	var event struct {
+		ID        string    `json:"id"`
+		Timestamp time.Time `json:"timestamp"`
+	}
+	if err := json.Unmarshal(data, &event); err != nil || event.ID == "" || event.Timestamp.IsZero() {
+		return
+	}
+
+	if len(_recentEventIDs) >= eventStreamRecentEvents {
+		delete(_recentEventTimes, _recentEventIDs[0])
+		_recentEventIDs = _recentEventIDs[1:]
+	}
+	_recentEventIDs = append(_recentEventIDs, event.ID)
+	_recentEventTimes[event.ID] = event.Timestamp
+}
+
+// GetChatEventsAfter will return up to limit chat history messages sent
+// after an event, and if they are every message sent since it. Events that
+// are not saved to the chat history, such as users joining, are resumed from
+// the message sent before them. Nothing is returned for an unknown event.
+func GetChatEventsAfter(eventID string, limit int) ([]interface{}, bool) {
+	if !messageExists(eventID) {
+		_eventStreamLock.Lock()
+		sentAt, ok := _recentEventTimes[eventID]
+		_eventStreamLock.Unlock()
+		if !ok {
+			return nil, false
+		}
+
+		eventID = getMessageIDBefore(sentAt)
+		if eventID == "" {
+			// Every message was sent after the event.
+			messages := GetChatHistoryPage(HistoryQuery{Since: &sentAt, Limit: limit + 1})
+			if len(messages) > limit {
+				return nil, false
+			}
+			return messages, true
+		}
+	}
+
+	messages := GetChatHistoryPage(HistoryQuery{After: eventID, Limit: limit + 1})
+	if len(messages) > limit {
+		return messages[:limit], false
+	}
+	return messages, true
+}
+
+func messageExists(id string) bool {
+	var count int
+	if err := _datastore.DB.QueryRow("SELECT COUNT(*) FROM messages WHERE id = ?", id).Scan(&count); err != nil {
+		return false
+	}
+	return count > 0
+}
+
+// getMessageIDBefore will return the ID of the last message saved at or
+// before a time.
+func getMessageIDBefore(t time.Time) string {
+	var id string
+	if err := _datastore.DB.QueryRow("SELECT id FROM messages WHERE timestamp <= ? ORDER BY timestamp DESC, id DESC LIMIT 1", t.Local()).Scan(&id); err != nil {
+		return ""
+	}
+	return id
+}
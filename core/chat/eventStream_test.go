@@ -0,0 +1,76 @@
+package chat
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
+)
+
+func TestGetChatEventsAfter(t *testing.T) {
+	dbFile, err := os.CreateTemp(os.TempDir(), "owncast-test-db.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := data.SetupPersistence(dbFile.Name()); err != nil {
+		t.Fatal(err)
+	}
+	user.SetupUsers()
+	_datastore = data.GetDatastore()
+	data.CreateMessagesTable(_datastore.DB)
+
+	u, _, err := user.CreateAnonymousUser("resumer")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now().Add(-time.Hour)
+	for i := 0; i < 5; i++ {
+		if _, err := _datastore.DB.Exec("INSERT INTO messages(id, user_id, body, eventType, timestamp) VALUES (?, ?, 'hello', ?, ?)",
+			fmt.Sprintf("message-%d", i), u.ID, events.MessageSent, start.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// An event that is not saved, sent between the second and third messages.
+	joined, _ := json.Marshal(map[string]interface{}{"id": "joined", "type": events.UserJoined, "timestamp": start.Add(90 * time.Second)})
+	sendToEventStreamSubscribers(joined)
+
+	ids := func(messages []interface{}) string {
+		result := []string{}
+		for _, message := range messages {
+			payload, _ := json.Marshal(message)
+			var event struct {
+				ID string `json:"id"`
+			}
+			_ = json.Unmarshal(payload, &event)
+			result = append(result, event.ID)
+		}
+		return strings.Join(result, " ")
+	}
+
+	tests := []struct {
+		after    string
+		limit    int
+		expected string
+		complete bool
+	}{
+		{"message-1", 10, "message-2 message-3 message-4", true},
+		{"message-1", 2, "message-2 message-3", false},
+		{"joined", 10, "message-2 message-3 message-4", true},
+		{"unknown", 10, "", false},
+	}
+
+	for _, test := range tests {
+		messages, complete := GetChatEventsAfter(test.after, test.limit)
+		if got := ids(messages); got != test.expected || complete != test.complete {
+			t.Errorf("after %s: got %s complete %t, expected %s complete %t", test.after, got, complete, test.expected, test.complete)
+		}
+	}
+}
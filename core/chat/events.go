@@ -118,6 +118,12 @@ func (s *Server) userMessageSent(eventData chatClientEvent) {
 		return
 	}
 
+	recordRaidMessage(event.User, event.Body)
+
+	if !s.passesMessageRestrictions(eventData.client, &event) {
+		return
+	}
+
 	payload := event.GetBroadcastPayload()
 	if err := s.Broadcast(payload); err != nil {
 		log.Errorln("error broadcasting UserMessageEvent payload", err)
@@ -131,4 +137,6 @@ func (s *Server) userMessageSent(eventData chatClientEvent) {
 	SaveUserMessage(event)
 	eventData.client.MessageCount++
 	_lastSeenCache[event.User.ID] = time.Now()
+
+	go handleChatCommand(event)
 }
@@ -2,6 +2,7 @@ package events
 
 import (
 	"bytes"
+	"path"
 	"regexp"
 	"strings"
 	"time"
@@ -43,8 +44,9 @@ type UserEvent struct {
 // MessageEvent is an event that has a message body.
 type MessageEvent struct {
 	OutboundEvent `json:"-"`
-	Body          string `json:"body"`
-	RawBody       string `json:"-"`
+	Body          string         `json:"body"`
+	RawBody       string         `json:"-"`
+	Reactions     map[string]int `json:"reactions,omitempty"`
 }
 
 // SystemActionEvent is an event that represents an action that took place, not a chat message.
@@ -80,6 +82,28 @@ func (m *MessageEvent) Empty() bool {
 	return m.Body == ""
 }
 
+var (
+	_renderedLinkMatch  = regexp.MustCompile(`(?i)<a\s`)
+	_renderedEmojiMatch = regexp.MustCompile(`(?i)<img[^>]+src="/img/emoji/([^"]+)"`)
+)
+
+// ContainsLink will return if the rendered message body links anywhere.
+func (m *MessageEvent) ContainsLink() bool {
+	return _renderedLinkMatch.MatchString(m.Body)
+}
+
+// GetCustomEmojiNames will return the names of the custom emoji used in the
+// rendered message body.
+func (m *MessageEvent) GetCustomEmojiNames() []string {
+	names := []string{}
+	for _, match := range _renderedEmojiMatch.FindAllStringSubmatch(m.Body, -1) {
+		file := path.Base(match[1])
+		names = append(names, strings.TrimSuffix(file, path.Ext(file)))
+	}
+
+	return names
+}
+
 // RenderBody will render markdown to html without any sanitization.
 func (m *MessageEvent) RenderBody() {
 	m.RawBody = m.Body
@@ -40,4 +40,16 @@ const (
 	FediverseEngagementLike EventType = "FEDIVERSE_ENGAGEMENT_LIKE"
 	// FediverseEngagementRepost is an event representing a re-post action that took place on the fediverse.
 	FediverseEngagementRepost EventType = "FEDIVERSE_ENGAGEMENT_REPOST"
+	// MessageReactionSent is the event sent when a user adds or removes an emoji reaction on a chat message.
+	MessageReactionSent EventType = "REACTION"
+	// ReactionUpdate is the event sent when the aggregated reactions of a chat message change.
+	ReactionUpdate EventType = "REACTION-UPDATE"
+	// PollStarted is the event sent when a new chat poll begins.
+	PollStarted EventType = "POLL_STARTED"
+	// PollVoteSent is the event sent when a user votes on the active chat poll.
+	PollVoteSent EventType = "POLL_VOTE"
+	// PollUpdated is the event sent when the vote tallies of the active chat poll change.
+	PollUpdated EventType = "POLL_UPDATE"
+	// PollEnded is the event sent when a chat poll closes with its final results.
+	PollEnded EventType = "POLL_ENDED"
 )
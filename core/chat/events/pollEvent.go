@@ -0,0 +1,32 @@
+package events
+
+import "github.com/owncast/owncast/models"
+
+// PollVoteEvent is received when a user votes on a chat poll.
+type PollVoteEvent struct {
+	Event
+	UserEvent
+	PollID string `json:"pollId"`
+	Option int    `json:"option"`
+}
+
+// PollEvent is the event fired when a chat poll starts, receives votes or ends.
+type PollEvent struct {
+	Event
+	Poll models.Poll
+}
+
+// GetBroadcastPayload will return the object to send to all chat users.
+func (e *PollEvent) GetBroadcastPayload() EventPayload {
+	return EventPayload{
+		"type":      e.GetMessageType(),
+		"id":        e.ID,
+		"timestamp": e.Timestamp,
+		"poll":      e.Poll,
+	}
+}
+
+// GetMessageType will return the event type for this message.
+func (e *PollEvent) GetMessageType() EventType {
+	return e.Event.Type
+}
@@ -0,0 +1,35 @@
+package events
+
+// MessageReactionEvent is received when a user adds or removes an emoji
+// reaction on a chat message.
+type MessageReactionEvent struct {
+	Event
+	UserEvent
+	MessageID string `json:"messageId"`
+	Emoji     string `json:"emoji"`
+	Remove    bool   `json:"remove"`
+}
+
+// ReactionUpdateEvent is the event fired when the aggregated reactions of a
+// single chat message change.
+type ReactionUpdateEvent struct {
+	Event
+	MessageID string
+	Reactions map[string]int
+}
+
+// GetBroadcastPayload will return the object to send to all chat users.
+func (e *ReactionUpdateEvent) GetBroadcastPayload() EventPayload {
+	return EventPayload{
+		"type":      ReactionUpdate,
+		"id":        e.ID,
+		"timestamp": e.Timestamp,
+		"messageId": e.MessageID,
+		"reactions": e.Reactions,
+	}
+}
+
+// GetMessageType will return the event type for this message.
+func (e *ReactionUpdateEvent) GetMessageType() EventType {
+	return ReactionUpdate
+}
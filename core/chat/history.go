@@ -0,0 +1,141 @@
+package chat
+
+import (
+	"strings"
+	"time"
+)
+
+// HistoryQuery describes a page of chat history to return. The zero value
+// returns the most recent visible messages.
+type HistoryQuery struct {
+	// Before will return messages sent before the message with this ID.
+	Before string
+	// After will return messages sent after the message with this ID.
+	After string
+	// Limit is the maximum number of messages to return.
+	Limit int
+
+	UserID     string
+	RoleID     string
+	Since      *time.Time
+	Until      *time.Time
+	EventTypes []string
+	// Search will only return messages matching these words.
+	Search string
+	// IncludeHidden will also return hidden messages and messages from
+	// disabled users, for moderation.
+	IncludeHidden bool
+}
+
+const historyColumns = "messages.id, messages.user_id, messages.body, messages.title, messages.subtitle, messages.image, messages.link, messages.reactions, messages.eventType, messages.hidden_at, messages.timestamp, users.display_name, users.display_color, users.created_at, users.disabled_at, users.previous_names, users.namechanged_at, users.authenticated_at, users.scopes, users.type"
+
+// GetChatHistoryPage will return a page of chat history, oldest message first.
+func GetChatHistoryPage(q HistoryQuery) []interface{} {
+	where, args := q.filters()
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = maxBacklogNumber
+	} else if limit > maxHistoryPageSize {
+		limit = maxHistoryPageSize
+	}
+
+	// When paging forward walk the history oldest first, otherwise start
+	// from the newest messages and flip them around afterwards.
+	order := "DESC"
+	if q.After != "" && q.Before == "" {
+		order = "ASC"
+	}
+
+	query := "SELECT " + historyColumns + " FROM messages INNER JOIN users ON messages.user_id = users.id"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY messages.timestamp " + order + ", messages.id " + order + " LIMIT ?"
+	args = append(args, limit)
+
+	m := getChat(query, args...)
+
+	if order == "DESC" {
+		for i, j := 0, len(m)-1; i < j; i, j = i+1, j-1 {
+			m[i], m[j] = m[j], m[i]
+		}
+	}
+
+	return m
+}
+
+func (q HistoryQuery) filters() ([]string, []interface{}) {
+	where := []string{}
+	args := []interface{}{}
+
+	if !q.IncludeHidden {
+		where = append(where, "messages.hidden_at IS NULL", "users.disabled_at IS NULL")
+	}
+
+	if q.Before != "" {
+		where = append(where, "(messages.timestamp, messages.id) < (SELECT timestamp, id FROM messages WHERE id = ?)")
+		args = append(args, q.Before)
+	}
+
+	if q.After != "" {
+		where = append(where, "(messages.timestamp, messages.id) > (SELECT timestamp, id FROM messages WHERE id = ?)")
+		args = append(args, q.After)
+	}
+
+	if q.UserID != "" {
+		where = append(where, "messages.user_id = ?")
+		args = append(args, q.UserID)
+	}
+
+	if q.RoleID != "" {
+		where = append(where, "messages.user_id IN (SELECT user_id FROM user_roles WHERE role_id = ?)")
+		args = append(args, q.RoleID)
+	}
+
+	if q.Since != nil {
+		where = append(where, "messages.timestamp >= ?")
+		args = append(args, q.Since.Local())
+	}
+
+	if q.Until != nil {
+		where = append(where, "messages.timestamp <= ?")
+		args = append(args, q.Until.Local())
+	}
+
+	if len(q.EventTypes) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(q.EventTypes)), ",")
+		where = append(where, "messages.eventType IN ("+placeholders+")")
+		for _, eventType := range q.EventTypes {
+			args = append(args, eventType)
+		}
+	}
+
+	if terms := strings.Fields(q.Search); len(terms) > 0 {
+		if _searchIndexEnabled {
+			where = append(where, "messages.rowid IN (SELECT rowid FROM messages_fts WHERE messages_fts MATCH ?)")
+			args = append(args, getSearchIndexQuery(terms))
+		} else {
+			for _, term := range terms {
+				where = append(where, `messages.body LIKE ? ESCAPE '\'`)
+				args = append(args, "%"+escapeLikePattern(term)+"%")
+			}
+		}
+	}
+
+	return where, args
+}
+
+// getSearchIndexQuery will quote each search term so user input is never
+// interpreted as FTS5 query syntax. All terms must match.
+func getSearchIndexQuery(terms []string) string {
+	quoted := make([]string, 0, len(terms))
+	for _, term := range terms {
+		quoted = append(quoted, `"`+strings.ReplaceAll(term, `"`, `""`)+`"`)
+	}
+	return strings.Join(quoted, " ")
+}
+
+func escapeLikePattern(term string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(term)
+}
@@ -63,3 +63,24 @@ func TestAllowHTML(t *testing.T) {
 		t.Errorf("message rendering does not match expected.  Got\n%s, \n\n want:\n%s", result, expected)
 	}
 }
+
+// Test finding the links and custom emoji that can be restricted to roles.
+func TestRestrictedMessageContent(t *testing.T) {
+	message := events.MessageEvent{Body: `check out www.owncast.online <img class="emoji" alt=":partyparrot:" src="/img/emoji/blob/partyparrot.gif">`}
+	message.RenderAndSanitizeMessageBody()
+
+	if !message.ContainsLink() {
+		t.Error("expected the message to contain a link")
+	}
+
+	if names := message.GetCustomEmojiNames(); len(names) != 1 || names[0] != "partyparrot" {
+		t.Errorf("expected the partyparrot emoji, got %v", names)
+	}
+
+	plain := events.MessageEvent{Body: "just some text"}
+	plain.RenderAndSanitizeMessageBody()
+
+	if plain.ContainsLink() || len(plain.GetCustomEmojiNames()) != 0 {
+		t.Error("expected plain text to contain no links or emoji")
+	}
+}
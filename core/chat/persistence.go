@@ -1,6 +1,8 @@
 package chat
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -9,26 +11,35 @@ import (
 	"github.com/owncast/owncast/core/data"
 	"github.com/owncast/owncast/core/user"
 	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/utils"
 	log "github.com/sirupsen/logrus"
 )
 
 var _datastore *data.Datastore
 
 const (
-	maxBacklogHours  = 2  // Keep backlog max hours worth of messages
-	maxBacklogNumber = 50 // Return max number of messages in history request
+	maxBacklogNumber   = 50  // Return max number of messages in history request
+	maxHistoryPageSize = 200 // Largest page of messages a history request can ask for
 )
 
+// Set when SQLite was built with FTS5 and chat messages can be searched
+// using the full-text index.
+var _searchIndexEnabled bool
+
 func setupPersistence() {
 	_datastore = data.GetDatastore()
 	data.CreateMessagesTable(_datastore.DB)
 	data.CreateBanIPTable(_datastore.DB)
+	data.CreatePollsTables(_datastore.DB)
+	_searchIndexEnabled = data.CreateMessagesSearchIndex(_datastore.DB)
 
 	chatDataPruner := time.NewTicker(5 * time.Minute)
 	go func() {
-		runPruner()
-		for range chatDataPruner.C {
+		for {
 			runPruner()
+			pruneExpiredIPAddressBans()
+			pruneUserIPAddresses()
+			<-chatDataPruner.C
 		}
 	}()
 }
@@ -128,8 +139,9 @@ func makeUserMessageEventFromRowData(row rowData) events.UserMessageEvent {
 			HiddenAt: row.hiddenAt,
 		},
 		MessageEvent: events.MessageEvent{
-			Body:    row.body,
-			RawBody: row.body,
+			Body:      row.body,
+			RawBody:   row.body,
+			Reactions: getReactionCounts(makeReactionsFromRowData(row)),
 		},
 	}
 
@@ -144,8 +156,9 @@ func makeSystemMessageChatEventFromRowData(row rowData) events.SystemMessageEven
 			Timestamp: row.timestamp,
 		},
 		MessageEvent: events.MessageEvent{
-			Body:    row.body,
-			RawBody: row.body,
+			Body:      row.body,
+			RawBody:   row.body,
+			Reactions: getReactionCounts(makeReactionsFromRowData(row)),
 		},
 	}
 	return message
@@ -159,8 +172,9 @@ func makeActionMessageChatEventFromRowData(row rowData) events.ActionEvent {
 			Timestamp: row.timestamp,
 		},
 		MessageEvent: events.MessageEvent{
-			Body:    row.body,
-			RawBody: row.body,
+			Body:      row.body,
+			RawBody:   row.body,
+			Reactions: getReactionCounts(makeReactionsFromRowData(row)),
 		},
 	}
 	return message
@@ -174,8 +188,9 @@ func makeFederatedActionChatEventFromRowData(row rowData) events.FediverseEngage
 			Timestamp: row.timestamp,
 		},
 		MessageEvent: events.MessageEvent{
-			Body:    row.body,
-			RawBody: row.body,
+			Body:      row.body,
+			RawBody:   row.body,
+			Reactions: getReactionCounts(makeReactionsFromRowData(row)),
 		},
 		Image:           row.image,
 		Link:            *row.link,
@@ -184,6 +199,19 @@ func makeFederatedActionChatEventFromRowData(row rowData) events.FediverseEngage
 	return message
 }
 
+func makeReactionsFromRowData(row rowData) map[string][]string {
+	reactions := map[string][]string{}
+	if row.reactions == nil || *row.reactions == "" {
+		return reactions
+	}
+
+	if err := json.Unmarshal([]byte(*row.reactions), &reactions); err != nil {
+		log.Debugln("unable to parse reactions for message", row.id, err)
+	}
+
+	return reactions
+}
+
 type rowData struct {
 	id        string
 	userID    *string
@@ -195,6 +223,7 @@ type rowData struct {
 	subtitle  *string
 	image     *string
 	link      *string
+	reactions *string
 
 	userDisplayName     *string
 	userDisplayColor    *int
@@ -207,9 +236,9 @@ type rowData struct {
 	userType            *string
 }
 
-func getChat(query string) []interface{} {
+func getChat(query string, args ...interface{}) []interface{} {
 	history := make([]interface{}, 0)
-	rows, err := _datastore.DB.Query(query)
+	rows, err := _datastore.DB.Query(query, args...)
 	if err != nil || rows.Err() != nil {
 		log.Errorln("error fetching chat history", err)
 		return history
@@ -228,6 +257,7 @@ func getChat(query string) []interface{} {
 			&row.subtitle,
 			&row.image,
 			&row.link,
+			&row.reactions,
 			&row.eventType,
 			&row.hiddenAt,
 			&row.timestamp,
@@ -266,9 +296,29 @@ func getChat(query string) []interface{} {
 		history = append(history, message)
 	}
 
+	addRolesToHistory(history)
+
 	return history
 }
 
+// addRolesToHistory will set the current roles of the users who sent the
+// messages so their badges are shown.
+func addRolesToHistory(history []interface{}) {
+	userIDs := []string{}
+	for _, message := range history {
+		if m, ok := message.(events.UserMessageEvent); ok && m.User != nil {
+			userIDs = append(userIDs, m.User.ID)
+		}
+	}
+
+	userRoles := user.GetRolesForUsers(utils.StringMapKeys(utils.StringSliceToMap(userIDs)))
+	for _, message := range history {
+		if m, ok := message.(events.UserMessageEvent); ok && m.User != nil {
+			m.User.Roles = userRoles[m.User.ID]
+		}
+	}
+}
+
 var _historyCache *[]interface{}
 
 // GetChatModerationHistory will return all the chat messages suitable for moderation purposes.
@@ -278,7 +328,7 @@ func GetChatModerationHistory() []interface{} {
 	}
 
 	// Get all messages regardless of visibility
-	query := "SELECT messages.id, user_id, body, title, subtitle, image, link, eventType, hidden_at, timestamp, display_name, display_color, created_at, disabled_at, previous_names, namechanged_at, authenticated_at, scopes, type FROM messages INNER JOIN users ON messages.user_id = users.id ORDER BY timestamp DESC"
+	query := "SELECT messages.id, user_id, body, title, subtitle, image, link, reactions, eventType, hidden_at, timestamp, display_name, display_color, created_at, disabled_at, previous_names, namechanged_at, authenticated_at, scopes, type FROM messages INNER JOIN users ON messages.user_id = users.id ORDER BY timestamp DESC"
 	result := getChat(query)
 
 	_historyCache = &result
@@ -286,18 +336,23 @@ func GetChatModerationHistory() []interface{} {
 	return result
 }
 
-// GetChatHistory will return all the chat messages suitable for returning as user-facing chat history.
-func GetChatHistory() []interface{} {
-	// Get all visible messages
-	query := fmt.Sprintf("SELECT messages.id, messages.user_id, messages.body, messages.title, messages.subtitle, messages.image, messages.link, messages.eventType, messages.hidden_at, messages.timestamp, users.display_name, users.display_color, users.created_at, users.disabled_at, users.previous_names, users.namechanged_at, users.authenticated_at, users.scopes, users.type FROM users JOIN messages ON users.id = messages.user_id WHERE hidden_at IS NULL AND disabled_at IS NULL ORDER BY timestamp DESC LIMIT %d", maxBacklogNumber)
-	m := getChat(query)
+// GetMessagesForUser will return every message sent by a single user,
+// including hidden messages, oldest first.
+func GetMessagesForUser(userID string) []interface{} {
+	query := "SELECT " + historyColumns + " FROM messages INNER JOIN users ON messages.user_id = users.id WHERE messages.user_id = ? ORDER BY messages.timestamp ASC, messages.id ASC"
+	return getChat(query, userID)
+}
 
-	// Invert order of messages
-	for i, j := 0, len(m)-1; i < j; i, j = i+1, j-1 {
-		m[i], m[j] = m[j], m[i]
-	}
+// UserDataDeleted will drop any cached history showing the identity of a
+// user whose data has been deleted and update their connected clients.
+func UserDataDeleted(userID string) error {
+	_historyCache = nil
+	return SendConnectedClientInfoToUser(userID)
+}
 
-	return m
+// GetChatHistory will return all the chat messages suitable for returning as user-facing chat history.
+func GetChatHistory() []interface{} {
+	return GetChatHistoryPage(HistoryQuery{})
 }
 
 // SetMessageVisibilityForUserID will bulk change the visibility of messages for a user
@@ -309,7 +364,7 @@ func SetMessageVisibilityForUserID(userID string, visible bool) error {
 
 	// Get a list of IDs to send to the connected clients to hide
 	ids := make([]string, 0)
-	query := fmt.Sprintf("SELECT messages.id, user_id, body, title, subtitle, image, link, eventType, hidden_at, timestamp, display_name, display_color, created_at, disabled_at,  previous_names, namechanged_at, authenticated, scopes, type FROM messages INNER JOIN users ON messages.user_id = users.id WHERE user_id IS '%s'", userID)
+	query := fmt.Sprintf("SELECT messages.id, user_id, body, title, subtitle, image, link, reactions, eventType, hidden_at, timestamp, display_name, display_color, created_at, disabled_at, previous_names, namechanged_at, authenticated_at, scopes, type FROM messages INNER JOIN users ON messages.user_id = users.id WHERE user_id IS '%s'", userID)
 	messages := getChat(query)
 
 	if len(messages) == 0 {
@@ -368,3 +423,56 @@ func saveMessageVisibility(messageIDs []string, visible bool) error {
 
 	return nil
 }
+
+// saveMessageReaction will add or remove a single user's emoji reaction on a
+// message and return the updated reaction counts for the message.
+func saveMessageReaction(messageID string, userID string, emoji string, remove bool) (map[string]int, error) {
+	defer func() {
+		_historyCache = nil
+	}()
+
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	tx, err := _datastore.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback() // nolint
+
+	row := rowData{id: messageID}
+	if err := tx.QueryRow("SELECT hidden_at, reactions FROM messages WHERE id = ?", messageID).Scan(&row.hiddenAt, &row.reactions); err != nil {
+		return nil, errors.New("unable to find message " + messageID + " to react to: " + err.Error())
+	}
+
+	if row.hiddenAt != nil {
+		return nil, errors.New("cannot react to hidden message " + messageID)
+	}
+
+	reactions, err := applyReaction(makeReactionsFromRowData(row), userID, emoji, remove)
+	if err != nil {
+		return nil, err
+	}
+
+	reactionsJSON, err := json.Marshal(reactions)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, err := tx.Prepare("UPDATE messages SET reactions = ? WHERE id = ?")
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	if _, err = stmt.Exec(string(reactionsJSON), messageID); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return getReactionCounts(reactions), nil
+}
@@ -0,0 +1,336 @@
+package chat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+	"github.com/teris-io/shortid"
+)
+
+const (
+	minPollDuration     = 10 * time.Second
+	maxPollDuration     = 24 * time.Hour
+	defaultPollDuration = 60 * time.Second
+	minPollOptions      = 2
+	maxPollOptions      = 10
+	maxPollTextLength   = 200
+)
+
+// The database is the source of truth for which poll is running, as it may
+// have been started on another node. _activePoll is only the poll started
+// or restored by this node, with the timer that ends it.
+var (
+	_pollLock   sync.Mutex
+	_activePoll *activePoll
+)
+
+type activePoll struct {
+	poll  models.Poll
+	timer *time.Timer
+}
+
+// StartPoll will start a new chat poll that closes automatically after the
+// given duration. Only a single poll can run at a time.
+func StartPoll(question string, options []string, duration time.Duration, createdBy string) (*models.Poll, error) {
+	question = strings.TrimSpace(question)
+	if question == "" || len(question) > maxPollTextLength {
+		return nil, fmt.Errorf("a poll question of up to %d characters is required", maxPollTextLength)
+	}
+
+	cleanedOptions := make([]string, 0, len(options))
+	for _, option := range options {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			continue
+		}
+		if len(option) > maxPollTextLength {
+			return nil, fmt.Errorf("poll options can be up to %d characters", maxPollTextLength)
+		}
+		cleanedOptions = append(cleanedOptions, option)
+	}
+
+	if len(cleanedOptions) < minPollOptions || len(cleanedOptions) > maxPollOptions {
+		return nil, fmt.Errorf("a poll requires between %d and %d options", minPollOptions, maxPollOptions)
+	}
+
+	if duration == 0 {
+		duration = defaultPollDuration
+	}
+	if duration < minPollDuration || duration > maxPollDuration {
+		return nil, fmt.Errorf("a poll must run between %s and %s", minPollDuration, maxPollDuration)
+	}
+
+	_pollLock.Lock()
+	defer _pollLock.Unlock()
+
+	running, err := getRunningPoll()
+	if err != nil {
+		return nil, err
+	}
+	if running != nil || _activePoll != nil {
+		return nil, errors.New("a poll is already running")
+	}
+
+	now := time.Now()
+	poll := models.Poll{
+		ID:        shortid.MustGenerate(),
+		Question:  question,
+		Options:   cleanedOptions,
+		Votes:     make([]int, len(cleanedOptions)),
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		EndsAt:    now.Add(duration),
+	}
+
+	if err := data.InsertPoll(poll); err != nil {
+		return nil, err
+	}
+
+	_activePoll = &activePoll{
+		poll:  poll,
+		timer: time.AfterFunc(duration, func() { endPollByTimer(poll.ID) }),
+	}
+
+	broadcastPoll(events.PollStarted, poll)
+
+	return &poll, nil
+}
+
+// EndPoll will close the currently running poll early and announce its results.
+func EndPoll(pollID string) error {
+	_pollLock.Lock()
+	defer _pollLock.Unlock()
+
+	if _activePoll != nil && _activePoll.poll.ID == pollID {
+		return endActivePoll()
+	}
+
+	// The poll may have been started on another node.
+	poll, err := data.GetPoll(pollID)
+	if err != nil || poll.ClosedAt != nil || time.Now().After(poll.EndsAt) {
+		return errors.New("poll " + pollID + " is not running")
+	}
+
+	return closePoll(*poll)
+}
+
+// GetActivePoll will return the currently running poll, if any.
+func GetActivePoll() *models.Poll {
+	poll, err := getRunningPoll()
+	if err != nil {
+		log.Errorln("unable to get the running poll", err)
+	}
+	return poll
+}
+
+// getRunningPoll will return the poll that is running on any node, if any.
+func getRunningPoll() (*models.Poll, error) {
+	polls, err := data.GetOpenPolls()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, poll := range polls {
+		if time.Now().Before(poll.EndsAt) {
+			return &poll, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func endPollByTimer(pollID string) {
+	_pollLock.Lock()
+	defer _pollLock.Unlock()
+
+	if _activePoll == nil || _activePoll.poll.ID != pollID {
+		return
+	}
+
+	// Another node may have ended it already.
+	if poll, err := data.GetPoll(pollID); err == nil && poll.ClosedAt != nil {
+		_activePoll.timer.Stop()
+		_activePoll = nil
+		return
+	}
+
+	if err := endActivePoll(); err != nil {
+		log.Errorln("unable to end poll", pollID, err)
+	}
+}
+
+// endActivePoll must be called with _pollLock held.
+func endActivePoll() error {
+	_activePoll.timer.Stop()
+
+	poll := _activePoll.poll
+	if err := closePoll(poll); err != nil {
+		return err
+	}
+	_activePoll = nil
+
+	return nil
+}
+
+// closePoll will close a poll and announce its results.
+func closePoll(poll models.Poll) error {
+	closedAt := time.Now()
+	if err := data.SetPollClosed(poll.ID, closedAt); err != nil {
+		return err
+	}
+	poll.ClosedAt = &closedAt
+
+	// Include the votes cast on every node.
+	if updated, err := data.GetPoll(poll.ID); err == nil {
+		poll.Votes = updated.Votes
+	}
+
+	broadcastPoll(events.PollEnded, poll)
+
+	if err := SendSystemMessage(getPollResultsMessage(poll), false); err != nil {
+		log.Errorln("unable to send poll results", err)
+	}
+
+	return nil
+}
+
+// restorePolls will resume any poll that was running when the server stopped,
+// or close it if it should have ended in the meantime.
+func restorePolls() {
+	polls, err := data.GetOpenPolls()
+	if err != nil {
+		log.Errorln("unable to load open polls", err)
+		return
+	}
+
+	_pollLock.Lock()
+	defer _pollLock.Unlock()
+
+	for _, poll := range polls {
+		remaining := time.Until(poll.EndsAt)
+		if _activePoll != nil || remaining <= 0 {
+			if err := data.SetPollClosed(poll.ID, time.Now()); err != nil {
+				log.Errorln("unable to close expired poll", poll.ID, err)
+			}
+			continue
+		}
+
+		pollID := poll.ID
+		_activePoll = &activePoll{
+			poll:  poll,
+			timer: time.AfterFunc(remaining, func() { endPollByTimer(pollID) }),
+		}
+	}
+}
+
+func (s *Server) userPollVoteSent(eventData chatClientEvent) {
+	var event events.PollVoteEvent
+	if err := json.Unmarshal(eventData.data, &event); err != nil {
+		log.Errorln("error unmarshalling to PollVoteEvent", err)
+		return
+	}
+
+	u := eventData.client.User
+
+	// Guard against nil users
+	if u == nil {
+		return
+	}
+
+	// The poll may have been started on another node, so the database is
+	// used as the source of truth rather than the locally running poll.
+	poll, err := data.GetPoll(event.PollID)
+	if err != nil || poll.ClosedAt != nil || time.Now().After(poll.EndsAt) {
+		s.sendActionToClient(eventData.client, "Sorry, this poll has ended.")
+		return
+	}
+
+	if event.Option < 0 || event.Option >= len(poll.Options) {
+		return
+	}
+
+	voted, err := data.SavePollVote(poll.ID, u.ID, event.Option)
+	if err != nil {
+		log.Errorln("unable to save poll vote", err)
+		return
+	}
+
+	if !voted {
+		s.sendActionToClient(eventData.client, "You have already voted in this poll.")
+		return
+	}
+
+	// Get the updated tallies including votes from every node.
+	if poll, err = data.GetPoll(poll.ID); err != nil {
+		log.Errorln("unable to get poll", err)
+		return
+	}
+
+	_pollLock.Lock()
+	if _activePoll != nil && _activePoll.poll.ID == poll.ID {
+		_activePoll.poll.Votes = poll.Votes
+	}
+	_pollLock.Unlock()
+
+	broadcastPoll(events.PollUpdated, *poll)
+
+	_lastSeenCache[u.ID] = time.Now()
+}
+
+func (s *Server) sendActivePollToClient(c *Client) {
+	poll := GetActivePoll()
+	if poll == nil {
+		return
+	}
+
+	event := events.PollEvent{Poll: *poll}
+	event.SetDefaults()
+	event.Type = events.PollStarted
+	s.Send(event.GetBroadcastPayload(), c)
+}
+
+func broadcastPoll(eventType events.EventType, poll models.Poll) {
+	event := events.PollEvent{Poll: copyPoll(poll)}
+	event.SetDefaults()
+	event.Type = eventType
+
+	if err := Broadcast(&event); err != nil {
+		log.Errorln("error broadcasting poll", eventType, err)
+	}
+}
+
+func copyPoll(poll models.Poll) models.Poll {
+	votes := make([]int, len(poll.Votes))
+	copy(votes, poll.Votes)
+	poll.Votes = votes
+	return poll
+}
+
+// getPollResultsMessage will return the markdown summary of a finished poll.
+// Poll text is user supplied and system messages are not sanitized, so it
+// is escaped here.
+func getPollResultsMessage(poll models.Poll) string {
+	total := poll.TotalVotes()
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "**Poll results: %s**\n\n", html.EscapeString(poll.Question))
+	for i, option := range poll.Options {
+		percent := 0
+		if total > 0 {
+			percent = poll.Votes[i] * 100 / total
+		}
+		fmt.Fprintf(&sb, "- %s: %d (%d%%)\n", html.EscapeString(option), poll.Votes[i], percent)
+	}
+	fmt.Fprintf(&sb, "\n%d total votes", total)
+
+	return sb.String()
+}
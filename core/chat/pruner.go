@@ -3,16 +3,22 @@ package chat
 import (
 	"fmt"
 
+	"github.com/owncast/owncast/core/data"
 	log "github.com/sirupsen/logrus"
 )
 
 // Only keep recent messages so we don't keep more chat data than needed
 // for privacy and efficiency reasons.
 func runPruner() {
+	retentionHours := data.GetChatRetentionHours()
+	if retentionHours <= 0 {
+		return
+	}
+
 	_datastore.DbLock.Lock()
 	defer _datastore.DbLock.Unlock()
 
-	log.Traceln("Removing chat messages older than", maxBacklogHours, "hours")
+	log.Traceln("Removing chat messages older than", retentionHours, "hours")
 
 	deleteStatement := `DELETE FROM messages WHERE timestamp <= datetime('now', 'localtime', ?)`
 	tx, err := _datastore.DB.Begin()
@@ -28,7 +34,7 @@ func runPruner() {
 	}
 	defer stmt.Close()
 
-	if _, err = stmt.Exec(fmt.Sprintf("-%d hours", maxBacklogHours)); err != nil {
+	if _, err = stmt.Exec(fmt.Sprintf("-%d hours", retentionHours)); err != nil {
 		log.Debugln(err)
 		return
 	}
@@ -36,4 +42,6 @@ func runPruner() {
 		log.Debugln(err)
 		return
 	}
+
+	_historyCache = nil
 }
@@ -0,0 +1,284 @@
+package chat
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/pubsub"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/core/webhooks"
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// RaidProtectionStatus is the current state of chat raid protection.
+type RaidProtectionStatus struct {
+	Active    bool       `json:"active"`
+	Reason    string     `json:"reason,omitempty"`
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+	EndsAt    *time.Time `json:"endsAt,omitempty"`
+}
+
+// raidProtectionCommand is shared between nodes so that every node protects
+// chat at the same time. Only the origin node sends notifications.
+type raidProtectionCommand struct {
+	Status RaidProtectionStatus `json:"status"`
+	Origin string               `json:"origin"`
+}
+
+// activityCounter counts events within a sliding window.
+type activityCounter struct {
+	times []time.Time
+}
+
+// add will record an event and return how many took place within the window.
+func (a *activityCounter) add(now time.Time, window time.Duration) int {
+	a.prune(now, window)
+	a.times = append(a.times, now)
+	return len(a.times)
+}
+
+func (a *activityCounter) prune(now time.Time, window time.Duration) {
+	cutoff := now.Add(-window)
+	i := 0
+	for i < len(a.times) && a.times[i].Before(cutoff) {
+		i++
+	}
+	a.times = a.times[i:]
+}
+
+type raidProtection struct {
+	mu            sync.Mutex
+	registrations activityCounter
+	connections   activityCounter
+	messages      map[string]*activityCounter
+	status        RaidProtectionStatus
+	origin        string
+	timer         *time.Timer
+}
+
+var _raidProtection = &raidProtection{messages: map[string]*activityCounter{}}
+
+// RecordNewChatUser will count a new chat user registration towards the raid
+// protection threshold.
+func RecordNewChatUser() {
+	config := data.GetChatRaidProtectionConfig()
+	if !config.Enabled || config.NewUserThreshold == 0 {
+		return
+	}
+
+	_raidProtection.mu.Lock()
+	count := _raidProtection.registrations.add(time.Now(), time.Duration(config.WindowSeconds)*time.Second)
+	_raidProtection.mu.Unlock()
+
+	if count > config.NewUserThreshold {
+		triggerRaidProtection(config, fmt.Sprintf("%d new chat users in %d seconds", count, config.WindowSeconds))
+	}
+}
+
+func recordRaidConnection() {
+	config := data.GetChatRaidProtectionConfig()
+	if !config.Enabled || config.ConnectionThreshold == 0 {
+		return
+	}
+
+	_raidProtection.mu.Lock()
+	count := _raidProtection.connections.add(time.Now(), time.Duration(config.WindowSeconds)*time.Second)
+	_raidProtection.mu.Unlock()
+
+	if count > config.ConnectionThreshold {
+		triggerRaidProtection(config, fmt.Sprintf("%d chat connections in %d seconds", count, config.WindowSeconds))
+	}
+}
+
+func recordRaidMessage(u *user.User, body string) {
+	config := data.GetChatRaidProtectionConfig()
+	if !config.Enabled || config.DuplicateMessageThreshold == 0 || u.IsModerator() {
+		return
+	}
+
+	// Small differences in case and spacing are the same message.
+	key := strings.ToLower(strings.Join(strings.Fields(body), " "))
+	if key == "" {
+		return
+	}
+
+	now := time.Now()
+	window := time.Duration(config.WindowSeconds) * time.Second
+
+	_raidProtection.mu.Lock()
+	for message, counter := range _raidProtection.messages {
+		if counter.prune(now, window); len(counter.times) == 0 {
+			delete(_raidProtection.messages, message)
+		}
+	}
+
+	counter, ok := _raidProtection.messages[key]
+	if !ok {
+		counter = &activityCounter{}
+		_raidProtection.messages[key] = counter
+	}
+	count := counter.add(now, window)
+	_raidProtection.mu.Unlock()
+
+	if count > config.DuplicateMessageThreshold {
+		triggerRaidProtection(config, fmt.Sprintf("%d duplicate chat messages in %d seconds", count, config.WindowSeconds))
+	}
+}
+
+// triggerRaidProtection will start protecting chat, or keep protecting it
+// for longer if it already is.
+func triggerRaidProtection(config models.RaidProtectionConfiguration, reason string) {
+	cooldown := time.Duration(config.CooldownSeconds) * time.Second
+
+	_raidProtection.mu.Lock()
+	status := _raidProtection.status
+	origin := _raidProtection.origin
+	_raidProtection.mu.Unlock()
+
+	// Avoid flooding the other nodes while a raid keeps going.
+	if status.Active && time.Until(*status.EndsAt) > cooldown/2 {
+		return
+	}
+
+	now := time.Now()
+	endsAt := now.Add(cooldown)
+	if status.Active {
+		status.EndsAt = &endsAt
+	} else {
+		status = RaidProtectionStatus{Active: true, Reason: reason, StartedAt: &now, EndsAt: &endsAt}
+		origin = pubsub.NodeID
+	}
+
+	if err := publishRaidProtection(raidProtectionCommand{Status: status, Origin: origin}); err != nil {
+		log.Errorln("unable to start raid protection", err)
+	}
+}
+
+// StartRaidProtection will protect chat for the configured cooldown, even
+// if automatic raid protection is disabled.
+func StartRaidProtection(reason string) error {
+	if reason == "" {
+		reason = "started manually"
+	}
+
+	now := time.Now()
+	endsAt := now.Add(time.Duration(data.GetChatRaidProtectionConfig().CooldownSeconds) * time.Second)
+	status := RaidProtectionStatus{Active: true, Reason: reason, StartedAt: &now, EndsAt: &endsAt}
+
+	return publishRaidProtection(raidProtectionCommand{Status: status, Origin: pubsub.NodeID})
+}
+
+// EndRaidProtection will stop protecting chat before the cooldown ends.
+func EndRaidProtection() error {
+	return publishRaidProtection(raidProtectionCommand{Origin: pubsub.NodeID})
+}
+
+// GetRaidProtectionStatus will return if chat is being protected from a raid.
+func GetRaidProtectionStatus() RaidProtectionStatus {
+	_raidProtection.mu.Lock()
+	defer _raidProtection.mu.Unlock()
+
+	return _raidProtection.status
+}
+
+// getActiveRaidProtection will return the restrictions to apply if chat is
+// being protected from a raid.
+func getActiveRaidProtection() (models.RaidProtectionConfiguration, bool) {
+	if !GetRaidProtectionStatus().Active {
+		return models.RaidProtectionConfiguration{}, false
+	}
+
+	return data.GetChatRaidProtectionConfig(), true
+}
+
+func publishRaidProtection(command raidProtectionCommand) error {
+	payload, err := json.Marshal(command)
+	if err != nil {
+		return err
+	}
+
+	return pubsub.Publish(raidProtectionTopic, payload)
+}
+
+func raidProtectionReceived(payload []byte) {
+	var command raidProtectionCommand
+	if err := json.Unmarshal(payload, &command); err != nil {
+		log.Errorln("error unmarshalling raid protection command", err)
+		return
+	}
+
+	_raidProtection.mu.Lock()
+	previous := _raidProtection.status
+
+	if _raidProtection.timer != nil {
+		_raidProtection.timer.Stop()
+		_raidProtection.timer = nil
+	}
+
+	_raidProtection.status = command.Status
+	_raidProtection.origin = command.Origin
+	if command.Status.Active {
+		_raidProtection.timer = time.AfterFunc(time.Until(*command.Status.EndsAt), expireRaidProtection)
+	} else {
+		_raidProtection.origin = ""
+	}
+	_raidProtection.mu.Unlock()
+
+	if command.Origin != pubsub.NodeID {
+		return
+	}
+
+	if command.Status.Active && !previous.Active {
+		raidProtectionStarted(command.Status)
+	} else if !command.Status.Active && previous.Active {
+		raidProtectionEnded(previous)
+	}
+}
+
+func expireRaidProtection() {
+	_raidProtection.mu.Lock()
+	status := _raidProtection.status
+	isOrigin := _raidProtection.origin == pubsub.NodeID
+
+	if !status.Active || time.Now().Before(*status.EndsAt) {
+		_raidProtection.mu.Unlock()
+		return
+	}
+
+	_raidProtection.status = RaidProtectionStatus{}
+	_raidProtection.origin = ""
+	_raidProtection.timer = nil
+	_raidProtection.mu.Unlock()
+
+	if isOrigin {
+		raidProtectionEnded(status)
+	}
+}
+
+func raidProtectionStarted(status RaidProtectionStatus) {
+	log.Warnln("Chat raid protection started:", status.Reason)
+
+	_ = SendSystemAction("Chat is temporarily restricted while it is protected from a raid.", true)
+
+	text := fmt.Sprintf("Raid protection has started because of %s. Chat will be restricted until %s unless it continues.", status.Reason, status.EndsAt.Format(time.Kitchen))
+	for _, moderator := range user.GetModeratorUsers() {
+		if err := SendActionToUser(moderator.ID, text); err != nil {
+			log.Debugln(err)
+		}
+	}
+
+	webhooks.SendRaidProtectionEvent(models.RaidProtectionStarted, status)
+}
+
+func raidProtectionEnded(status RaidProtectionStatus) {
+	log.Infoln("Chat raid protection ended")
+
+	_ = SendSystemAction("Chat is no longer restricted.", true)
+
+	webhooks.SendRaidProtectionEvent(models.RaidProtectionEnded, status)
+}
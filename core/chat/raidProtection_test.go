@@ -0,0 +1,25 @@
+package chat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestActivityCounter(t *testing.T) {
+	counter := activityCounter{}
+	window := 10 * time.Second
+	start := time.Now()
+
+	for i := 0; i < 5; i++ {
+		counter.add(start.Add(time.Duration(i)*time.Second), window)
+	}
+
+	if count := counter.add(start.Add(9*time.Second), window); count != 6 {
+		t.Errorf("expected 6 events within the window, got %d", count)
+	}
+
+	// The first three events are now outside of the window.
+	if count := counter.add(start.Add(12*time.Second+time.Millisecond), window); count != 4 {
+		t.Errorf("expected 4 events within the window, got %d", count)
+	}
+}
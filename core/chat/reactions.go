@@ -0,0 +1,157 @@
+package chat
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/data"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// The number of different emoji a single message can be reacted with.
+	maxReactionTypesPerMessage = 20
+
+	// Longest emoji sequence (including modifiers and joiners) we accept.
+	maxEmojiRuneLength = 12
+
+	zeroWidthJoiner = '\u200d'
+	combiningKeycap = '\u20e3'
+)
+
+func (s *Server) userReactionSent(eventData chatClientEvent) {
+	var event events.MessageReactionEvent
+	if err := json.Unmarshal(eventData.data, &event); err != nil {
+		log.Errorln("error unmarshalling to MessageReactionEvent", err)
+		return
+	}
+
+	u := eventData.client.User
+
+	// Guard against nil users
+	if u == nil || event.MessageID == "" {
+		return
+	}
+
+	emoji, valid := normalizeReactionEmoji(event.Emoji)
+	if !valid {
+		s.sendActionToClient(eventData.client, "Sorry, you can only react to messages with an emoji.")
+		return
+	}
+
+	reactions, err := saveMessageReaction(event.MessageID, u.ID, emoji, event.Remove)
+	if err != nil {
+		log.Debugln("unable to save reaction", err)
+		return
+	}
+
+	update := events.ReactionUpdateEvent{
+		MessageID: event.MessageID,
+		Reactions: reactions,
+	}
+	update.SetDefaults()
+
+	if err := s.Broadcast(update.GetBroadcastPayload()); err != nil {
+		log.Errorln("error broadcasting ReactionUpdateEvent payload", err)
+		return
+	}
+
+	_lastSeenCache[u.ID] = time.Now()
+}
+
+// normalizeReactionEmoji will return the key a reaction is stored under and
+// if it is a valid reaction. Custom emoji are referenced by :name: and unicode
+// emoji are used as-is.
+func normalizeReactionEmoji(emoji string) (string, bool) {
+	emoji = strings.TrimSpace(emoji)
+
+	if name := strings.Trim(emoji, ":"); len(emoji) > 2 && name != emoji {
+		for _, customEmoji := range data.GetEmojiList() {
+			if customEmoji.Name == name {
+				return ":" + name + ":", true
+			}
+		}
+		return "", false
+	}
+
+	return emoji, isUnicodeEmoji(emoji)
+}
+
+// isUnicodeEmoji will return if a string consists only of the symbols,
+// modifiers and joiners that make up a single unicode emoji sequence.
+func isUnicodeEmoji(emoji string) bool {
+	if emoji == "" || utf8.RuneCountInString(emoji) > maxEmojiRuneLength {
+		return false
+	}
+
+	for _, r := range emoji {
+		switch {
+		case r < utf8.RuneSelf:
+			return false
+		case r == zeroWidthJoiner, r == combiningKeycap, unicode.Is(unicode.Variation_Selector, r):
+		case r >= 0xe0020 && r <= 0xe007f: // Tag characters used by subdivision flags.
+		case unicode.Is(unicode.So, r), unicode.Is(unicode.Sk, r):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// applyReaction will add or remove a user from the list of users that
+// reacted to a message with an emoji.
+func applyReaction(reactions map[string][]string, userID string, emoji string, remove bool) (map[string][]string, error) {
+	users := reactions[emoji]
+	index := -1
+	for i, id := range users {
+		if id == userID {
+			index = i
+			break
+		}
+	}
+
+	if remove {
+		if index == -1 {
+			return reactions, nil
+		}
+		users = append(users[:index], users[index+1:]...)
+		if len(users) == 0 {
+			delete(reactions, emoji)
+		} else {
+			reactions[emoji] = users
+		}
+		return reactions, nil
+	}
+
+	if index != -1 {
+		return reactions, nil
+	}
+
+	if _, exists := reactions[emoji]; !exists && len(reactions) >= maxReactionTypesPerMessage {
+		return nil, errors.New("message has reached the maximum number of different reactions")
+	}
+
+	reactions[emoji] = append(users, userID)
+	return reactions, nil
+}
+
+// getReactionCounts will return the number of users that reacted to a
+// message with each emoji.
+func getReactionCounts(reactions map[string][]string) map[string]int {
+	if len(reactions) == 0 {
+		return nil
+	}
+
+	counts := make(map[string]int, len(reactions))
+	for emoji, users := range reactions {
+		counts[emoji] = len(users)
+	}
+
+	return counts
+}
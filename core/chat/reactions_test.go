@@ -0,0 +1,47 @@
+package chat
+
+import "testing"
+
+func TestIsUnicodeEmoji(t *testing.T) {
+	valid := []string{"👍", "❤️", "👍🏽", "👩‍💻", "🏳️‍🌈", "🇨🇦"}
+	for _, emoji := range valid {
+		if !isUnicodeEmoji(emoji) {
+			t.Errorf("expected %s to be a valid emoji", emoji)
+		}
+	}
+
+	invalid := []string{"", "+1", "lol", "👍 nice", "<img src=x>", "👍👍👍👍👍👍👍👍👍👍👍👍👍"}
+	for _, emoji := range invalid {
+		if isUnicodeEmoji(emoji) {
+			t.Errorf("expected %s to be an invalid emoji", emoji)
+		}
+	}
+}
+
+func TestApplyReaction(t *testing.T) {
+	reactions := map[string][]string{}
+
+	reactions, _ = applyReaction(reactions, "user-1", "👍", false)
+	reactions, _ = applyReaction(reactions, "user-2", "👍", false)
+	reactions, _ = applyReaction(reactions, "user-2", "👍", false)
+	reactions, _ = applyReaction(reactions, "user-1", "🎉", false)
+
+	counts := getReactionCounts(reactions)
+	if counts["👍"] != 2 {
+		t.Errorf("expected 2 👍 reactions, got %d", counts["👍"])
+	}
+	if counts["🎉"] != 1 {
+		t.Errorf("expected 1 🎉 reaction, got %d", counts["🎉"])
+	}
+
+	reactions, _ = applyReaction(reactions, "user-1", "🎉", true)
+	reactions, _ = applyReaction(reactions, "user-3", "👍", true)
+
+	counts = getReactionCounts(reactions)
+	if _, exists := counts["🎉"]; exists {
+		t.Error("expected 🎉 reaction to be removed")
+	}
+	if counts["👍"] != 2 {
+		t.Errorf("expected 2 👍 reactions, got %d", counts["👍"])
+	}
+}
@@ -0,0 +1,62 @@
+package chat
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
+)
+
+// When each user last sent a chat message, for slow mode.
+var _lastMessageSentCache = map[string]time.Time{}
+
+// passesMessageRestrictions will check a message against slow mode and the
+// link and emoji restrictions, letting the client know why it was rejected.
+func (s *Server) passesMessageRestrictions(c *Client, event *events.UserMessageEvent) bool {
+	u := event.User
+
+	slowMode := time.Duration(data.GetChatSlowModeDuration()) * time.Second
+	linksRestricted := data.GetChatLinksRestricted()
+
+	// Raid protection can only make chat more restrictive.
+	if raidProtection, active := getActiveRaidProtection(); active {
+		if raidSlowMode := time.Duration(raidProtection.SlowModeDuration) * time.Second; raidSlowMode > slowMode {
+			slowMode = raidSlowMode
+		}
+		linksRestricted = linksRestricted || raidProtection.BlockLinks
+	}
+
+	if slowMode > 0 && !u.HasPermission(user.RolePermissionBypassSlowMode) {
+		if lastSent, ok := _lastMessageSentCache[u.ID]; ok && time.Since(lastSent) < slowMode {
+			wait := (slowMode - time.Since(lastSent)).Round(time.Second)
+			if wait < time.Second {
+				wait = time.Second
+			}
+			s.sendActionToClient(c, fmt.Sprintf("Slow mode is enabled. You can send another message in %s.", wait))
+			return false
+		}
+	}
+
+	if linksRestricted && !u.HasPermission(user.RolePermissionPostLinks) && event.ContainsLink() {
+		s.sendActionToClient(c, "You do not have permission to post links in this chat.")
+		return false
+	}
+
+	if restricted := data.GetChatRestrictedEmoji(); len(restricted) > 0 && !u.HasPermission(user.RolePermissionUseRestrictedEmoji) {
+		for _, name := range event.GetCustomEmojiNames() {
+			for _, restrictedName := range restricted {
+				if strings.EqualFold(name, restrictedName) {
+					s.sendActionToClient(c, fmt.Sprintf("You do not have permission to use the :%s: emoji.", name))
+					return false
+				}
+			}
+		}
+	}
+
+	_lastMessageSentCache[u.ID] = time.Now()
+
+	return true
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/owncast/owncast/config"
 	"github.com/owncast/owncast/core/chat/events"
 	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/pubsub"
 	"github.com/owncast/owncast/core/user"
 	"github.com/owncast/owncast/core/webhooks"
 	"github.com/owncast/owncast/geoip"
@@ -112,6 +113,7 @@ func (s *Server) Addclient(conn *websocket.Conn, user *user.User, accessToken st
 	go client.readPump()
 
 	client.sendConnectedClientInfo()
+	s.sendActivePollToClient(client)
 
 	if getStatus().Online {
 		if shouldSendJoinedMessages {
@@ -120,6 +122,9 @@ func (s *Server) Addclient(conn *websocket.Conn, user *user.User, accessToken st
 		s.sendWelcomeMessageToClient(client)
 	}
 
+	go checkForBanEvasion(client)
+	go recordRaidConnection()
+
 	// Asynchronously, optionally, fetch GeoIP data.
 	go func(client *Client) {
 		client.Geo = s.geoipClient.GetGeoFromIP(ipAddress)
@@ -161,7 +166,7 @@ func (s *Server) HandleClientConnection(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	ipAddress := utils.GetIPAddressFromRequest(r)
+	ipAddress := utils.GetClientIPAddress(r)
 	// Check if this client's IP address is banned. If so send a rejection.
 	if blocked, err := data.IsIPAddressBanned(ipAddress); blocked {
 		log.Debugln("Client ip address has been blocked. Rejecting.")
@@ -224,13 +229,31 @@ func (s *Server) HandleClientConnection(w http.ResponseWriter, r *http.Request)
 	s.Addclient(conn, user, accessToken, userAgent, ipAddress)
 }
 
-// Broadcast sends message to all connected clients.
+// Broadcast sends message to all connected clients, on every Owncast node.
+// Clients on this node receive it right away, other nodes receive it once
+// it has been published.
 func (s *Server) Broadcast(payload events.EventPayload) error {
 	data, err := json.Marshal(payload)
 	if err != nil {
 		return err
 	}
 
+	s.broadcastLocal(data)
+
+	message, err := json.Marshal(broadcastMessage{Origin: pubsub.NodeID, Payload: data})
+	if err != nil {
+		return err
+	}
+	pubsub.PublishAsync(broadcastTopic, message)
+
+	return nil
+}
+
+// broadcastLocal sends an already encoded message to the clients connected
+// to this node and the read-only event stream subscribers.
+func (s *Server) broadcastLocal(data []byte) {
+	sendToEventStreamSubscribers(data)
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -245,8 +268,6 @@ func (s *Server) Broadcast(payload events.EventPayload) error {
 			go client.close()
 		}
 	}
-
-	return nil
 }
 
 // Send will send a single payload to a single connected client.
@@ -289,41 +310,25 @@ func (s *Server) DisconnectClients(clients []*Client) {
 // SendConnectedClientInfoToUser will find all the connected clients assigned to a user
 // and re-send each the connected client info.
 func SendConnectedClientInfoToUser(userID string) error {
-	clients, err := GetClientsForUser(userID)
-	if err != nil {
-		return err
-	}
-
-	// Get an updated reference to the user.
-	user := user.GetUserByID(userID)
-	if user == nil {
-		return fmt.Errorf("user not found")
-	}
-
-	if err != nil {
-		return err
-	}
-
-	for _, client := range clients {
-		// Update the client's reference to its user.
-		client.User = user
-		// Send the update to the client.
-		client.sendConnectedClientInfo()
-	}
-
-	return nil
+	return publishUserCommand(userCommand{Command: refreshUserCommand, UserID: userID})
 }
 
 // SendActionToUser will send system action text to all connected clients
 // assigned to a user ID.
 func SendActionToUser(userID string, text string) error {
-	clients, err := GetClientsForUser(userID)
-	if err != nil {
+	return publishUserCommand(userCommand{Command: actionUserCommand, UserID: userID, Text: text})
+}
+
+// DisconnectUser will forcefully disconnect all clients belonging to a user
+// and ban the IP addresses they connected from on behalf of the actor.
+func DisconnectUser(userID string, actor string) error {
+	if err := publishUserCommand(userCommand{Command: disconnectUserCommand, UserID: userID, Actor: actor}); err != nil {
 		return err
 	}
 
-	for _, client := range clients {
-		_server.sendActionToClient(client, text)
+	// Announce the removal once, rather than from every node.
+	if disconnectedUser := user.GetUserByID(userID); disconnectedUser != nil {
+		_ = SendSystemAction(fmt.Sprintf("**%s** has been removed from chat.", disconnectedUser.DisplayName), true)
 	}
 
 	return nil
@@ -335,7 +340,9 @@ func (s *Server) eventReceived(event chatClientEvent) {
 
 	// If established chat user only mode is enabled and the user is not old
 	// enough then reject this event and send them an informative message.
-	if u != nil && data.GetChatEstbalishedUsersOnlyMode() && time.Since(event.client.User.CreatedAt) < config.GetDefaults().ChatEstablishedUserModeTimeDuration && !u.IsModerator() {
+	raidProtection, raidProtectionActive := getActiveRaidProtection()
+	establishedUsersOnly := data.GetChatEstbalishedUsersOnlyMode() || (raidProtectionActive && raidProtection.EstablishedUsersOnly)
+	if u != nil && establishedUsersOnly && time.Since(event.client.User.CreatedAt) < config.GetDefaults().ChatEstablishedUserModeTimeDuration && !u.IsModerator() {
 		s.sendActionToClient(c, "You have not been an established chat participant long enough to take part in chat. Please enjoy the stream and try again later.")
 		return
 	}
@@ -354,6 +361,12 @@ func (s *Server) eventReceived(event chatClientEvent) {
 	case events.UserNameChanged:
 		s.userNameChanged(event)
 
+	case events.MessageReactionSent:
+		s.userReactionSent(event)
+
+	case events.PollVoteSent:
+		s.userPollVoteSent(event)
+
 	default:
 		log.Debugln(eventType, "event not found:", typecheck)
 	}
@@ -0,0 +1,169 @@
+package chat
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/data"
+)
+
+// How long each chat event is shown for in subtitle formats.
+const transcriptCueDuration = 5 * time.Second
+
+// TranscriptEntry is a single chat event in a broadcast transcript.
+type TranscriptEntry struct {
+	ID        string           `json:"id"`
+	Type      events.EventType `json:"type"`
+	Timestamp time.Time        `json:"timestamp"`
+	// Offset is how long after the broadcast started the event took place.
+	Offset        time.Duration `json:"-"`
+	OffsetSeconds float64       `json:"offset"`
+	Author        string        `json:"author,omitempty"`
+	// Text is the message body with any markup removed.
+	Text  string      `json:"text"`
+	Event interface{} `json:"event"`
+}
+
+// GetChatTranscript will return the visible chat events that took place
+// between the start and end of a broadcast, oldest first.
+func GetChatTranscript(start time.Time, end time.Time) []TranscriptEntry {
+	// Unlike the chat history this includes events without a user, such as
+	// system messages and fediverse engagement.
+	query := "SELECT " + historyColumns + " FROM messages LEFT JOIN users ON messages.user_id = users.id WHERE messages.hidden_at IS NULL AND users.disabled_at IS NULL AND messages.timestamp >= ? AND messages.timestamp <= ? ORDER BY messages.timestamp ASC, messages.id ASC"
+	history := getChat(query, start.Local(), end.Local())
+
+	transcript := make([]TranscriptEntry, 0, len(history))
+	for _, event := range history {
+		entry, ok := newTranscriptEntry(event)
+		if !ok {
+			continue
+		}
+
+		entry.Offset = entry.Timestamp.Sub(start)
+		if entry.Offset < 0 {
+			entry.Offset = 0
+		}
+		entry.OffsetSeconds = entry.Offset.Seconds()
+		transcript = append(transcript, entry)
+	}
+
+	return transcript
+}
+
+func newTranscriptEntry(event interface{}) (TranscriptEntry, bool) {
+	entry := TranscriptEntry{Event: event}
+
+	var e events.Event
+	var body string
+
+	switch message := event.(type) {
+	case events.UserMessageEvent:
+		e, body = message.Event, message.Body
+		entry.Author = message.User.DisplayName
+	case events.SystemMessageEvent:
+		e, body = message.Event, message.Body
+		entry.Author = data.GetServerName()
+	case events.ActionEvent:
+		e, body = message.Event, message.Body
+	case events.FediverseEngagementEvent:
+		e, body = message.Event, message.Body
+		entry.Author = message.UserAccountName
+	default:
+		return entry, false
+	}
+
+	entry.ID = e.ID
+	entry.Type = e.Type
+	entry.Timestamp = e.Timestamp
+	entry.Text = getPlainText(body)
+
+	return entry, true
+}
+
+// getPlainText will turn a rendered message body into plain text.
+func getPlainText(body string) string {
+	text := bluemonday.StrictPolicy().Sanitize(body)
+	return strings.Join(strings.Fields(html.UnescapeString(text)), " ")
+}
+
+// WriteTranscriptCSV will write a chat transcript as CSV.
+func WriteTranscriptCSV(w io.Writer, transcript []TranscriptEntry) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"id", "timestamp", "offset", "type", "author", "text"}); err != nil {
+		return err
+	}
+
+	for _, entry := range transcript {
+		record := []string{
+			entry.ID,
+			entry.Timestamp.Format(time.RFC3339),
+			strconv.FormatFloat(entry.Offset.Seconds(), 'f', 3, 64),
+			string(entry.Type),
+			entry.Author,
+			entry.Text,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteTranscriptWebVTT will write a chat transcript as WebVTT captions
+// timed from the start of the broadcast.
+func WriteTranscriptWebVTT(w io.Writer, transcript []TranscriptEntry) error {
+	if _, err := io.WriteString(w, "WEBVTT\n"); err != nil {
+		return err
+	}
+
+	for _, entry := range transcript {
+		start := formatCueTime(entry.Offset, ".")
+		end := formatCueTime(entry.Offset+transcriptCueDuration, ".")
+		if _, err := fmt.Fprintf(w, "\n%s\n%s --> %s\n%s\n", entry.ID, start, end, getCueText(entry)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteTranscriptSRT will write a chat transcript as SRT subtitles timed
+// from the start of the broadcast.
+func WriteTranscriptSRT(w io.Writer, transcript []TranscriptEntry) error {
+	for i, entry := range transcript {
+		start := formatCueTime(entry.Offset, ",")
+		end := formatCueTime(entry.Offset+transcriptCueDuration, ",")
+		if _, err := fmt.Fprintf(w, "%d\n%s --> %s\n%s\n\n", i+1, start, end, getCueText(entry)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func getCueText(entry TranscriptEntry) string {
+	text := entry.Text
+	if entry.Author != "" {
+		text = entry.Author + ": " + text
+	}
+
+	// Subtitle cue text is markup, so escape what was unescaped for plain text.
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
+
+// formatCueTime will format an offset as HH:MM:SS followed by the
+// milliseconds using the separator of the subtitle format.
+func formatCueTime(offset time.Duration, millisecondSeparator string) string {
+	ms := offset.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", ms/3600000, ms/60000%60, ms/1000%60, millisecondSeparator, ms%1000)
+}
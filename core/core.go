@@ -1,6 +1,7 @@
 package core
 
 import (
+	"fmt"
 	"os"
 	"path"
 	"path/filepath"
@@ -86,15 +87,13 @@ func Start() error {
 }
 
 func createInitialOfflineState() error {
-	transitionToOfflineVideoStreamContent()
-
-	return nil
+	return transitionToOfflineVideoStreamContent()
 }
 
 // transitionToOfflineVideoStreamContent will overwrite the current stream with the
 // offline video stream state only.  No live stream HLS segments will continue to be
 // referenced.
-func transitionToOfflineVideoStreamContent() {
+func transitionToOfflineVideoStreamContent() error {
 	log.Traceln("Firing transcoder with offline stream state")
 
 	_transcoder := transcoder.NewTranscoder()
@@ -102,9 +101,9 @@ func transitionToOfflineVideoStreamContent() {
 	_transcoder.SetLatencyLevel(models.GetLatencyLevel(4))
 	_transcoder.SetIsEvent(true)
 
-	offlineFilePath, err := saveOfflineClipToDisk("offline.ts")
+	offlineFilePath, _, err := saveOfflineClipToDisk("offline.ts")
 	if err != nil {
-		log.Fatalln("unable to save offline clip:", err)
+		return fmt.Errorf("unable to save offline clip: %w", err)
 	}
 
 	_transcoder.SetInput(offlineFilePath)
@@ -118,6 +117,8 @@ func transitionToOfflineVideoStreamContent() {
 
 	// Delete the preview Gif
 	_ = os.Remove(path.Join(config.WebRoot, "preview.gif"))
+
+	return nil
 }
 
 func resetDirectories() {
@@ -0,0 +1,59 @@
+package data
+
+import (
+	"database/sql"
+
+	"github.com/owncast/owncast/models"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+func createActionAuditTable(db *sql.DB) {
+	log.Traceln("Creating action audit table...")
+
+	createTableSQL := `CREATE TABLE IF NOT EXISTS action_audit_log (
+		"id" INTEGER PRIMARY KEY AUTOINCREMENT,
+		"action" TEXT NOT NULL,
+		"actor" TEXT NOT NULL,
+		"parameters" TEXT NOT NULL,
+		"success" BOOLEAN NOT NULL,
+		"result" TEXT NOT NULL DEFAULT '',
+		"timestamp" TIMESTAMP NOT NULL
+	);`
+
+	if _, err := db.Exec(createTableSQL); err != nil {
+		log.Warnln(err)
+	}
+}
+
+// InsertActionAuditEntry will record an attempt to trigger an action.
+func InsertActionAuditEntry(entry models.ActionAuditEntry) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	_, err := _db.Exec("INSERT INTO action_audit_log(action, actor, parameters, success, result, timestamp) VALUES(?, ?, ?, ?, ?, ?)",
+		entry.Action, entry.Actor, string(entry.Parameters), entry.Success, entry.Result, entry.Timestamp)
+	return errors.Wrap(err, "unable to save action audit entry")
+}
+
+// GetActionAuditLog will return the most recent attempts to trigger actions.
+func GetActionAuditLog(limit int) ([]models.ActionAuditEntry, error) {
+	rows, err := _db.Query("SELECT id, action, actor, parameters, success, result, timestamp FROM action_audit_log ORDER BY id DESC LIMIT ?", limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]models.ActionAuditEntry, 0)
+	for rows.Next() {
+		var entry models.ActionAuditEntry
+		var parameters string
+		if err := rows.Scan(&entry.ID, &entry.Action, &entry.Actor, &parameters, &entry.Success, &entry.Result, &entry.Timestamp); err != nil {
+			return nil, err
+		}
+		entry.Parameters = []byte(parameters)
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
@@ -0,0 +1,114 @@
+package data
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/owncast/owncast/models"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+func createBroadcastsTable(db *sql.DB) {
+	log.Traceln("Creating broadcasts table...")
+
+	createTableSQL := `CREATE TABLE IF NOT EXISTS broadcasts (
+		"id" INTEGER PRIMARY KEY AUTOINCREMENT,
+		"started_at" DATETIME NOT NULL,
+		"ended_at" DATETIME
+	);`
+
+	stmt, err := db.Prepare(createTableSQL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer stmt.Close()
+	if _, err := stmt.Exec(); err != nil {
+		log.Warnln(err)
+	}
+}
+
+// AddBroadcast will record the start of a new broadcast. Any broadcast that
+// was never marked as ended, such as after a crash, is ended first.
+func AddBroadcast(startedAt time.Time) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	tx, err := _db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	if _, err := tx.Exec("UPDATE broadcasts SET ended_at = ? WHERE ended_at IS NULL", startedAt); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("INSERT INTO broadcasts(started_at) values(?)", startedAt); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetBroadcastEnded will mark the current broadcast as ended.
+func SetBroadcastEnded(endedAt time.Time) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	_, err := _db.Exec("UPDATE broadcasts SET ended_at = ? WHERE ended_at IS NULL", endedAt)
+	return err
+}
+
+// GetBroadcasts will return all past and current broadcasts, newest first.
+func GetBroadcasts() ([]models.Broadcast, error) {
+	return getBroadcasts("SELECT id, started_at, ended_at FROM broadcasts ORDER BY started_at DESC")
+}
+
+// GetBroadcast will return a single broadcast by ID.
+func GetBroadcast(id int) (*models.Broadcast, error) {
+	broadcasts, err := getBroadcasts("SELECT id, started_at, ended_at FROM broadcasts WHERE id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(broadcasts) == 0 {
+		return nil, errors.Errorf("broadcast %d not found", id)
+	}
+
+	return &broadcasts[0], nil
+}
+
+// GetLatestBroadcast will return the current or most recent broadcast.
+func GetLatestBroadcast() (*models.Broadcast, error) {
+	broadcasts, err := getBroadcasts("SELECT id, started_at, ended_at FROM broadcasts ORDER BY started_at DESC LIMIT 1")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(broadcasts) == 0 {
+		return nil, errors.New("no broadcasts found")
+	}
+
+	return &broadcasts[0], nil
+}
+
+func getBroadcasts(query string, args ...interface{}) ([]models.Broadcast, error) {
+	broadcasts := make([]models.Broadcast, 0)
+
+	rows, err := _db.Query(query, args...)
+	if err != nil {
+		return broadcasts, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var broadcast models.Broadcast
+		if err := rows.Scan(&broadcast.ID, &broadcast.StartedAt, &broadcast.EndedAt); err != nil {
+			return broadcasts, errors.Wrap(err, "unable to read broadcast")
+		}
+		broadcasts = append(broadcasts, broadcast)
+	}
+
+	return broadcasts, rows.Err()
+}
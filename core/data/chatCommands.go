@@ -0,0 +1,118 @@
+package data
+
+import (
+	"database/sql"
+
+	"github.com/owncast/owncast/models"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// ErrChatCommandNotFound is returned when a chat command does not exist.
+var ErrChatCommandNotFound = errors.New("chat command not found")
+
+func createChatCommandsTable(db *sql.DB) {
+	log.Traceln("Creating chat commands table...")
+
+	createTableSQL := `CREATE TABLE IF NOT EXISTS chat_commands (
+		"id" TEXT NOT NULL PRIMARY KEY,
+		"trigger" TEXT NOT NULL UNIQUE,
+		"response" TEXT NOT NULL,
+		"cooldown_seconds" INTEGER NOT NULL DEFAULT 0,
+		"role_id" TEXT,
+		"enabled" BOOLEAN NOT NULL DEFAULT TRUE,
+		"created_at" DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`
+
+	if _, err := db.Exec(createTableSQL); err != nil {
+		log.Warnln(err)
+	}
+}
+
+// GetChatCommands will return all the chat commands.
+func GetChatCommands() ([]models.ChatCommand, error) {
+	return getChatCommands("SELECT id, trigger, response, cooldown_seconds, role_id, enabled, created_at FROM chat_commands ORDER BY trigger ASC")
+}
+
+// GetChatCommandForTrigger will return the chat command with a trigger.
+func GetChatCommandForTrigger(trigger string) (*models.ChatCommand, error) {
+	commands, err := getChatCommands("SELECT id, trigger, response, cooldown_seconds, role_id, enabled, created_at FROM chat_commands WHERE trigger = ?", trigger)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(commands) == 0 {
+		return nil, ErrChatCommandNotFound
+	}
+
+	return &commands[0], nil
+}
+
+// InsertChatCommand will save a new chat command.
+func InsertChatCommand(command models.ChatCommand) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	_, err := _db.Exec("INSERT INTO chat_commands(id, trigger, response, cooldown_seconds, role_id, enabled, created_at) VALUES(?, ?, ?, ?, ?, ?, ?)",
+		command.ID, command.Trigger, command.Response, command.CooldownSeconds, command.RoleID, command.Enabled, command.CreatedAt)
+	return errors.Wrap(err, "unable to save chat command")
+}
+
+// UpdateChatCommand will save changes to an existing chat command.
+func UpdateChatCommand(command models.ChatCommand) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	result, err := _db.Exec("UPDATE chat_commands SET trigger = ?, response = ?, cooldown_seconds = ?, role_id = ?, enabled = ? WHERE id = ?",
+		command.Trigger, command.Response, command.CooldownSeconds, command.RoleID, command.Enabled, command.ID)
+	if err != nil {
+		return errors.Wrap(err, "unable to update chat command")
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return ErrChatCommandNotFound
+	}
+
+	return nil
+}
+
+// DeleteChatCommand will remove a chat command.
+func DeleteChatCommand(id string) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	result, err := _db.Exec("DELETE FROM chat_commands WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return ErrChatCommandNotFound
+	}
+
+	return nil
+}
+
+func getChatCommands(query string, args ...interface{}) ([]models.ChatCommand, error) {
+	commands := make([]models.ChatCommand, 0)
+
+	rows, err := _db.Query(query, args...)
+	if err != nil {
+		return commands, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var command models.ChatCommand
+		var roleID sql.NullString
+
+		if err := rows.Scan(&command.ID, &command.Trigger, &command.Response, &command.CooldownSeconds, &roleID, &command.Enabled, &command.CreatedAt); err != nil {
+			return commands, errors.Wrap(err, "unable to read chat command")
+		}
+
+		command.RoleID = roleID.String
+		commands = append(commands, command)
+	}
+
+	return commands, rows.Err()
+}
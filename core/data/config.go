@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/owncast/owncast/config"
@@ -56,13 +57,32 @@ const (
 	suggestedUsernamesKey                = "suggested_usernames"
 	chatJoinMessagesEnabledKey           = "chat_join_messages_enabled"
 	chatEstablishedUsersOnlyModeKey      = "chat_established_users_only_mode"
+	chatRetentionHoursKey                = "chat_retention_hours"
+	chatSlowModeDurationKey              = "chat_slow_mode_duration"
+	chatLinksRestrictedKey               = "chat_links_restricted"
+	chatRestrictedEmojiKey               = "chat_restricted_emoji"
+	chatBanEvasionDetectionKey           = "chat_ban_evasion_detection"
+	chatRaidProtectionKey                = "chat_raid_protection"
+	chatCommandBotIDKey                  = "chat_command_bot_id"
+	oidcConfigurationKey                 = "oidc_configuration"
 	notificationsEnabledKey              = "notifications_enabled"
 	discordConfigurationKey              = "discord_configuration"
+	matrixConfigurationKey               = "matrix_configuration"
+	emailConfigurationKey                = "email_configuration"
+	emailSigningKeyKey                   = "email_signing_key"
+	ntfyConfigurationKey                 = "ntfy_configuration"
+	gotifyConfigurationKey               = "gotify_configuration"
+	telegramConfigurationKey             = "telegram_configuration"
+	mastodonConfigurationKey             = "mastodon_configuration"
+	notificationThrottleKey              = "notification_throttle"
+	notificationLastSentKeyPrefix        = "notification_last_sent_"
+	browserPushDeliveriesKey             = "browser_push_deliveries"
 	browserPushConfigurationKey          = "browser_push_configuration"
 	browserPushPublicKeyKey              = "browser_push_public_key"
 	browserPushPrivateKeyKey             = "browser_push_private_key"
 	twitterConfigurationKey              = "twitter_configuration"
 	hasConfiguredInitialNotificationsKey = "has_configured_initial_notifications"
+	offlineClipKey                       = "offline_clip"
 )
 
 // GetExtraPageBodyContent will return the user-supplied body content.
@@ -802,6 +822,121 @@ func GetChatJoinMessagesEnabled() bool {
 	return enabled
 }
 
+// SetChatRetentionHours will set how many hours of chat messages are kept.
+// Zero will keep messages forever.
+func SetChatRetentionHours(hours int) error {
+	return _datastore.SetNumber(chatRetentionHoursKey, float64(hours))
+}
+
+// GetChatRetentionHours will return how many hours of chat messages are kept.
+func GetChatRetentionHours() int {
+	hours, err := _datastore.GetNumber(chatRetentionHoursKey)
+	if err != nil {
+		return config.GetDefaults().ChatRetentionHours
+	}
+
+	return int(hours)
+}
+
+// SetChatSlowModeDuration will set how many seconds chat users must wait
+// between messages. Zero disables slow mode.
+func SetChatSlowModeDuration(seconds int) error {
+	return _datastore.SetNumber(chatSlowModeDurationKey, float64(seconds))
+}
+
+// GetChatSlowModeDuration will return how many seconds chat users must wait
+// between messages.
+func GetChatSlowModeDuration() int {
+	seconds, err := _datastore.GetNumber(chatSlowModeDurationKey)
+	if err != nil {
+		return 0
+	}
+
+	return int(seconds)
+}
+
+// SetChatLinksRestricted will set if only moderators and roles with the
+// permission can post links in chat.
+func SetChatLinksRestricted(restricted bool) error {
+	return _datastore.SetBool(chatLinksRestrictedKey, restricted)
+}
+
+// GetChatLinksRestricted will return if posting links in chat is restricted.
+func GetChatLinksRestricted() bool {
+	restricted, err := _datastore.GetBool(chatLinksRestrictedKey)
+	if err != nil {
+		return false
+	}
+
+	return restricted
+}
+
+// SetChatRestrictedEmoji will set the names of the custom emoji that only
+// moderators and roles with the permission can use.
+func SetChatRestrictedEmoji(names []string) error {
+	return _datastore.SetStringSlice(chatRestrictedEmojiKey, names)
+}
+
+// GetChatRestrictedEmoji will return the names of the restricted custom emoji.
+func GetChatRestrictedEmoji() []string {
+	names, err := _datastore.GetStringSlice(chatRestrictedEmojiKey)
+	if err != nil {
+		return []string{}
+	}
+
+	return names
+}
+
+// SetChatBanEvasionDetectionEnabled will set if new chat users should be
+// compared against the IP addresses of disabled users.
+func SetChatBanEvasionDetectionEnabled(enabled bool) error {
+	return _datastore.SetBool(chatBanEvasionDetectionKey, enabled)
+}
+
+// GetChatBanEvasionDetectionEnabled will return if ban evasion detection
+// is enabled.
+func GetChatBanEvasionDetectionEnabled() bool {
+	enabled, _ := _datastore.GetBool(chatBanEvasionDetectionKey)
+	return enabled
+}
+
+// GetChatRaidProtectionConfig will return the chat raid protection
+// configuration, or the defaults if it has not been configured.
+func GetChatRaidProtectionConfig() models.RaidProtectionConfiguration {
+	defaults := config.GetDefaults().ChatRaidProtection
+
+	configEntry, err := _datastore.Get(chatRaidProtectionKey)
+	if err != nil {
+		return defaults
+	}
+
+	var raidProtection models.RaidProtectionConfiguration
+	if err := configEntry.getObject(&raidProtection); err != nil {
+		return defaults
+	}
+
+	return raidProtection
+}
+
+// SetChatRaidProtectionConfig will set the chat raid protection configuration.
+func SetChatRaidProtectionConfig(raidProtection models.RaidProtectionConfiguration) error {
+	configEntry := ConfigEntry{Key: chatRaidProtectionKey, Value: raidProtection}
+	return _datastore.Save(configEntry)
+}
+
+// SetChatCommandBotID will set the integration that replies to chat
+// commands. Empty replies as the system.
+func SetChatCommandBotID(id string) error {
+	return _datastore.SetString(chatCommandBotIDKey, id)
+}
+
+// GetChatCommandBotID will return the integration that replies to chat
+// commands.
+func GetChatCommandBotID() string {
+	id, _ := _datastore.GetString(chatCommandBotIDKey)
+	return id
+}
+
 // SetNotificationsEnabled will save the enabled state of notifications.
 func SetNotificationsEnabled(enabled bool) error {
 	return _datastore.SetBool(notificationsEnabledKey, enabled)
@@ -834,6 +969,251 @@ func SetDiscordConfig(config models.DiscordConfiguration) error {
 	return _datastore.Save(configEntry)
 }
 
+// GetMatrixConfig will return the Matrix configuration.
+func GetMatrixConfig() models.MatrixConfiguration {
+	configEntry, err := _datastore.Get(matrixConfigurationKey)
+	if err != nil {
+		return models.MatrixConfiguration{Enabled: false}
+	}
+
+	var config models.MatrixConfiguration
+	if err := configEntry.getObject(&config); err != nil {
+		return models.MatrixConfiguration{Enabled: false}
+	}
+
+	return config
+}
+
+// SetMatrixConfig will set the Matrix configuration.
+func SetMatrixConfig(config models.MatrixConfiguration) error {
+	configEntry := ConfigEntry{Key: matrixConfigurationKey, Value: config}
+	return _datastore.Save(configEntry)
+}
+
+// GetEmailConfig will return the email notification configuration.
+func GetEmailConfig() models.EmailConfiguration {
+	configEntry, err := _datastore.Get(emailConfigurationKey)
+	if err != nil {
+		return models.EmailConfiguration{Enabled: false}
+	}
+
+	var config models.EmailConfiguration
+	if err := configEntry.getObject(&config); err != nil {
+		return models.EmailConfiguration{Enabled: false}
+	}
+
+	return config
+}
+
+// SetEmailConfig will set the email notification configuration.
+func SetEmailConfig(config models.EmailConfiguration) error {
+	configEntry := ConfigEntry{Key: emailConfigurationKey, Value: config}
+	return _datastore.Save(configEntry)
+}
+
+// GetEmailSigningKey will return the key used to sign email confirmation and
+// unsubscribe links, creating it the first time.
+func GetEmailSigningKey() ([]byte, error) {
+	if key, err := _datastore.GetString(emailSigningKeyKey); err == nil && key != "" {
+		return []byte(key), nil
+	}
+
+	key, err := utils.GenerateAccessToken()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := _datastore.SetString(emailSigningKeyKey, key); err != nil {
+		return nil, err
+	}
+
+	return []byte(key), nil
+}
+
+// GetNtfyConfig will return the ntfy notification configuration.
+func GetNtfyConfig() models.NtfyConfiguration {
+	configEntry, err := _datastore.Get(ntfyConfigurationKey)
+	if err != nil {
+		return models.NtfyConfiguration{Enabled: false}
+	}
+
+	var config models.NtfyConfiguration
+	if err := configEntry.getObject(&config); err != nil {
+		return models.NtfyConfiguration{Enabled: false}
+	}
+
+	return config
+}
+
+// SetNtfyConfig will set the ntfy notification configuration.
+func SetNtfyConfig(config models.NtfyConfiguration) error {
+	configEntry := ConfigEntry{Key: ntfyConfigurationKey, Value: config}
+	return _datastore.Save(configEntry)
+}
+
+// GetGotifyConfig will return the Gotify notification configuration.
+func GetGotifyConfig() models.GotifyConfiguration {
+	configEntry, err := _datastore.Get(gotifyConfigurationKey)
+	if err != nil {
+		return models.GotifyConfiguration{Enabled: false}
+	}
+
+	var config models.GotifyConfiguration
+	if err := configEntry.getObject(&config); err != nil {
+		return models.GotifyConfiguration{Enabled: false}
+	}
+
+	return config
+}
+
+// SetGotifyConfig will set the Gotify notification configuration.
+func SetGotifyConfig(config models.GotifyConfiguration) error {
+	configEntry := ConfigEntry{Key: gotifyConfigurationKey, Value: config}
+	return _datastore.Save(configEntry)
+}
+
+// GetTelegramConfig will return the Telegram notification configuration.
+func GetTelegramConfig() models.TelegramConfiguration {
+	configEntry, err := _datastore.Get(telegramConfigurationKey)
+	if err != nil {
+		return models.TelegramConfiguration{Enabled: false}
+	}
+
+	var config models.TelegramConfiguration
+	if err := configEntry.getObject(&config); err != nil {
+		return models.TelegramConfiguration{Enabled: false}
+	}
+
+	return config
+}
+
+// SetTelegramConfig will set the Telegram notification configuration.
+func SetTelegramConfig(config models.TelegramConfiguration) error {
+	configEntry := ConfigEntry{Key: telegramConfigurationKey, Value: config}
+	return _datastore.Save(configEntry)
+}
+
+// GetMastodonConfig will return the Mastodon notification configuration.
+func GetMastodonConfig() models.MastodonConfiguration {
+	configEntry, err := _datastore.Get(mastodonConfigurationKey)
+	if err != nil {
+		return models.MastodonConfiguration{Enabled: false}
+	}
+
+	var config models.MastodonConfiguration
+	if err := configEntry.getObject(&config); err != nil {
+		return models.MastodonConfiguration{Enabled: false}
+	}
+
+	return config
+}
+
+// SetMastodonConfig will set the Mastodon notification configuration.
+func SetMastodonConfig(config models.MastodonConfiguration) error {
+	configEntry := ConfigEntry{Key: mastodonConfigurationKey, Value: config}
+	return _datastore.Save(configEntry)
+}
+
+// GetNotificationThrottleConfig will return how often go-live
+// notifications can be sent.
+func GetNotificationThrottleConfig() models.NotificationThrottleConfiguration {
+	defaultConfig := models.NotificationThrottleConfiguration{
+		ReconnectSuppressionMinutes: config.GetDefaults().NotificationReconnectSuppressionMinutes,
+	}
+
+	configEntry, err := _datastore.Get(notificationThrottleKey)
+	if err != nil {
+		return defaultConfig
+	}
+
+	var throttle models.NotificationThrottleConfiguration
+	if err := configEntry.getObject(&throttle); err != nil {
+		return defaultConfig
+	}
+
+	return throttle
+}
+
+// SetNotificationThrottleConfig will set how often go-live notifications
+// can be sent.
+func SetNotificationThrottleConfig(throttle models.NotificationThrottleConfiguration) error {
+	configEntry := ConfigEntry{Key: notificationThrottleKey, Value: throttle}
+	return _datastore.Save(configEntry)
+}
+
+// GetNotificationLastSent will return when a notification channel last sent
+// a go-live notification, or the zero time if it never has.
+func GetNotificationLastSent(channel string) time.Time {
+	sent, err := _datastore.GetNumber(notificationLastSentKeyPrefix + channel)
+	if err != nil || sent == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(int64(sent), 0)
+}
+
+// SetNotificationLastSent will set when a notification channel last sent a
+// go-live notification.
+func SetNotificationLastSent(channel string, sent time.Time) error {
+	return _datastore.SetNumber(notificationLastSentKeyPrefix+channel, float64(sent.Unix()))
+}
+
+// How many browser push deliveries are kept for the admin to see.
+const maxBrowserPushDeliveries = 20
+
+var browserPushDeliveriesLock sync.Mutex
+
+// GetBrowserPushDeliveries will return the most recent browser push
+// deliveries, newest first.
+func GetBrowserPushDeliveries() []models.BrowserPushDelivery {
+	configEntry, err := _datastore.Get(browserPushDeliveriesKey)
+	if err != nil {
+		return []models.BrowserPushDelivery{}
+	}
+
+	var deliveries []models.BrowserPushDelivery
+	if err := configEntry.getObject(&deliveries); err != nil {
+		return []models.BrowserPushDelivery{}
+	}
+
+	return deliveries
+}
+
+// AddBrowserPushDelivery will save the summary of a browser push delivery.
+func AddBrowserPushDelivery(delivery models.BrowserPushDelivery) error {
+	browserPushDeliveriesLock.Lock()
+	defer browserPushDeliveriesLock.Unlock()
+
+	deliveries := append([]models.BrowserPushDelivery{delivery}, GetBrowserPushDeliveries()...)
+	if len(deliveries) > maxBrowserPushDeliveries {
+		deliveries = deliveries[:maxBrowserPushDeliveries]
+	}
+
+	configEntry := ConfigEntry{Key: browserPushDeliveriesKey, Value: deliveries}
+	return _datastore.Save(configEntry)
+}
+
+// GetOIDCConfig will return the OpenID Connect login configuration.
+func GetOIDCConfig() models.OIDCConfiguration {
+	configEntry, err := _datastore.Get(oidcConfigurationKey)
+	if err != nil {
+		return models.OIDCConfiguration{Enabled: false}
+	}
+
+	var config models.OIDCConfiguration
+	if err := configEntry.getObject(&config); err != nil {
+		return models.OIDCConfiguration{Enabled: false}
+	}
+
+	return config
+}
+
+// SetOIDCConfig will set the OpenID Connect login configuration.
+func SetOIDCConfig(config models.OIDCConfiguration) error {
+	configEntry := ConfigEntry{Key: oidcConfigurationKey, Value: config}
+	return _datastore.Save(configEntry)
+}
+
 // GetBrowserPushConfig will return the browser push configuration.
 func GetBrowserPushConfig() models.BrowserNotificationConfiguration {
 	configEntry, err := _datastore.Get(browserPushConfigurationKey)
@@ -906,3 +1286,15 @@ func GetHasPerformedInitialNotificationsConfig() bool {
 	configured, _ := _datastore.GetBool(hasConfiguredInitialNotificationsKey)
 	return configured
 }
+
+// SetOfflineClip will set the name of the clip shown while offline.
+func SetOfflineClip(name string) error {
+	return _datastore.SetString(offlineClipKey, name)
+}
+
+// GetOfflineClip will return the name of the clip shown while offline. An
+// empty name is the default clip.
+func GetOfflineClip() string {
+	name, _ := _datastore.GetString(offlineClipKey)
+	return name
+}
@@ -17,7 +17,7 @@ import (
 )
 
 const (
-	schemaVersion = 5
+	schemaVersion = 13
 )
 
 var (
@@ -74,8 +74,15 @@ func SetupPersistence(file string) error {
 	_, _ = db.Exec("pragma wal_checkpoint(full)")
 
 	createWebhooksTable()
+	createWebhookDeliveriesTable(db)
 	createUsersTable(db)
 	createAccessTokenTable(db)
+	createUserProfilesTable(db)
+	createRolesTables(db)
+	createUserIPAddressesTable(db)
+	createBroadcastsTable(db)
+	createChatCommandsTable(db)
+	createActionAuditTable(db)
 
 	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS config (
 		"key" string NOT NULL PRIMARY KEY,
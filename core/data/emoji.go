@@ -0,0 +1,55 @@
+package data
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/owncast/owncast/config"
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	emojiCache          = make([]models.CustomEmoji, 0)
+	emojiCacheTimestamp time.Time
+	emojiCacheLock      sync.Mutex
+)
+
+// GetEmojiList returns a list of custom emoji either from the cache or from the emoji directory.
+func GetEmojiList() []models.CustomEmoji {
+	emojiCacheLock.Lock()
+	defer emojiCacheLock.Unlock()
+
+	fullPath := filepath.Join(config.WebRoot, config.EmojiDir)
+	emojiDirInfo, err := os.Stat(fullPath)
+	if err != nil {
+		log.Errorln(err)
+		return emojiCache
+	}
+	if emojiDirInfo.ModTime() != emojiCacheTimestamp {
+		log.Traceln("Emoji cache invalid")
+		emojiCache = make([]models.CustomEmoji, 0)
+	}
+
+	if len(emojiCache) == 0 {
+		files, err := os.ReadDir(fullPath)
+		if err != nil {
+			log.Errorln(err)
+			return emojiCache
+		}
+		for _, f := range files {
+			name := strings.TrimSuffix(f.Name(), path.Ext(f.Name()))
+			emojiPath := filepath.Join(config.EmojiDir, f.Name())
+			singleEmoji := models.CustomEmoji{Name: name, Emoji: emojiPath}
+			emojiCache = append(emojiCache, singleEmoji)
+		}
+
+		emojiCacheTimestamp = emojiDirInfo.ModTime()
+	}
+
+	return emojiCache
+}
@@ -0,0 +1,80 @@
+package data
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIPAddressBans(t *testing.T) {
+	CreateBanIPTable(_datastore.DB)
+
+	if err := BanIPAddress("203.0.113.7/24", "range", "admin", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := BanIPAddress("2001:db8::1", "single", "admin", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	expired := time.Now().Add(-time.Minute)
+	if err := BanIPAddress("198.51.100.1", "expired", "admin", &expired); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := BanIPAddress("not an address", "invalid", "admin", nil); err == nil {
+		t.Error("expected an invalid address to be rejected")
+	}
+
+	tests := map[string]bool{
+		"203.0.113.200":         true,
+		"203.0.114.1":           false,
+		"2001:0db8:0000::0001":  true,
+		"2001:db8::2":           false,
+		"198.51.100.1":          false,
+		"203.0.113.9, 10.0.0.1": false,
+	}
+
+	for address, expected := range tests {
+		banned, err := IsIPAddressBanned(address)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if banned != expected {
+			t.Errorf("expected %s banned to be %t", address, expected)
+		}
+	}
+
+	bans, err := GetIPAddressBans()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+	for _, ban := range bans {
+		if ban.IPAddress == "203.0.113.0/24" {
+			found = ban.Notes == "range" && ban.BannedBy == "admin"
+		}
+	}
+	if !found {
+		t.Error("expected the range to be saved in its canonical form with its reason and actor")
+	}
+
+	// Bans are cached, so removing one must take effect right away.
+	if err := RemoveIPAddressBan("2001:db8::1"); err != nil {
+		t.Fatal(err)
+	}
+	if banned, _ := IsIPAddressBanned("2001:db8::1"); banned {
+		t.Error("expected the removed ban to no longer apply")
+	}
+
+	if err := RemoveExpiredIPAddressBans(); err != nil {
+		t.Fatal(err)
+	}
+
+	bans, _ = GetIPAddressBans()
+	for _, ban := range bans {
+		if ban.IPAddress == "198.51.100.1" {
+			t.Error("expected the expired ban to be removed")
+		}
+	}
+}
@@ -3,6 +3,11 @@ package data
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/owncast/owncast/db"
 	"github.com/owncast/owncast/models"
@@ -22,6 +27,7 @@ func CreateMessagesTable(db *sql.DB) {
     "subtitle" TEXT,
     "image" TEXT,
     "link" TEXT,
+    "reactions" TEXT,
 		PRIMARY KEY (id)
 	);CREATE INDEX index ON messages (id, user_id, hidden_at, timestamp);
 	CREATE INDEX id ON messages (id);
@@ -39,6 +45,70 @@ func CreateMessagesTable(db *sql.DB) {
 	}
 }
 
+// The triggers that keep the chat search index in sync with the messages table.
+var messagesSearchIndexTriggers = []string{"messages_fts_insert", "messages_fts_delete", "messages_fts_update"}
+
+// CreateMessagesSearchIndex will create the full-text search index of chat
+// message bodies. It requires SQLite built with FTS5 support (the sqlite_fts5
+// build tag) and returns false if the index is unavailable.
+func CreateMessagesSearchIndex(db *sql.DB) bool {
+	var fts5 bool
+	if err := db.QueryRow("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&fts5); err != nil || !fts5 {
+		log.Debugln("chat message search index is unavailable", err)
+		// A database indexed by a build with FTS5 has triggers that would
+		// make every message write fail, so remove them. The index itself
+		// is rebuilt when a build with FTS5 opens the database again.
+		dropMessagesSearchIndexTriggers(db)
+		return false
+	}
+
+	var existingTriggers int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'trigger' AND name LIKE 'messages_fts_%'").Scan(&existingTriggers); err != nil {
+		log.Warnln("unable to check for the chat search index", err)
+		return false
+	}
+
+	// The index uses the messages table as its content and is kept in sync
+	// with triggers, so it only stores the search terms.
+	createIndexSQL := `CREATE VIRTUAL TABLE IF NOT EXISTS messages_fts USING fts5(body, content='messages', content_rowid='rowid');
+	CREATE TRIGGER IF NOT EXISTS messages_fts_insert AFTER INSERT ON messages BEGIN
+		INSERT INTO messages_fts(rowid, body) VALUES (new.rowid, new.body);
+	END;
+	CREATE TRIGGER IF NOT EXISTS messages_fts_delete AFTER DELETE ON messages BEGIN
+		INSERT INTO messages_fts(messages_fts, rowid, body) VALUES ('delete', old.rowid, old.body);
+	END;
+	CREATE TRIGGER IF NOT EXISTS messages_fts_update AFTER UPDATE OF body ON messages BEGIN
+		INSERT INTO messages_fts(messages_fts, rowid, body) VALUES ('delete', old.rowid, old.body);
+		INSERT INTO messages_fts(rowid, body) VALUES (new.rowid, new.body);
+	END;`
+
+	if _, err := db.Exec(createIndexSQL); err != nil {
+		log.Debugln("chat message search index is unavailable", err)
+		dropMessagesSearchIndexTriggers(db)
+		return false
+	}
+
+	// Index any messages that were saved while the index was not being
+	// kept in sync.
+	if existingTriggers < len(messagesSearchIndexTriggers) {
+		if _, err := db.Exec("INSERT INTO messages_fts(messages_fts) VALUES ('rebuild')"); err != nil {
+			log.Errorln("unable to build chat search index", err)
+			dropMessagesSearchIndexTriggers(db)
+			return false
+		}
+	}
+
+	return true
+}
+
+func dropMessagesSearchIndexTriggers(db *sql.DB) {
+	for _, trigger := range messagesSearchIndexTriggers {
+		if _, err := db.Exec("DROP TRIGGER IF EXISTS " + trigger); err != nil {
+			log.Errorln("unable to remove chat search index trigger", trigger, err)
+		}
+	}
+}
+
 // GetMessagesCount will return the number of messages in the database.
 func GetMessagesCount() int64 {
 	query := `SELECT COUNT(*) FROM messages`
@@ -61,7 +131,9 @@ func CreateBanIPTable(db *sql.DB) {
 	createTableSQL := `  CREATE TABLE IF NOT EXISTS ip_bans (
     "ip_address" TEXT NOT NULL PRIMARY KEY,
     "notes" TEXT,
-    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    "expires_at" TIMESTAMP,
+    "banned_by" TEXT
   );`
 
 	stmt, err := db.Prepare(createTableSQL)
@@ -74,18 +146,139 @@ func CreateBanIPTable(db *sql.DB) {
 	}
 }
 
-// BanIPAddress will persist a new IP address ban to the datastore.
-func BanIPAddress(address, note string) error {
+// BanIPAddress will persist a new ban of a single IP address or a CIDR range
+// of addresses to the datastore. A nil expiry bans the addresses forever.
+func BanIPAddress(address, reason, bannedBy string, expiresAt *time.Time) error {
+	address, err := NormalizeIPAddressBan(address)
+	if err != nil {
+		return err
+	}
+
+	var expires sql.NullTime
+	if expiresAt != nil {
+		expires = sql.NullTime{Time: *expiresAt, Valid: true}
+	}
+
+	defer invalidateIPAddressBans()
+
 	return _datastore.GetQueries().BanIPAddress(context.Background(), db.BanIPAddressParams{
 		IpAddress: address,
-		Notes:     sql.NullString{String: note, Valid: true},
+		Notes:     sql.NullString{String: reason, Valid: true},
+		ExpiresAt: expires,
+		BannedBy:  sql.NullString{String: bannedBy, Valid: bannedBy != ""},
 	})
 }
 
-// IsIPAddressBanned will return if an IP address has been previously blocked.
+// NormalizeIPAddressBan will return the canonical form of a single IP address
+// or a CIDR range, such as 203.0.113.0/24 or 2001:db8::/48.
+func NormalizeIPAddressBan(address string) (string, error) {
+	address = strings.TrimSpace(address)
+
+	if strings.Contains(address, "/") {
+		_, network, err := net.ParseCIDR(address)
+		if err != nil {
+			return "", fmt.Errorf("%s is not a valid CIDR range", address)
+		}
+		return network.String(), nil
+	}
+
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return "", fmt.Errorf("%s is not a valid IP address", address)
+	}
+
+	return ip.String(), nil
+}
+
+// A ban with its addresses parsed, so checking an address does not parse
+// every ban.
+type parsedIPAddressBan struct {
+	network   *net.IPNet
+	expiresAt *time.Time
+}
+
+var (
+	// The current bans, loaded from the datastore when first needed and
+	// again after they change.
+	_ipAddressBans       []parsedIPAddressBan
+	_ipAddressBansLoaded bool
+	_ipAddressBansLock   sync.RWMutex
+)
+
+// IsIPAddressBanned will return if an IP address has been blocked, either by
+// itself or as part of a range, and the ban has not expired.
 func IsIPAddressBanned(address string) (bool, error) {
-	blocked, error := _datastore.GetQueries().IsIPAddressBlocked(context.Background(), address)
-	return blocked > 0, error
+	ip := net.ParseIP(strings.TrimSpace(address))
+	if ip == nil {
+		return false, nil
+	}
+
+	bans, err := getParsedIPAddressBans()
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	for _, ban := range bans {
+		if (ban.expiresAt == nil || now.Before(*ban.expiresAt)) && ban.network.Contains(ip) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func getParsedIPAddressBans() ([]parsedIPAddressBan, error) {
+	_ipAddressBansLock.RLock()
+	if _ipAddressBansLoaded {
+		defer _ipAddressBansLock.RUnlock()
+		return _ipAddressBans, nil
+	}
+	_ipAddressBansLock.RUnlock()
+
+	_ipAddressBansLock.Lock()
+	defer _ipAddressBansLock.Unlock()
+
+	bans, err := GetIPAddressBans()
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := make([]parsedIPAddressBan, 0, len(bans))
+	for _, ban := range bans {
+		address, err := NormalizeIPAddressBan(ban.IPAddress)
+		if err != nil {
+			log.Warnln("ignoring invalid IP address ban", err)
+			continue
+		}
+		if !strings.Contains(address, "/") {
+			if net.ParseIP(address).To4() != nil {
+				address += "/32"
+			} else {
+				address += "/128"
+			}
+		}
+
+		_, network, err := net.ParseCIDR(address)
+		if err != nil {
+			log.Warnln("ignoring invalid IP address ban", err)
+			continue
+		}
+		parsed = append(parsed, parsedIPAddressBan{network: network, expiresAt: ban.ExpiresAt})
+	}
+
+	_ipAddressBans = parsed
+	_ipAddressBansLoaded = true
+
+	return _ipAddressBans, nil
+}
+
+func invalidateIPAddressBans() {
+	_ipAddressBansLock.Lock()
+	defer _ipAddressBansLock.Unlock()
+
+	_ipAddressBans = nil
+	_ipAddressBansLoaded = false
 }
 
 // GetIPAddressBans will return all the banned IP addresses.
@@ -97,16 +290,45 @@ func GetIPAddressBans() ([]models.IPAddress, error) {
 
 	response := []models.IPAddress{}
 	for _, ip := range result {
-		response = append(response, models.IPAddress{
+		ban := models.IPAddress{
 			IPAddress: ip.IpAddress,
 			Notes:     ip.Notes.String,
 			CreatedAt: ip.CreatedAt.Time,
-		})
+			BannedBy:  ip.BannedBy.String,
+		}
+		if ip.ExpiresAt.Valid {
+			ban.ExpiresAt = &ip.ExpiresAt.Time
+		}
+		response = append(response, ban)
 	}
 	return response, err
 }
 
-// RemoveIPAddressBan will remove a previously banned IP address.
+// RemoveExpiredIPAddressBans will remove the bans that have ended.
+func RemoveExpiredIPAddressBans() error {
+	bans, err := GetIPAddressBans()
+	if err != nil {
+		return err
+	}
+
+	for _, ban := range bans {
+		if ban.IsExpired() {
+			if err := RemoveIPAddressBan(ban.IPAddress); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// RemoveIPAddressBan will remove a previously banned IP address or range.
 func RemoveIPAddressBan(address string) error {
+	if normalized, err := NormalizeIPAddressBan(address); err == nil {
+		address = normalized
+	}
+
+	defer invalidateIPAddressBans()
+
 	return _datastore.GetQueries().RemoveIPAddressBan(context.Background(), address)
 }
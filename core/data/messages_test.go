@@ -0,0 +1,31 @@
+package data
+
+import "testing"
+
+func TestMessagesSearchIndexWithoutFTS5(t *testing.T) {
+	CreateMessagesTable(_datastore.DB)
+
+	var fts5 bool
+	if err := _datastore.DB.QueryRow("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&fts5); err != nil {
+		t.Fatal(err)
+	}
+	if fts5 {
+		t.Skip("SQLite was built with FTS5")
+	}
+
+	// A trigger left behind by a build with FTS5 references a table this
+	// build can not write to.
+	if _, err := _datastore.DB.Exec(`CREATE TRIGGER messages_fts_insert AFTER INSERT ON messages BEGIN
+		INSERT INTO messages_fts(rowid, body) VALUES (new.rowid, new.body);
+	END;`); err != nil {
+		t.Fatal(err)
+	}
+
+	if CreateMessagesSearchIndex(_datastore.DB) {
+		t.Fatal("expected the search index to be unavailable")
+	}
+
+	if _, err := _datastore.DB.Exec("INSERT INTO messages(id, body) VALUES ('search-index-test', 'hello')"); err != nil {
+		t.Errorf("expected messages to be saved once the index triggers are removed: %v", err)
+	}
+}
@@ -29,6 +29,22 @@ func migrateDatabaseSchema(db *sql.DB, from, to int) error {
 			migrateToSchema4(db)
 		case 4:
 			migrateToSchema5(db)
+		case 5:
+			migrateToSchema6(db)
+		case 6:
+			migrateToSchema7(db)
+		case 7:
+			migrateToSchema8(db)
+		case 8:
+			migrateToSchema9(db)
+		case 9:
+			migrateToSchema10(db)
+		case 10:
+			migrateToSchema11(db)
+		case 11:
+			migrateToSchema12(db)
+		case 12:
+			migrateToSchema13(db)
 		default:
 			log.Fatalln("missing database migration step")
 		}
@@ -42,6 +58,122 @@ func migrateDatabaseSchema(db *sql.DB, from, to int) error {
 	return nil
 }
 
+func migrateToSchema13(db *sql.DB) {
+	// Browser push subscribers can now choose topics. Existing subscribers
+	// have none, which means go-live notifications only.
+	if _, err := db.Exec("ALTER TABLE notifications ADD COLUMN topics TEXT"); err != nil {
+		log.Errorln("Error running migration. This may be because you have already been running a dev version.", err)
+	}
+}
+
+func migrateToSchema12(db *sql.DB) {
+	// Webhooks can now filter events and change what is sent with a template.
+	for _, query := range []string{
+		"ALTER TABLE webhooks ADD COLUMN filters TEXT",
+		"ALTER TABLE webhooks ADD COLUMN template TEXT",
+		"ALTER TABLE webhooks ADD COLUMN content_type TEXT",
+	} {
+		if _, err := db.Exec(query); err != nil {
+			log.Errorln("Error running migration. This may be because you have already been running a dev version.", err)
+		}
+	}
+}
+
+func migrateToSchema11(db *sql.DB) {
+	// Webhooks that keep failing are now disabled.
+	for _, query := range []string{
+		"ALTER TABLE webhooks ADD COLUMN failure_count INTEGER NOT NULL DEFAULT 0",
+		"ALTER TABLE webhooks ADD COLUMN disabled_at TIMESTAMP",
+	} {
+		if _, err := db.Exec(query); err != nil {
+			log.Errorln("Error running migration. This may be because you have already been running a dev version.", err)
+		}
+	}
+}
+
+func migrateToSchema10(db *sql.DB) {
+	// Webhook requests are now signed with a secret for each webhook.
+	if _, err := db.Exec("ALTER TABLE webhooks ADD COLUMN secret TEXT"); err != nil {
+		log.Errorln("Error running migration. This may be because you have already been running a dev version.", err)
+	}
+
+	rows, err := db.Query("SELECT id FROM webhooks WHERE secret IS NULL")
+	if err != nil {
+		log.Errorln(err)
+		return
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			log.Errorln(err)
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	for _, id := range ids {
+		secret, err := utils.GenerateAccessToken()
+		if err != nil {
+			log.Errorln(err)
+			return
+		}
+
+		if _, err := db.Exec("UPDATE webhooks SET secret = ? WHERE id = ?", secret, id); err != nil {
+			log.Errorln(err)
+		}
+	}
+}
+
+func migrateToSchema9(db *sql.DB) {
+	// IP address bans can now expire and record who added them.
+	for _, query := range []string{
+		"ALTER TABLE ip_bans ADD COLUMN expires_at TIMESTAMP",
+		"ALTER TABLE ip_bans ADD COLUMN banned_by TEXT",
+	} {
+		if _, err := db.Exec(query); err != nil {
+			log.Errorln("Error running migration. This may be because you have already been running a dev version.", err)
+		}
+	}
+}
+
+func migrateToSchema8(db *sql.DB) {
+	// Notification registrations are now linked to the chat user who made
+	// them so they can be removed along with the rest of their data.
+	if _, err := db.Exec("ALTER TABLE notifications ADD COLUMN user_id TEXT"); err != nil {
+		log.Errorln("Error running migration. This may be because you have already been running a dev version.", err)
+	}
+}
+
+func migrateToSchema7(db *sql.DB) {
+	// Access tokens can now expire and be limited to specific addresses.
+	for _, query := range []string{
+		"ALTER TABLE user_access_tokens ADD COLUMN expires_at TIMESTAMP",
+		"ALTER TABLE user_access_tokens ADD COLUMN allowed_ips TEXT DEFAULT ''",
+	} {
+		if _, err := db.Exec(query); err != nil {
+			log.Errorln("Error running migration. This may be because you have already been running a dev version.", err)
+		}
+	}
+}
+
+func migrateToSchema6(db *sql.DB) {
+	// Aggregated emoji reactions are now saved alongside each chat message.
+	stmt, err := db.Prepare("ALTER TABLE messages ADD COLUMN reactions TEXT")
+	if err != nil {
+		log.Errorln("Error running migration. This may be because you have already been running a dev version.", err)
+		return
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec()
+	if err != nil {
+		log.Warnln(err)
+	}
+}
+
 // nolint:cyclop
 func migrateToSchema5(db *sql.DB) {
 	// Create the access tokens table.
@@ -0,0 +1,204 @@
+package data
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/owncast/owncast/models"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// CreatePollsTables will create the chat poll tables if needed.
+func CreatePollsTables(db *sql.DB) {
+	createTableSQL := `CREATE TABLE IF NOT EXISTS polls (
+		"id" TEXT NOT NULL PRIMARY KEY,
+		"question" TEXT NOT NULL,
+		"options" TEXT NOT NULL,
+		"created_by" TEXT,
+		"created_at" DATETIME DEFAULT CURRENT_TIMESTAMP,
+		"ends_at" DATETIME NOT NULL,
+		"closed_at" DATETIME
+	);
+	CREATE TABLE IF NOT EXISTS poll_votes (
+		"poll_id" TEXT NOT NULL,
+		"user_id" TEXT NOT NULL,
+		"option" INTEGER NOT NULL,
+		"timestamp" DATETIME DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (poll_id, user_id)
+	);`
+
+	if _, err := db.Exec(createTableSQL); err != nil {
+		log.Fatal("error creating chat polls tables", err)
+	}
+}
+
+// InsertPoll will save a newly started poll.
+func InsertPoll(poll models.Poll) error {
+	options, err := json.Marshal(poll.Options)
+	if err != nil {
+		return err
+	}
+
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	tx, err := _db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	stmt, err := tx.Prepare("INSERT INTO polls(id, question, options, created_by, created_at, ends_at) values(?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(poll.ID, poll.Question, string(options), poll.CreatedBy, poll.CreatedAt, poll.EndsAt); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetPollClosed will mark a poll as closed so it no longer accepts votes.
+func SetPollClosed(pollID string, closedAt time.Time) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	_, err := _db.Exec("UPDATE polls SET closed_at = ? WHERE id = ?", closedAt, pollID)
+	return err
+}
+
+// SavePollVote will save a user's vote on a poll. It returns false if the
+// user has already voted on this poll.
+func SavePollVote(pollID string, userID string, option int) (bool, error) {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	result, err := _db.Exec("INSERT INTO poll_votes(poll_id, user_id, option) values(?, ?, ?) ON CONFLICT DO NOTHING", pollID, userID, option)
+	if err != nil {
+		return false, err
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return inserted > 0, nil
+}
+
+// GetPoll will return a single poll with its vote counts.
+func GetPoll(pollID string) (*models.Poll, error) {
+	polls, err := getPolls("SELECT id, question, options, created_by, created_at, ends_at, closed_at FROM polls WHERE id = ?", pollID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(polls) == 0 {
+		return nil, errors.New("poll " + pollID + " not found")
+	}
+
+	return &polls[0], nil
+}
+
+// GetPolls will return all polls, newest first, with their vote counts.
+func GetPolls() ([]models.Poll, error) {
+	return getPolls("SELECT id, question, options, created_by, created_at, ends_at, closed_at FROM polls ORDER BY created_at DESC")
+}
+
+// GetOpenPolls will return the polls that have not been closed.
+func GetOpenPolls() ([]models.Poll, error) {
+	return getPolls("SELECT id, question, options, created_by, created_at, ends_at, closed_at FROM polls WHERE closed_at IS NULL ORDER BY created_at DESC")
+}
+
+func getPolls(query string, args ...interface{}) ([]models.Poll, error) {
+	polls := make([]models.Poll, 0)
+
+	rows, err := _db.Query(query, args...)
+	if err != nil {
+		return polls, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var poll models.Poll
+		var options string
+		var createdBy *string
+
+		if err := rows.Scan(&poll.ID, &poll.Question, &options, &createdBy, &poll.CreatedAt, &poll.EndsAt, &poll.ClosedAt); err != nil {
+			return polls, errors.Wrap(err, "unable to read poll")
+		}
+
+		if err := json.Unmarshal([]byte(options), &poll.Options); err != nil {
+			return polls, errors.Wrap(err, "unable to read poll options")
+		}
+
+		if createdBy != nil {
+			poll.CreatedBy = *createdBy
+		}
+
+		polls = append(polls, poll)
+	}
+
+	if err := rows.Err(); err != nil {
+		return polls, err
+	}
+
+	for i := range polls {
+		votes, err := getPollVoteCounts(polls[i].ID, len(polls[i].Options))
+		if err != nil {
+			return polls, err
+		}
+		polls[i].Votes = votes
+	}
+
+	return polls, nil
+}
+
+// GetPollVotesForUser will return every poll vote cast by a single user.
+func GetPollVotesForUser(userID string) ([]models.PollVote, error) {
+	rows, err := _db.Query("SELECT poll_id, option, timestamp FROM poll_votes WHERE user_id = ? ORDER BY timestamp", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	votes := []models.PollVote{}
+	for rows.Next() {
+		var vote models.PollVote
+		if err := rows.Scan(&vote.PollID, &vote.Option, &vote.Timestamp); err != nil {
+			return nil, err
+		}
+		votes = append(votes, vote)
+	}
+
+	return votes, rows.Err()
+}
+
+func getPollVoteCounts(pollID string, optionCount int) ([]int, error) {
+	votes := make([]int, optionCount)
+
+	rows, err := _db.Query("SELECT option, COUNT(*) FROM poll_votes WHERE poll_id = ? GROUP BY option", pollID)
+	if err != nil {
+		return votes, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var option int
+		var count int
+		if err := rows.Scan(&option, &count); err != nil {
+			return votes, err
+		}
+
+		if option >= 0 && option < optionCount {
+			votes[option] = count
+		}
+	}
+
+	return votes, rows.Err()
+}
@@ -11,6 +11,8 @@ func createAccessTokenTable(db *sql.DB) {
     "token" TEXT NOT NULL PRIMARY KEY,
     "user_id" TEXT NOT NULL,
     "timestamp" DATE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    "expires_at" TIMESTAMP,
+    "allowed_ips" TEXT DEFAULT '',
     FOREIGN KEY(user_id) REFERENCES users(id)
   );`
 
@@ -73,3 +75,70 @@ func GetUsersCount() int64 {
 	}
 	return count
 }
+
+func createUserProfilesTable(db *sql.DB) {
+	log.Traceln("Creating user profiles table...")
+
+	createTableSQL := `CREATE TABLE IF NOT EXISTS user_profiles (
+		"user_id" TEXT NOT NULL PRIMARY KEY,
+		"bio" TEXT,
+		"avatar" TEXT,
+		"pronouns" TEXT,
+		"links" TEXT,
+		"updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY(user_id) REFERENCES users(id)
+	);`
+
+	stmt, err := db.Prepare(createTableSQL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec()
+	if err != nil {
+		log.Warnln(err)
+	}
+}
+
+func createRolesTables(db *sql.DB) {
+	log.Traceln("Creating roles tables...")
+
+	createTableSQL := `CREATE TABLE IF NOT EXISTS roles (
+		"id" TEXT NOT NULL PRIMARY KEY,
+		"name" TEXT NOT NULL UNIQUE,
+		"color" TEXT,
+		"badge" TEXT,
+		"permissions" TEXT,
+		"created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE TABLE IF NOT EXISTS user_roles (
+		"user_id" TEXT NOT NULL,
+		"role_id" TEXT NOT NULL,
+		"assigned_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (user_id, role_id),
+		FOREIGN KEY(user_id) REFERENCES users(id),
+		FOREIGN KEY(role_id) REFERENCES roles(id)
+	);
+	CREATE INDEX IF NOT EXISTS idx_user_roles_role_id ON user_roles (role_id);`
+
+	if _, err := db.Exec(createTableSQL); err != nil {
+		log.Warnln(err)
+	}
+}
+
+func createUserIPAddressesTable(db *sql.DB) {
+	log.Traceln("Creating user IP addresses table...")
+
+	createTableSQL := `CREATE TABLE IF NOT EXISTS user_ip_addresses (
+		"user_id" TEXT NOT NULL,
+		"ip_address" TEXT NOT NULL,
+		"last_seen" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (user_id, ip_address),
+		FOREIGN KEY(user_id) REFERENCES users(id)
+	);
+	CREATE INDEX IF NOT EXISTS idx_user_ip_addresses_ip_address ON user_ip_addresses (ip_address);`
+
+	if _, err := db.Exec(createTableSQL); err != nil {
+		log.Warnln(err)
+	}
+}
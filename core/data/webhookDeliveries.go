@@ -0,0 +1,145 @@
+package data
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/owncast/owncast/models"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// ErrWebhookDeliveryNotFound is returned when a webhook delivery does not exist.
+var ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+
+const webhookDeliveryColumns = "id, webhook_id, event_type, payload, status, attempts, status_code, latency_ms, response, error, created_at, last_attempt_at, next_attempt_at"
+
+func createWebhookDeliveriesTable(db *sql.DB) {
+	log.Traceln("Creating webhook deliveries table...")
+
+	createTableSQL := `CREATE TABLE IF NOT EXISTS webhook_deliveries (
+		"id" TEXT NOT NULL PRIMARY KEY,
+		"webhook_id" INTEGER NOT NULL,
+		"event_type" TEXT NOT NULL,
+		"payload" TEXT NOT NULL,
+		"status" TEXT NOT NULL,
+		"attempts" INTEGER NOT NULL DEFAULT 0,
+		"status_code" INTEGER NOT NULL DEFAULT 0,
+		"latency_ms" INTEGER NOT NULL DEFAULT 0,
+		"response" TEXT NOT NULL DEFAULT '',
+		"error" TEXT NOT NULL DEFAULT '',
+		"created_at" TIMESTAMP NOT NULL,
+		"last_attempt_at" TIMESTAMP,
+		"next_attempt_at" TIMESTAMP
+	);`
+
+	if _, err := db.Exec(createTableSQL); err != nil {
+		log.Warnln(err)
+	}
+
+	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status_next_attempt ON webhook_deliveries (status, next_attempt_at)`); err != nil {
+		log.Warnln(err)
+	}
+}
+
+// InsertWebhookDelivery will save a new webhook delivery.
+func InsertWebhookDelivery(delivery models.WebhookDelivery) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	_, err := _db.Exec("INSERT INTO webhook_deliveries(id, webhook_id, event_type, payload, status, attempts, created_at, next_attempt_at) VALUES(?, ?, ?, ?, ?, ?, ?, ?)",
+		delivery.ID, delivery.WebhookID, delivery.EventType, string(delivery.Payload), delivery.Status, delivery.Attempts, delivery.CreatedAt, delivery.NextAttemptAt)
+	return errors.Wrap(err, "unable to save webhook delivery")
+}
+
+// UpdateWebhookDelivery will save the result of an attempt to send a webhook
+// delivery.
+func UpdateWebhookDelivery(delivery models.WebhookDelivery) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	_, err := _db.Exec("UPDATE webhook_deliveries SET status = ?, attempts = ?, status_code = ?, latency_ms = ?, response = ?, error = ?, last_attempt_at = ?, next_attempt_at = ? WHERE id = ?",
+		delivery.Status, delivery.Attempts, delivery.StatusCode, delivery.LatencyMS, delivery.Response, delivery.Error, delivery.LastAttemptAt, delivery.NextAttemptAt, delivery.ID)
+	return errors.Wrap(err, "unable to update webhook delivery")
+}
+
+// GetWebhookDelivery will return a single webhook delivery.
+func GetWebhookDelivery(id string) (*models.WebhookDelivery, error) {
+	deliveries, err := getWebhookDeliveries("SELECT "+webhookDeliveryColumns+" FROM webhook_deliveries WHERE id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deliveries) == 0 {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+
+	return &deliveries[0], nil
+}
+
+// GetWebhookDeliveries will return the most recent deliveries, optionally
+// only those to a single webhook.
+func GetWebhookDeliveries(webhookID int, limit int) ([]models.WebhookDelivery, error) {
+	if webhookID == 0 {
+		return getWebhookDeliveries("SELECT "+webhookDeliveryColumns+" FROM webhook_deliveries ORDER BY created_at DESC LIMIT ?", limit)
+	}
+
+	return getWebhookDeliveries("SELECT "+webhookDeliveryColumns+" FROM webhook_deliveries WHERE webhook_id = ? ORDER BY created_at DESC LIMIT ?", webhookID, limit)
+}
+
+// ClaimDueWebhookDeliveries will return the pending deliveries to enabled
+// webhooks that are due to be attempted again, and postpone their next
+// attempt by the lease so they are not claimed twice while being sent.
+func ClaimDueWebhookDeliveries(now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error) {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	deliveries, err := getWebhookDeliveries(`SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries
+		WHERE status = ? AND next_attempt_at <= ?
+		AND webhook_id IN (SELECT id FROM webhooks WHERE disabled_at IS NULL)
+		ORDER BY next_attempt_at ASC LIMIT ?`, models.WebhookDeliveryPending, now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	leaseEnd := now.Add(lease)
+	for i := range deliveries {
+		if _, err := _db.Exec("UPDATE webhook_deliveries SET next_attempt_at = ? WHERE id = ?", leaseEnd, deliveries[i].ID); err != nil {
+			return nil, err
+		}
+		deliveries[i].NextAttemptAt = &leaseEnd
+	}
+
+	return deliveries, nil
+}
+
+// RemoveWebhookDeliveriesBefore will remove the finished deliveries created
+// before a point in time.
+func RemoveWebhookDeliveriesBefore(before time.Time) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	_, err := _db.Exec("DELETE FROM webhook_deliveries WHERE status != ? AND created_at < ?", models.WebhookDeliveryPending, before)
+	return err
+}
+
+func getWebhookDeliveries(query string, args ...interface{}) ([]models.WebhookDelivery, error) {
+	rows, err := _db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]models.WebhookDelivery, 0)
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+		var payload string
+		if err := rows.Scan(&delivery.ID, &delivery.WebhookID, &delivery.EventType, &payload, &delivery.Status, &delivery.Attempts, &delivery.StatusCode, &delivery.LatencyMS, &delivery.Response, &delivery.Error, &delivery.CreatedAt, &delivery.LastAttemptAt, &delivery.NextAttemptAt); err != nil {
+			return nil, err
+		}
+		delivery.Payload = []byte(payload)
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
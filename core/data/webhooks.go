@@ -1,15 +1,19 @@
 package data
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/utils"
 	log "github.com/sirupsen/logrus"
 )
 
+const webhookColumns = "id, url, events, timestamp, last_used, secret, failure_count, disabled_at, filters, template, content_type"
+
 func createWebhooksTable() {
 	log.Traceln("Creating webhooks table...")
 
@@ -18,7 +22,13 @@ func createWebhooksTable() {
 		"url" string NOT NULL,
 		"events" TEXT NOT NULL,
 		"timestamp" DATETIME DEFAULT CURRENT_TIMESTAMP,
-		"last_used" DATETIME
+		"last_used" DATETIME,
+		"secret" TEXT,
+		"failure_count" INTEGER NOT NULL DEFAULT 0,
+		"disabled_at" TIMESTAMP,
+		"filters" TEXT,
+		"template" TEXT,
+		"content_type" TEXT
 	);`
 
 	stmt, err := _db.Prepare(createTableSQL)
@@ -31,37 +41,89 @@ func createWebhooksTable() {
 	}
 }
 
-// InsertWebhook will add a new webhook to the database.
-func InsertWebhook(url string, events []models.EventType) (int, error) {
+// InsertWebhook will add a new webhook to the database and return its ID
+// along with the secret generated to sign its requests.
+func InsertWebhook(url string, events []models.EventType, options models.WebhookOptions) (int, string, error) {
 	log.Traceln("Adding new webhook")
 
 	eventsString := strings.Join(events, ",")
 
+	filters, err := json.Marshal(options.Filters)
+	if err != nil {
+		return 0, "", err
+	}
+
+	secret, err := utils.GenerateAccessToken()
+	if err != nil {
+		return 0, "", err
+	}
+
 	tx, err := _db.Begin()
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
-	stmt, err := tx.Prepare("INSERT INTO webhooks(url, events) values(?, ?)")
+	stmt, err := tx.Prepare("INSERT INTO webhooks(url, events, secret, filters, template, content_type) values(?, ?, ?, ?, ?, ?)")
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
 	defer stmt.Close()
 
-	insertResult, err := stmt.Exec(url, eventsString)
+	insertResult, err := stmt.Exec(url, eventsString, secret, string(filters), options.Template, options.ContentType)
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
 
 	if err = tx.Commit(); err != nil {
-		return 0, err
+		return 0, "", err
 	}
 
 	newID, err := insertResult.LastInsertId()
 	if err != nil {
-		return 0, err
+		return 0, "", err
+	}
+
+	return int(newID), secret, err
+}
+
+// UpdateWebhook will change where a webhook is sent, which events are sent to
+// it and its options.
+func UpdateWebhook(id int, url string, events []models.EventType, options models.WebhookOptions) error {
+	filters, err := json.Marshal(options.Filters)
+	if err != nil {
+		return err
+	}
+
+	result, err := _db.Exec("UPDATE webhooks SET url = ?, events = ?, filters = ?, template = ?, content_type = ? WHERE id = ?",
+		url, strings.Join(events, ","), string(filters), options.Template, options.ContentType, id)
+	if err != nil {
+		return err
+	}
+
+	if rowsUpdated, _ := result.RowsAffected(); rowsUpdated == 0 {
+		return errors.New(fmt.Sprint(id) + " not found")
+	}
+
+	return nil
+}
+
+// RotateWebhookSecret will replace the secret used to sign requests to a
+// webhook and return the new one.
+func RotateWebhookSecret(id int) (string, error) {
+	secret, err := utils.GenerateAccessToken()
+	if err != nil {
+		return "", err
 	}
 
-	return int(newID), err
+	result, err := _db.Exec("UPDATE webhooks SET secret = ? WHERE id = ?", secret, id)
+	if err != nil {
+		return "", err
+	}
+
+	if rowsUpdated, _ := result.RowsAffected(); rowsUpdated == 0 {
+		return "", errors.New(fmt.Sprint(id) + " not found")
+	}
+
+	return secret, nil
 }
 
 // DeleteWebhook will delete a webhook from the database.
@@ -88,6 +150,11 @@ func DeleteWebhook(id int) error {
 		return errors.New(fmt.Sprint(id) + " not found")
 	}
 
+	if _, err := tx.Exec("DELETE FROM webhook_deliveries WHERE webhook_id = ?", id); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
 	if err = tx.Commit(); err != nil {
 		return err
 	}
@@ -95,57 +162,54 @@ func DeleteWebhook(id int) error {
 	return nil
 }
 
-// GetWebhooksForEvent will return all of the webhooks that want to be notified about an event type.
+// GetWebhooksForEvent will return all of the enabled webhooks that want to be notified about an event type.
 func GetWebhooksForEvent(event models.EventType) []models.Webhook {
-	webhooks := make([]models.Webhook, 0)
-
-	query := `SELECT * FROM (
-		WITH RECURSIVE split(id, url, event, rest) AS (
-		  SELECT id, url, '', events || ',' FROM webhooks
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE disabled_at IS NULL AND id IN (
+		WITH RECURSIVE split(id, event, rest) AS (
+		  SELECT id, '', events || ',' FROM webhooks
 		   UNION ALL
-		  SELECT id, url,
+		  SELECT id,
 				 substr(rest, 0, instr(rest, ',')),
 				 substr(rest, instr(rest, ',')+1)
 			FROM split
 		   WHERE rest <> '')
-		SELECT id, url, event
+		SELECT id
 		  FROM split
-		 WHERE event <> ''
-	  ) AS webhook WHERE event IS "` + event + `"`
+		 WHERE event = ?
+	  )`
 
-	rows, err := _db.Query(query)
-	if err != nil || rows.Err() != nil {
-		log.Fatal(err)
+	webhooks, err := getWebhooks(query, event)
+	if err != nil {
+		log.Debugln(err)
+		log.Error("There is a problem with the database.")
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var id int
-		var url string
+	return webhooks
+}
 
-		if err := rows.Scan(&id, &url, &event); err != nil {
-			log.Debugln(err)
-			log.Error("There is a problem with the database.")
-			break
-		}
+// GetWebhooks will return all the webhooks.
+func GetWebhooks() ([]models.Webhook, error) { //nolint
+	return getWebhooks("SELECT " + webhookColumns + " FROM webhooks")
+}
 
-		singleWebhook := models.Webhook{
-			ID:  id,
-			URL: url,
-		}
+// GetWebhook will return a single webhook.
+func GetWebhook(id int) (*models.Webhook, error) {
+	webhooks, err := getWebhooks("SELECT "+webhookColumns+" FROM webhooks WHERE id = ?", id)
+	if err != nil {
+		return nil, err
+	}
 
-		webhooks = append(webhooks, singleWebhook)
+	if len(webhooks) == 0 {
+		return nil, errors.New(fmt.Sprint(id) + " not found")
 	}
-	return webhooks
+
+	return &webhooks[0], nil
 }
 
-// GetWebhooks will return all the webhooks.
-func GetWebhooks() ([]models.Webhook, error) { //nolint
+func getWebhooks(query string, args ...interface{}) ([]models.Webhook, error) {
 	webhooks := make([]models.Webhook, 0)
 
-	query := "SELECT * FROM webhooks"
-
-	rows, err := _db.Query(query)
+	rows, err := _db.Query(query, args...)
 	if err != nil {
 		return webhooks, err
 	}
@@ -157,8 +221,12 @@ func GetWebhooks() ([]models.Webhook, error) { //nolint
 		var events string
 		var timestampString string
 		var lastUsedString *string
+		var secret *string
+		var failureCount int
+		var disabledAt *time.Time
+		var filters, template, contentType *string
 
-		if err := rows.Scan(&id, &url, &events, &timestampString, &lastUsedString); err != nil {
+		if err := rows.Scan(&id, &url, &events, &timestampString, &lastUsedString, &secret, &failureCount, &disabledAt, &filters, &template, &contentType); err != nil {
 			log.Error("There is a problem reading the database.", err)
 			return webhooks, err
 		}
@@ -175,11 +243,27 @@ func GetWebhooks() ([]models.Webhook, error) { //nolint
 		}
 
 		singleWebhook := models.Webhook{
-			ID:        id,
-			URL:       url,
-			Events:    strings.Split(events, ","),
-			Timestamp: timestamp,
-			LastUsed:  lastUsed,
+			ID:           id,
+			URL:          url,
+			Events:       strings.Split(events, ","),
+			Timestamp:    timestamp,
+			LastUsed:     lastUsed,
+			FailureCount: failureCount,
+			DisabledAt:   disabledAt,
+		}
+		if secret != nil {
+			singleWebhook.Secret = *secret
+		}
+		if filters != nil && *filters != "" {
+			if err := json.Unmarshal([]byte(*filters), &singleWebhook.Filters); err != nil {
+				log.Errorln("unable to read webhook filters", err)
+			}
+		}
+		if template != nil {
+			singleWebhook.Template = *template
+		}
+		if contentType != nil {
+			singleWebhook.ContentType = *contentType
 		}
 
 		webhooks = append(webhooks, singleWebhook)
@@ -192,13 +276,14 @@ func GetWebhooks() ([]models.Webhook, error) { //nolint
 	return webhooks, nil
 }
 
-// SetWebhookAsUsed will update the last used time for a webhook.
+// SetWebhookAsUsed will update the last used time for a webhook and reset
+// its count of failed deliveries.
 func SetWebhookAsUsed(webhook models.Webhook) error {
 	tx, err := _db.Begin()
 	if err != nil {
 		return err
 	}
-	stmt, err := tx.Prepare("UPDATE webhooks SET last_used = CURRENT_TIMESTAMP WHERE id = ?")
+	stmt, err := tx.Prepare("UPDATE webhooks SET last_used = CURRENT_TIMESTAMP, failure_count = 0 WHERE id = ?")
 	if err != nil {
 		return err
 	}
@@ -214,3 +299,47 @@ func SetWebhookAsUsed(webhook models.Webhook) error {
 
 	return nil
 }
+
+// SetWebhookDeliveryFailed will count a delivery that failed every attempt
+// and disable the webhook once it has failed too many times in a row. It
+// returns if the webhook was disabled.
+func SetWebhookDeliveryFailed(id int, disableAfter int) (bool, error) {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	if _, err := _db.Exec("UPDATE webhooks SET failure_count = failure_count + 1 WHERE id = ?", id); err != nil {
+		return false, err
+	}
+
+	result, err := _db.Exec("UPDATE webhooks SET disabled_at = ? WHERE id = ? AND disabled_at IS NULL AND failure_count >= ?", time.Now(), id, disableAfter)
+	if err != nil {
+		return false, err
+	}
+
+	disabled, _ := result.RowsAffected()
+	return disabled > 0, nil
+}
+
+// SetWebhookEnabled will enable or disable sending events to a webhook.
+// Enabling a webhook resets its count of failed deliveries.
+func SetWebhookEnabled(id int, enabled bool) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	var disabledAt *time.Time
+	if !enabled {
+		now := time.Now()
+		disabledAt = &now
+	}
+
+	result, err := _db.Exec("UPDATE webhooks SET disabled_at = ?, failure_count = 0 WHERE id = ?", disabledAt, id)
+	if err != nil {
+		return err
+	}
+
+	if rowsUpdated, _ := result.RowsAffected(); rowsUpdated == 0 {
+		return errors.New(fmt.Sprint(id) + " not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/core/pubsub"
+)
+
+// Each Owncast node periodically shares its viewer count and stream state so
+// nodes that only serve the web and chat can report the totals.
+const nodeStatsTopic = "stats.node"
+
+type nodeStats struct {
+	NodeID  string `json:"nodeId"`
+	Online  bool   `json:"online"`
+	Viewers int    `json:"viewers"`
+
+	receivedAt time.Time
+}
+
+var (
+	_remoteNodeStats     = map[string]nodeStats{}
+	_remoteNodeStatsLock = sync.RWMutex{}
+)
+
+func setupNodeStats() {
+	pubsub.Subscribe(nodeStatsTopic, nodeStatsReceived)
+}
+
+func publishNodeStats() {
+	l.RLock()
+	stats := nodeStats{
+		NodeID:  pubsub.NodeID,
+		Online:  IsStreamConnected(),
+		Viewers: len(_stats.Viewers),
+	}
+	l.RUnlock()
+
+	payload, err := json.Marshal(stats)
+	if err != nil {
+		log.Errorln(err)
+		return
+	}
+
+	if err := pubsub.Publish(nodeStatsTopic, payload); err != nil {
+		log.Debugln("unable to publish node stats", err)
+	}
+}
+
+func nodeStatsReceived(payload []byte) {
+	var stats nodeStats
+	if err := json.Unmarshal(payload, &stats); err != nil {
+		log.Errorln("error unmarshalling node stats", err)
+		return
+	}
+
+	if stats.NodeID == pubsub.NodeID {
+		return
+	}
+
+	stats.receivedAt = time.Now()
+
+	_remoteNodeStatsLock.Lock()
+	defer _remoteNodeStatsLock.Unlock()
+
+	_remoteNodeStats[stats.NodeID] = stats
+
+	for nodeID, s := range _remoteNodeStats {
+		if time.Since(s.receivedAt) > _activeViewerPurgeTimeout {
+			delete(_remoteNodeStats, nodeID)
+		}
+	}
+}
+
+// getRemoteNodeStats will return if the stream is online on another node
+// and the number of viewers watching through other nodes.
+func getRemoteNodeStats() (bool, int) {
+	_remoteNodeStatsLock.RLock()
+	defer _remoteNodeStatsLock.RUnlock()
+
+	online := false
+	viewers := 0
+	for _, s := range _remoteNodeStats {
+		if time.Since(s.receivedAt) > _activeViewerPurgeTimeout {
+			continue
+		}
+		online = online || s.Online
+		viewers += s.Viewers
+	}
+
+	return online, viewers
+}
@@ -4,15 +4,26 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 
 	"github.com/grafov/m3u8"
 	"github.com/owncast/owncast/config"
+	"github.com/owncast/owncast/core/data"
 	"github.com/owncast/owncast/static"
 	"github.com/owncast/owncast/utils"
 	log "github.com/sirupsen/logrus"
 )
 
-func appendOfflineToVariantPlaylist(index int, playlistFilePath string) {
+// The duration of the offline clip when it can not be read from the clip,
+// which is the length of the default clip.
+const defaultOfflineClipDuration = 8.0
+
+// Held while the stream changes between live and offline content, so
+// changing the offline clip does not replace a stream that is connecting.
+var _offlineStateLock sync.Mutex
+
+func appendOfflineToVariantPlaylist(index int, playlistFilePath string, duration float64) {
 	existingPlaylistContents, err := os.ReadFile(playlistFilePath) // nolint: gosec
 	if err != nil {
 		log.Debugln("unable to read existing playlist file", err)
@@ -34,8 +45,7 @@ func appendOfflineToVariantPlaylist(index int, playlistFilePath string) {
 
 	// Manually append the offline clip to the end of the media playlist.
 	_, _ = atomicWriteTmpPlaylistFile.WriteString("#EXT-X-DISCONTINUITY\n")
-	// If "offline" content gets changed then change the duration below
-	_, _ = atomicWriteTmpPlaylistFile.WriteString("#EXTINF:8.000000,\n")
+	_, _ = fmt.Fprintf(atomicWriteTmpPlaylistFile, "#EXTINF:%f,\n", duration)
 	_, _ = atomicWriteTmpPlaylistFile.WriteString("offline.ts\n")
 	_, _ = atomicWriteTmpPlaylistFile.WriteString("#EXT-X-ENDLIST\n")
 
@@ -48,7 +58,7 @@ func appendOfflineToVariantPlaylist(index int, playlistFilePath string) {
 	}
 }
 
-func makeVariantIndexOffline(index int, offlineFilePath string, offlineFilename string) {
+func makeVariantIndexOffline(index int, offlineFilePath string, offlineFilename string, duration float64) {
 	playlistFilePath := fmt.Sprintf(filepath.Join(config.HLSStoragePath, "%d/stream.m3u8"), index)
 	segmentFilePath := fmt.Sprintf(filepath.Join(config.HLSStoragePath, "%d/%s"), index, offlineFilename)
 
@@ -61,23 +71,22 @@ func makeVariantIndexOffline(index int, offlineFilePath string, offlineFilename
 	}
 
 	if utils.DoesFileExists(playlistFilePath) {
-		appendOfflineToVariantPlaylist(index, playlistFilePath)
+		appendOfflineToVariantPlaylist(index, playlistFilePath, duration)
 	} else {
-		createEmptyOfflinePlaylist(playlistFilePath, offlineFilename)
+		createEmptyOfflinePlaylist(playlistFilePath, offlineFilename, duration)
 	}
 	if _, err := _storage.Save(playlistFilePath, 0); err != nil {
 		log.Warnln(err)
 	}
 }
 
-func createEmptyOfflinePlaylist(playlistFilePath string, offlineFilename string) {
+func createEmptyOfflinePlaylist(playlistFilePath string, offlineFilename string, duration float64) {
 	p, err := m3u8.NewMediaPlaylist(1, 1)
 	if err != nil {
 		log.Errorln(err)
 	}
 
-	// If "offline" content gets changed then change the duration below
-	if err := p.Append(offlineFilename, 8.0, ""); err != nil {
+	if err := p.Append(offlineFilename, duration, ""); err != nil {
 		log.Errorln(err)
 	}
 
@@ -92,18 +101,99 @@ func createEmptyOfflinePlaylist(playlistFilePath string, offlineFilename string)
 	}
 }
 
-func saveOfflineClipToDisk(offlineFilename string) (string, error) {
-	offlineFileData := static.GetOfflineSegment()
+// saveOfflineClipToDisk will write the offline clip to a temporary file and
+// return its path and duration in seconds.
+func saveOfflineClipToDisk(offlineFilename string) (string, float64, error) {
+	offlineFileData := getOfflineSegment()
 	offlineTmpFile, err := os.CreateTemp(config.TempDir, offlineFilename)
 	if err != nil {
-		log.Errorln("unable to create temp file for offline video segment", err)
+		return "", 0, fmt.Errorf("unable to create temp file for offline video segment: %s", err)
 	}
+	defer offlineTmpFile.Close()
 
 	if _, err = offlineTmpFile.Write(offlineFileData); err != nil {
-		return "", fmt.Errorf("unable to write offline segment to disk: %s", err)
+		return "", 0, fmt.Errorf("unable to write offline segment to disk: %s", err)
+	}
+
+	duration, err := utils.GetTransportStreamDuration(offlineFileData)
+	if err != nil {
+		log.Warnln("unable to read the offline clip duration", err)
+		duration = defaultOfflineClipDuration
+	}
+
+	return offlineTmpFile.Name(), duration, nil
+}
+
+// getOfflineSegment will return the selected offline clip, falling back to
+// the default clip if it can no longer be read.
+func getOfflineSegment() []byte {
+	if name := data.GetOfflineClip(); name != "" {
+		clip, err := os.ReadFile(filepath.Join(config.OfflineClipsDirectory, name)) // nolint: gosec
+		if err == nil {
+			return clip
+		}
+		log.Warnln("unable to read offline clip", name, err)
+	}
+
+	return static.GetOfflineSegment()
+}
+
+// GetOfflineClips will return the names of the clips in the offline clips
+// directory that can be shown while offline.
+func GetOfflineClips() []string {
+	clips := []string{}
+
+	files, err := os.ReadDir(config.OfflineClipsDirectory)
+	if err != nil {
+		return clips
+	}
+
+	for _, file := range files {
+		if !file.IsDir() && strings.HasSuffix(file.Name(), ".ts") {
+			clips = append(clips, file.Name())
+		}
+	}
+
+	return clips
+}
+
+// SetOfflineClip will change the clip shown while offline. An empty name
+// restores the default clip. If the stream is offline the new clip is shown
+// right away.
+func SetOfflineClip(name string) error {
+	_offlineStateLock.Lock()
+	defer _offlineStateLock.Unlock()
+
+	if name != "" {
+		found := false
+		for _, clip := range GetOfflineClips() {
+			if clip == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s is not a clip in %s", name, config.OfflineClipsDirectory)
+		}
+
+		clip, err := os.ReadFile(filepath.Join(config.OfflineClipsDirectory, name)) // nolint: gosec
+		if err != nil {
+			return err
+		}
+		if _, err := utils.GetTransportStreamDuration(clip); err != nil {
+			return fmt.Errorf("%s is not a video clip that can be streamed: %s", name, err)
+		}
 	}
 
-	offlineFilePath := offlineTmpFile.Name()
+	if err := data.SetOfflineClip(name); err != nil {
+		return err
+	}
+
+	if !_stats.StreamConnected {
+		StopOfflineCleanupTimer()
+		resetDirectories()
+		return transitionToOfflineVideoStreamContent()
+	}
 
-	return offlineFilePath, nil
+	return nil
 }
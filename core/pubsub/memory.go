@@ -0,0 +1,42 @@
+package pubsub
+
+import "sync"
+
+// MemoryBus delivers messages to subscribers within this process only.
+type MemoryBus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+// NewMemoryBus will return a bus that does not leave this process.
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{
+		handlers: map[string][]Handler{},
+	}
+}
+
+// Publish will synchronously call every handler subscribed to the topic.
+func (b *MemoryBus) Publish(topic string, payload []byte) error {
+	b.mu.RLock()
+	handlers := b.handlers[topic]
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(payload)
+	}
+
+	return nil
+}
+
+// Subscribe will call the handler for every message published to the topic.
+func (b *MemoryBus) Subscribe(topic string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[topic] = append(b.handlers[topic], handler)
+}
+
+// Close is a no-op for the in-memory bus.
+func (b *MemoryBus) Close() error {
+	return nil
+}
@@ -0,0 +1,122 @@
+package pubsub
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/teris-io/shortid"
+)
+
+// Handler is called with the payload of every message published to a topic.
+type Handler func(payload []byte)
+
+// Bus distributes messages between every Owncast process sharing it. Each
+// handler subscribed to a topic receives all messages published to it,
+// including the ones published by this process.
+type Bus interface {
+	Publish(topic string, payload []byte) error
+	Subscribe(topic string, handler Handler)
+	Close() error
+}
+
+// NodeID uniquely identifies this Owncast process on the bus.
+var NodeID = shortid.MustGenerate()
+
+// How many messages can wait to be published before new ones are dropped.
+const publishQueueSize = 1024
+
+type queuedMessage struct {
+	topic   string
+	payload []byte
+}
+
+var (
+	_publishQueue     = make(chan queuedMessage, publishQueueSize)
+	_publishQueueOnce sync.Once
+)
+
+var (
+	_bus Bus = NewMemoryBus()
+	l        = sync.RWMutex{}
+)
+
+// Setup will connect to the bus described by the address. An empty address
+// keeps messages within this process. A redis:// address shares messages
+// with every process connected to the same Redis compatible server. Only
+// live events are shared, so every process must use the same database.
+func Setup(address string) error {
+	if address == "" {
+		return nil
+	}
+
+	u, err := url.Parse(address)
+	if err != nil {
+		return err
+	}
+
+	var bus Bus
+	switch u.Scheme {
+	case "redis":
+		if bus, err = NewRedisBus(u); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported pub/sub backend %s", u.Scheme)
+	}
+
+	l.Lock()
+	previous := _bus
+	_bus = bus
+	l.Unlock()
+
+	if err := previous.Close(); err != nil {
+		log.Debugln(err)
+	}
+
+	log.Infoln("Sharing chat and viewer events with other Owncast nodes via", u.Host)
+
+	return nil
+}
+
+// Publish will send a message to every subscriber of the topic.
+func Publish(topic string, payload []byte) error {
+	l.RLock()
+	defer l.RUnlock()
+
+	return _bus.Publish(topic, payload)
+}
+
+// PublishAsync will queue a message to be published without waiting for the
+// bus, so a slow or unavailable bus does not hold up the caller. Messages
+// are published in order. Failures are logged, and messages are dropped if
+// the queue is full.
+func PublishAsync(topic string, payload []byte) {
+	_publishQueueOnce.Do(func() {
+		go publishQueued()
+	})
+
+	select {
+	case _publishQueue <- queuedMessage{topic: topic, payload: payload}:
+	default:
+		log.Warnln("pub/sub publish queue is full, dropping message for", topic)
+	}
+}
+
+func publishQueued() {
+	for message := range _publishQueue {
+		if err := Publish(message.topic, message.payload); err != nil {
+			log.Warnln("unable to publish message for", message.topic, err)
+		}
+	}
+}
+
+// Subscribe will call the handler for every message published to the topic.
+// Subscriptions must be made after Setup.
+func Subscribe(topic string, handler Handler) {
+	l.RLock()
+	defer l.RUnlock()
+
+	_bus.Subscribe(topic, handler)
+}
@@ -0,0 +1,27 @@
+package pubsub
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPublishAsync(t *testing.T) {
+	received := make(chan string, 2)
+	Subscribe("test.async", func(payload []byte) {
+		received <- string(payload)
+	})
+
+	PublishAsync("test.async", []byte("first"))
+	PublishAsync("test.async", []byte("second"))
+
+	for _, expected := range []string{"first", "second"} {
+		select {
+		case payload := <-received:
+			if payload != expected {
+				t.Errorf("expected %s, got %s", expected, payload)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("the message was not published")
+		}
+	}
+}
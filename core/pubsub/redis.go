@@ -0,0 +1,292 @@
+package pubsub
+
+import (
+	"bufio"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	redisChannelPrefix   = "owncast:"
+	redisDefaultPort     = "6379"
+	redisTimeout         = 5 * time.Second
+	redisMaxRetryBackoff = 30 * time.Second
+)
+
+// RedisBus shares messages between processes using the publish/subscribe
+// commands of a Redis compatible server.
+type RedisBus struct {
+	address  string
+	username string
+	password string
+
+	publishLock   sync.Mutex
+	publishConn   net.Conn
+	publishReader *bufio.Reader
+
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+
+	subscribeLock sync.Mutex
+	subscribeConn net.Conn
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// NewRedisBus will connect to the Redis compatible server at the address,
+// in the form redis://[[username]:password@]host[:port].
+func NewRedisBus(u *url.URL) (*RedisBus, error) {
+	address := u.Host
+	if u.Port() == "" {
+		address = net.JoinHostPort(u.Hostname(), redisDefaultPort)
+	}
+
+	b := &RedisBus{
+		address:  address,
+		handlers: map[string][]Handler{},
+		closed:   make(chan struct{}),
+	}
+
+	if u.User != nil {
+		b.username = u.User.Username()
+		b.password, _ = u.User.Password()
+	}
+
+	// Connect up front so a misconfigured server is reported at startup.
+	b.publishLock.Lock()
+	err := b.connectPublisher()
+	b.publishLock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	go b.runSubscriber()
+
+	return b, nil
+}
+
+// Publish will send a message to every subscriber of the topic on every
+// connected process.
+func (b *RedisBus) Publish(topic string, payload []byte) error {
+	b.publishLock.Lock()
+	defer b.publishLock.Unlock()
+
+	var err error
+
+	// Retry once on a fresh connection in case the previous one went away.
+	for attempt := 0; attempt < 2; attempt++ {
+		if b.publishConn == nil {
+			if err = b.connectPublisher(); err != nil {
+				continue
+			}
+		}
+
+		_ = b.publishConn.SetDeadline(time.Now().Add(redisTimeout))
+		if err = writeCommand(b.publishConn, []byte("PUBLISH"), []byte(redisChannelPrefix+topic), payload); err == nil {
+			if _, err = readReply(b.publishReader); err == nil {
+				return nil
+			}
+		}
+
+		_ = b.publishConn.Close()
+		b.publishConn = nil
+	}
+
+	return err
+}
+
+// Subscribe will call the handler for every message published to the topic.
+func (b *RedisBus) Subscribe(topic string, handler Handler) {
+	b.mu.Lock()
+	isNewTopic := len(b.handlers[topic]) == 0
+	b.handlers[topic] = append(b.handlers[topic], handler)
+	b.mu.Unlock()
+
+	if !isNewTopic {
+		return
+	}
+
+	b.subscribeLock.Lock()
+	defer b.subscribeLock.Unlock()
+
+	// If not connected the topic is subscribed to when the connection is made.
+	if b.subscribeConn != nil {
+		if err := writeCommand(b.subscribeConn, []byte("SUBSCRIBE"), []byte(redisChannelPrefix+topic)); err != nil {
+			log.Warnln("unable to subscribe to", topic, err)
+		}
+	}
+}
+
+// Close will disconnect from the server.
+func (b *RedisBus) Close() error {
+	b.closeOnce.Do(func() {
+		close(b.closed)
+
+		b.publishLock.Lock()
+		if b.publishConn != nil {
+			_ = b.publishConn.Close()
+			b.publishConn = nil
+		}
+		b.publishLock.Unlock()
+
+		b.subscribeLock.Lock()
+		if b.subscribeConn != nil {
+			_ = b.subscribeConn.Close()
+		}
+		b.subscribeLock.Unlock()
+	})
+
+	return nil
+}
+
+// connectPublisher must be called with publishLock held.
+func (b *RedisBus) connectPublisher() error {
+	conn, reader, err := b.dial()
+	if err != nil {
+		return err
+	}
+
+	b.publishConn = conn
+	b.publishReader = reader
+	return nil
+}
+
+func (b *RedisBus) dial() (net.Conn, *bufio.Reader, error) {
+	conn, err := net.DialTimeout("tcp", b.address, redisTimeout)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+
+	if b.password != "" {
+		args := [][]byte{[]byte("AUTH")}
+		if b.username != "" {
+			args = append(args, []byte(b.username))
+		}
+		args = append(args, []byte(b.password))
+
+		_ = conn.SetDeadline(time.Now().Add(redisTimeout))
+		if err := writeCommand(conn, args...); err != nil {
+			_ = conn.Close()
+			return nil, nil, err
+		}
+		if _, err := readReply(reader); err != nil {
+			_ = conn.Close()
+			return nil, nil, err
+		}
+		_ = conn.SetDeadline(time.Time{})
+	}
+
+	return conn, reader, nil
+}
+
+func (b *RedisBus) runSubscriber() {
+	backoff := time.Second
+
+	for {
+		connected, err := b.subscribe()
+
+		select {
+		case <-b.closed:
+			return
+		default:
+		}
+
+		if connected {
+			backoff = time.Second
+		}
+
+		log.Warnln("lost connection to the pub/sub server, reconnecting in", backoff, err)
+		select {
+		case <-b.closed:
+			return
+		case <-time.After(backoff):
+		}
+
+		if backoff < redisMaxRetryBackoff {
+			backoff *= 2
+		}
+	}
+}
+
+// subscribe will subscribe to every topic and dispatch messages until the
+// connection fails. It returns if a connection was made.
+func (b *RedisBus) subscribe() (bool, error) {
+	conn, reader, err := b.dial()
+	if err != nil {
+		return false, err
+	}
+
+	b.subscribeLock.Lock()
+	b.subscribeConn = conn
+	args := [][]byte{[]byte("SUBSCRIBE")}
+	for _, topic := range b.topics() {
+		args = append(args, []byte(redisChannelPrefix+topic))
+	}
+	if len(args) > 1 {
+		err = writeCommand(conn, args...)
+	}
+	b.subscribeLock.Unlock()
+
+	defer func() {
+		b.subscribeLock.Lock()
+		b.subscribeConn = nil
+		b.subscribeLock.Unlock()
+		_ = conn.Close()
+	}()
+
+	if err != nil {
+		return true, err
+	}
+
+	for {
+		reply, err := readReply(reader)
+		if err != nil {
+			return true, err
+		}
+
+		// Messages arrive as ["message", channel, payload]. Subscription
+		// confirmations are ignored.
+		values, ok := reply.([]interface{})
+		if !ok || len(values) != 3 {
+			continue
+		}
+
+		kind, _ := values[0].([]byte)
+		channel, _ := values[1].([]byte)
+		payload, _ := values[2].([]byte)
+		if string(kind) != "message" {
+			continue
+		}
+
+		b.dispatch(strings.TrimPrefix(string(channel), redisChannelPrefix), payload)
+	}
+}
+
+func (b *RedisBus) topics() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	topics := make([]string, 0, len(b.handlers))
+	for topic := range b.handlers {
+		topics = append(topics, topic)
+	}
+	return topics
+}
+
+func (b *RedisBus) dispatch(topic string, payload []byte) {
+	b.mu.RLock()
+	handlers := b.handlers[topic]
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(payload)
+	}
+}
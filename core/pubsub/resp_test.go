@@ -0,0 +1,51 @@
+package pubsub
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCommandRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	payload := []byte("{\"body\":\"line one\r\nline two\"}")
+
+	if err := writeCommand(&buf, []byte("PUBLISH"), []byte("owncast:chat"), payload); err != nil {
+		t.Fatal(err)
+	}
+
+	reply, err := readReply(bufio.NewReader(&buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values, ok := reply.([]interface{})
+	if !ok || len(values) != 3 {
+		t.Fatalf("expected an array of 3 values, got %v", reply)
+	}
+
+	if string(values[0].([]byte)) != "PUBLISH" || string(values[1].([]byte)) != "owncast:chat" || !bytes.Equal(values[2].([]byte), payload) {
+		t.Errorf("unexpected command %q", values)
+	}
+}
+
+func TestReadReply(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("+OK\r\n:3\r\n$-1\r\n-ERR wrong password\r\n"))
+
+	if reply, err := readReply(r); err != nil || string(reply.([]byte)) != "OK" {
+		t.Errorf("expected simple string OK, got %v %v", reply, err)
+	}
+
+	if reply, err := readReply(r); err != nil || reply.(int64) != 3 {
+		t.Errorf("expected integer 3, got %v %v", reply, err)
+	}
+
+	if reply, err := readReply(r); err != nil || reply != nil {
+		t.Errorf("expected nil bulk string, got %v %v", reply, err)
+	}
+
+	if _, err := readReply(r); err == nil {
+		t.Error("expected an error reply to be returned as an error")
+	}
+}
@@ -29,10 +29,13 @@ func setupStats() error {
 		}
 	}()
 
+	setupNodeStats()
+
 	viewerCountPruneTimer := time.NewTicker(5 * time.Second)
 	go func() {
 		for range viewerCountPruneTimer.C {
 			pruneViewerCount()
+			publishNodeStats()
 		}
 	}()
 
@@ -67,8 +70,9 @@ func RemoveChatClient(clientID string) {
 
 // SetViewerActive sets a client as active and connected.
 func SetViewerActive(viewer *models.Viewer) {
-	// Don't update viewer counts if a live stream session is not active.
-	if !_stats.StreamConnected {
+	// Don't update viewer counts if a live stream session is not active on
+	// this or any other node.
+	if remoteOnline, _ := getRemoteNodeStats(); !_stats.StreamConnected && !remoteOnline {
 		return
 	}
 
@@ -85,7 +89,8 @@ func SetViewerActive(viewer *models.Viewer) {
 	} else {
 		_stats.Viewers[viewer.ClientID] = viewer
 	}
-	_stats.SessionMaxViewerCount = int(math.Max(float64(len(_stats.Viewers)), float64(_stats.SessionMaxViewerCount)))
+	_, remoteViewers := getRemoteNodeStats()
+	_stats.SessionMaxViewerCount = int(math.Max(float64(len(_stats.Viewers)+remoteViewers), float64(_stats.SessionMaxViewerCount)))
 	_stats.OverallMaxViewerCount = int(math.Max(float64(_stats.SessionMaxViewerCount), float64(_stats.OverallMaxViewerCount)))
 }
 
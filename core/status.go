@@ -12,13 +12,17 @@ func GetStatus() models.Status {
 		return models.Status{}
 	}
 
+	// Include viewers and the stream state from any other Owncast nodes.
+	remoteOnline, remoteViewers := getRemoteNodeStats()
+	online := IsStreamConnected() || remoteOnline
+
 	viewerCount := 0
-	if IsStreamConnected() {
-		viewerCount = len(_stats.Viewers)
+	if online {
+		viewerCount = len(_stats.Viewers) + remoteViewers
 	}
 
 	return models.Status{
-		Online:                IsStreamConnected(),
+		Online:                online,
 		ViewerCount:           viewerCount,
 		OverallMaxViewerCount: _stats.OverallMaxViewerCount,
 		SessionMaxViewerCount: _stats.SessionMaxViewerCount,
@@ -29,22 +29,31 @@ var _currentBroadcast *models.CurrentBroadcast
 
 var _onlineTimerCancelFunc context.CancelFunc
 
-var _lastNotified *time.Time
+// Set once go-live notifications are sent, and kept when an encoder
+// reconnects so the same broadcast is not announced twice.
+var _goLiveNotified bool
 
 // setStreamAsConnected sets the stream as connected.
 func setStreamAsConnected(rtmpOut *io.PipeReader) {
+	_offlineStateLock.Lock()
 	now := utils.NullTime{Time: time.Now(), Valid: true}
+	previousStreamEnded := _stats.LastDisconnectTime
 	_stats.StreamConnected = true
 	_stats.LastDisconnectTime = nil
 	_stats.LastConnectTime = &now
 	_stats.SessionMaxViewerCount = 0
+	StopOfflineCleanupTimer()
+	_offlineStateLock.Unlock()
+
+	if err := data.AddBroadcast(now.Time); err != nil {
+		log.Errorln("unable to save broadcast", err)
+	}
 
 	_currentBroadcast = &models.CurrentBroadcast{
 		LatencyLevel:   data.GetStreamLatencyLevel(),
 		OutputSettings: data.GetStreamOutputVariants(),
 	}
 
-	StopOfflineCleanupTimer()
 	startOnlineCleanupTimer()
 
 	if _yp != nil {
@@ -75,7 +84,7 @@ func setStreamAsConnected(rtmpOut *io.PipeReader) {
 	chat.SendAllWelcomeMessage()
 
 	// Send delayed notification messages.
-	_onlineTimerCancelFunc = startLiveStreamNotificationsTimer()
+	_onlineTimerCancelFunc = startLiveStreamNotificationsTimer(previousStreamEnded)
 }
 
 // SetStreamAsDisconnected sets the stream as disconnected.
@@ -87,14 +96,21 @@ func SetStreamAsDisconnected() {
 		_onlineTimerCancelFunc()
 	}
 
+	_offlineStateLock.Lock()
+	defer _offlineStateLock.Unlock()
+
 	_stats.StreamConnected = false
 	_stats.LastDisconnectTime = &now
 	_stats.LastConnectTime = nil
 	_broadcaster = nil
 
+	if err := data.SetBroadcastEnded(now.Time); err != nil {
+		log.Errorln("unable to save broadcast end", err)
+	}
+
 	offlineFilename := "offline.ts"
 
-	offlineFilePath, err := saveOfflineClipToDisk(offlineFilename)
+	offlineFilePath, offlineDuration, err := saveOfflineClipToDisk(offlineFilename)
 	if err != nil {
 		log.Errorln(err)
 		return
@@ -112,13 +128,15 @@ func SetStreamAsDisconnected() {
 	// Just transition to offline.
 	if _currentBroadcast == nil {
 		stopOnlineCleanupTimer()
-		transitionToOfflineVideoStreamContent()
+		if err := transitionToOfflineVideoStreamContent(); err != nil {
+			log.Errorln(err)
+		}
 		log.Errorln("unexpected nil _currentBroadcast")
 		return
 	}
 
 	for index := range _currentBroadcast.OutputSettings {
-		makeVariantIndexOffline(index, offlineFilePath, offlineFilename)
+		makeVariantIndexOffline(index, offlineFilePath, offlineFilename, offlineDuration)
 	}
 
 	StartOfflineCleanupTimer()
@@ -126,6 +144,17 @@ func SetStreamAsDisconnected() {
 	saveStats()
 
 	go webhooks.SendStreamStatusEvent(models.StreamStopped)
+	go notifyStreamEnded()
+}
+
+func notifyStreamEnded() {
+	notifier, err := notifications.New(data.GetDatastore())
+	if err != nil {
+		log.Errorln(err)
+		return
+	}
+
+	notifier.NotifyStreamEnded()
 }
 
 // StartOfflineCleanupTimer will fire a cleanup after n minutes being disconnected.
@@ -134,8 +163,14 @@ func StartOfflineCleanupTimer() {
 	go func() {
 		for range _offlineCleanupTimer.C {
 			// Set video to offline state
-			resetDirectories()
-			transitionToOfflineVideoStreamContent()
+			_offlineStateLock.Lock()
+			if !_stats.StreamConnected {
+				resetDirectories()
+				if err := transitionToOfflineVideoStreamContent(); err != nil {
+					log.Errorln(err)
+				}
+			}
+			_offlineStateLock.Unlock()
 		}
 	}()
 }
@@ -162,17 +197,19 @@ func stopOnlineCleanupTimer() {
 	}
 }
 
-func startLiveStreamNotificationsTimer() context.CancelFunc {
+func startLiveStreamNotificationsTimer(previousStreamEnded *utils.NullTime) context.CancelFunc {
+	if _goLiveNotified && previousStreamEnded != nil && previousStreamEnded.Valid && notifications.IsReconnect(previousStreamEnded.Time) {
+		log.Traceln("Not sending go live notifications, the previous stream ended at", previousStreamEnded.Time)
+		return func() {}
+	}
+	_goLiveNotified = false
+
 	// Send delayed notification messages.
 	c, cancelFunc := context.WithCancel(context.Background())
 	_onlineTimerCancelFunc = cancelFunc
 	go func(c context.Context) {
 		select {
 		case <-time.After(time.Minute * 2.0):
-			if _lastNotified != nil && time.Since(*_lastNotified) < 10*time.Minute {
-				return
-			}
-
 			// Send Fediverse message.
 			if data.GetFederationEnabled() {
 				log.Traceln("Sending Federated Go Live message.")
@@ -188,8 +225,7 @@ func startLiveStreamNotificationsTimer() context.CancelFunc {
 				notifier.Notify()
 			}
 
-			now := time.Now()
-			_lastNotified = &now
+			_goLiveNotified = true
 		case <-c.Done():
 		}
 	}(c)
@@ -13,6 +13,7 @@ import (
 
 	"github.com/owncast/owncast/config"
 	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/webhooks"
 	"github.com/owncast/owncast/logging"
 	"github.com/owncast/owncast/models"
 	"github.com/owncast/owncast/utils"
@@ -151,6 +152,7 @@ func (t *Transcoder) Start() {
 
 	if err != nil {
 		log.Errorln("transcoding error. look at ", logging.GetTranscoderLogFilePath(), " to help debug. your copy of ffmpeg may not support your selected codec of", t.codec.Name(), "https://owncast.online/docs/codecs/")
+		webhooks.SendTranscoderErrorEvent(fmt.Sprintf("the video transcoder using %s stopped unexpectedly: %s", t.codec.Name(), err))
 	}
 }
 
@@ -9,6 +9,7 @@ import (
 
 	"github.com/owncast/owncast/config"
 	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/webhooks"
 	"github.com/owncast/owncast/utils"
 	log "github.com/sirupsen/logrus"
 )
@@ -73,9 +74,11 @@ func handleTranscoderMessage(message string) {
 	}
 
 	// Convert specific transcoding messages to human-readable messages.
+	isKnownError := false
 	for error, displayMessage := range errorMap {
 		if strings.Contains(message, error) {
 			message = displayMessage
+			isKnownError = true
 			break
 		}
 	}
@@ -91,6 +94,11 @@ func handleTranscoderMessage(message string) {
 
 	log.Error(message)
 
+	// Only errors we recognize are worth telling webhooks about.
+	if isKnownError {
+		webhooks.SendTranscoderErrorEvent(message)
+	}
+
 	_lastTranscoderLogMessage = message
 }
 
@@ -3,6 +3,7 @@ package user
 import (
 	"context"
 	"database/sql"
+	"net"
 	"strings"
 	"time"
 
@@ -24,6 +25,11 @@ type ExternalAPIUser struct {
 	Type         string     `json:"type,omitempty"` // Should be API
 	LastUsedAt   *time.Time `json:"lastUsedAt,omitempty"`
 	IsBot        bool       `json:"isBot"`
+	// ExpiresAt is when the access token stops working, if ever.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	// AllowedIPs limits the addresses or CIDR ranges the access token can
+	// be used from. Empty allows any address.
+	AllowedIPs []string `json:"allowedIPs,omitempty"`
 }
 
 const (
@@ -32,7 +38,23 @@ const (
 	// ScopeCanSendSystemMessages will allow sending chat messages as the system.
 	ScopeCanSendSystemMessages = "CAN_SEND_SYSTEM_MESSAGES"
 	// ScopeHasAdminAccess will allow performing administrative actions on the server.
+	// It includes every scope in adminAccessScopes.
 	ScopeHasAdminAccess = "HAS_ADMIN_ACCESS"
+	// ScopeCanReadChat will allow reading chat messages and polls.
+	ScopeCanReadChat = "CAN_READ_CHAT"
+	// ScopeCanModerateChat will allow hiding chat messages and managing chat users.
+	ScopeCanModerateChat = "CAN_MODERATE_CHAT"
+	// ScopeCanManageStream will allow changing the stream title and other stream metadata.
+	ScopeCanManageStream = "CAN_MANAGE_STREAM"
+	// ScopeCanReadMetrics will allow reading viewer and connected client details.
+	ScopeCanReadMetrics = "CAN_READ_METRICS"
+	// ScopeCanManageWebhooks will allow creating and removing webhooks.
+	ScopeCanManageWebhooks = "CAN_MANAGE_WEBHOOKS"
+	// ScopeCanManageFollowers will allow approving and removing fediverse followers.
+	ScopeCanManageFollowers = "CAN_MANAGE_FOLLOWERS"
+	// ScopeCanTriggerActions will allow triggering server actions such as
+	// announcements, polls and disconnecting the broadcaster.
+	ScopeCanTriggerActions = "CAN_TRIGGER_ACTIONS"
 )
 
 // For a scope to be seen as "valid" it must live in this slice.
@@ -40,10 +62,29 @@ var validAccessTokenScopes = []string{
 	ScopeCanSendChatMessages,
 	ScopeCanSendSystemMessages,
 	ScopeHasAdminAccess,
+	ScopeCanReadChat,
+	ScopeCanModerateChat,
+	ScopeCanManageStream,
+	ScopeCanReadMetrics,
+	ScopeCanManageWebhooks,
+	ScopeCanManageFollowers,
+	ScopeCanTriggerActions,
+}
+
+// Tokens created with admin access before the finer grained scopes existed
+// keep access to everything those scopes cover.
+var adminAccessScopes = []string{
+	ScopeCanReadChat,
+	ScopeCanModerateChat,
+	ScopeCanManageStream,
+	ScopeCanReadMetrics,
+	ScopeCanManageWebhooks,
+	ScopeCanManageFollowers,
+	ScopeCanTriggerActions,
 }
 
 // InsertExternalAPIUser will add a new API user to the database.
-func InsertExternalAPIUser(token string, name string, color int, scopes []string) error {
+func InsertExternalAPIUser(token string, name string, color int, scopes []string, expiresAt *time.Time, allowedIPs []string) error {
 	log.Traceln("Adding new API user")
 
 	_datastore.DbLock.Lock()
@@ -70,13 +111,32 @@ func InsertExternalAPIUser(token string, name string, color int, scopes []string
 		return err
 	}
 
-	if err := addAccessTokenForUser(token, id); err != nil {
+	if _, err := _datastore.DB.Exec("INSERT INTO user_access_tokens(token, user_id, expires_at, allowed_ips) VALUES(?, ?, ?, ?)", token, id, expiresAt, strings.Join(allowedIPs, ",")); err != nil {
 		return errors.Wrap(err, "unable to save access token for new external api user")
 	}
 
 	return nil
 }
 
+// RotateExternalAPIUserAccessToken will replace the access token of an API
+// user, keeping its scopes, expiry and address restrictions. The old token
+// stops working immediately.
+func RotateExternalAPIUserAccessToken(token string, newToken string) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	result, err := _datastore.DB.Exec("UPDATE user_access_tokens SET token = ?, timestamp = CURRENT_TIMESTAMP WHERE token = ? AND user_id IN (SELECT id FROM users WHERE type IS 'API' AND disabled_at IS NULL)", newToken, token)
+	if err != nil {
+		return err
+	}
+
+	if rowsUpdated, _ := result.RowsAffected(); rowsUpdated == 0 {
+		return errors.New(token + " not found")
+	}
+
+	return nil
+}
+
 // DeleteExternalAPIUser will delete a token from the database.
 func DeleteExternalAPIUser(token string) error {
 	log.Traceln("Deleting access token")
@@ -113,29 +173,76 @@ func DeleteExternalAPIUser(token string) error {
 
 // GetExternalAPIUserForAccessTokenAndScope will determine if a specific token has access to perform a scoped action.
 func GetExternalAPIUserForAccessTokenAndScope(token string, scope string) (*ExternalAPIUser, error) {
-	// This will split the scopes from comma separated to individual rows
-	// so we can efficiently find if a token supports a single scope.
-	// This is SQLite specific, so if we ever support other database
-	// backends we need to support other methods.
-	query := `SELECT id, scopes, display_name, display_color, created_at, last_used FROM user_access_tokens, (
-		WITH RECURSIVE split(id, scopes, display_name, display_color, created_at, last_used, disabled_at, scope, rest) AS (
-		  SELECT id, scopes, display_name, display_color, created_at, last_used, disabled_at, '', scopes || ',' FROM users
-		   UNION ALL
-		  SELECT id, scopes, display_name, display_color, created_at, last_used, disabled_at,
-				 substr(rest, 0, instr(rest, ',')),
-				 substr(rest, instr(rest, ',')+1)
-			FROM split
-		   WHERE rest <> '')
-		SELECT id, scopes, display_name, display_color, created_at, last_used, disabled_at, scope
-		  FROM split
-		 WHERE scope <> ''
-		 ORDER BY scope
-	  ) AS token WHERE user_access_tokens.token = ? AND token.scope = ?`
-
-	row := _datastore.DB.QueryRow(query, token, scope)
+	query := `SELECT id, scopes, display_name, display_color, created_at, last_used, expires_at, allowed_ips FROM users, user_access_tokens
+		WHERE user_access_tokens.token = ? AND user_access_tokens.user_id = users.id AND type IS 'API' AND disabled_at IS NULL`
+
+	row := _datastore.DB.QueryRow(query, token)
 	integration, err := makeExternalAPIUserFromRow(row)
+	if err != nil {
+		return nil, err
+	}
+
+	if !integration.HasScope(scope) {
+		return nil, errors.New("access token does not have the " + scope + " scope")
+	}
+
+	return integration, nil
+}
+
+// HasScope will determine if an API user has been granted a scope, either
+// directly or through admin access.
+func (u *ExternalAPIUser) HasScope(scope string) bool {
+	if _, granted := utils.FindInSlice(u.Scopes, scope); granted {
+		return true
+	}
+
+	if _, isAdminScope := utils.FindInSlice(adminAccessScopes, scope); isAdminScope {
+		_, hasAdminAccess := utils.FindInSlice(u.Scopes, ScopeHasAdminAccess)
+		return hasAdminAccess
+	}
+
+	return false
+}
 
-	return integration, err
+// IsExpired will determine if the API user's access token has expired.
+func (u *ExternalAPIUser) IsExpired() bool {
+	return u.ExpiresAt != nil && !time.Now().Before(*u.ExpiresAt)
+}
+
+// IsAllowedIPAddress will determine if the API user's access token can be
+// used from an address.
+func (u *ExternalAPIUser) IsAllowedIPAddress(address string) bool {
+	if len(u.AllowedIPs) == 0 {
+		return true
+	}
+
+	ip := net.ParseIP(strings.TrimSpace(address))
+	if ip == nil {
+		return false
+	}
+
+	for _, allowed := range u.AllowedIPs {
+		if _, network, err := net.ParseCIDR(allowed); err == nil {
+			if network.Contains(ip) {
+				return true
+			}
+		} else if allowedIP := net.ParseIP(allowed); allowedIP != nil && allowedIP.Equal(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasValidAllowedIPs will verify that all the addresses provided are either
+// an IP address or a CIDR range.
+func HasValidAllowedIPs(allowedIPs []string) bool {
+	for _, allowed := range allowedIPs {
+		if _, _, err := net.ParseCIDR(allowed); err != nil && net.ParseIP(allowed) == nil {
+			return false
+		}
+	}
+	return true
 }
 
 // GetIntegrationNameForAccessToken will return the integration name associated with a specific access token.
@@ -151,7 +258,7 @@ func GetIntegrationNameForAccessToken(token string) *string {
 // GetExternalAPIUser will return all API users with access tokens.
 func GetExternalAPIUser() ([]ExternalAPIUser, error) { //nolint
 	// Get all messages sent within the past day
-	query := "SELECT id, token, display_name, display_color, scopes, created_at, last_used FROM users, user_access_tokens WHERE user_access_tokens.user_id = id  AND type IS 'API' AND disabled_at IS NULL"
+	query := "SELECT id, token, display_name, display_color, scopes, created_at, last_used, expires_at, allowed_ips FROM users, user_access_tokens WHERE user_access_tokens.user_id = id  AND type IS 'API' AND disabled_at IS NULL"
 
 	rows, err := _datastore.DB.Query(query)
 	if err != nil {
@@ -164,6 +271,22 @@ func GetExternalAPIUser() ([]ExternalAPIUser, error) { //nolint
 	return integrations, err
 }
 
+// GetExternalAPIUserByID will return a single enabled API user.
+func GetExternalAPIUserByID(id string) (*ExternalAPIUser, error) {
+	integrations, err := GetExternalAPIUser()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range integrations {
+		if integrations[i].ID == id {
+			return &integrations[i], nil
+		}
+	}
+
+	return nil, errors.New("integration not found")
+}
+
 // SetExternalAPIUserAccessTokenAsUsed will update the last used timestamp for a token.
 func SetExternalAPIUserAccessTokenAsUsed(token string) error {
 	tx, err := _datastore.DB.Begin()
@@ -195,8 +318,10 @@ func makeExternalAPIUserFromRow(row *sql.Row) (*ExternalAPIUser, error) {
 	var scopes string
 	var createdAt time.Time
 	var lastUsedAt *time.Time
+	var expiresAt *time.Time
+	var allowedIPs *string
 
-	err := row.Scan(&id, &scopes, &displayName, &displayColor, &createdAt, &lastUsedAt)
+	err := row.Scan(&id, &scopes, &displayName, &displayColor, &createdAt, &lastUsedAt, &expiresAt, &allowedIPs)
 	if err != nil {
 		log.Debugln("unable to convert row to api user", err)
 		return nil, err
@@ -209,6 +334,8 @@ func makeExternalAPIUserFromRow(row *sql.Row) (*ExternalAPIUser, error) {
 		CreatedAt:    createdAt,
 		Scopes:       strings.Split(scopes, ","),
 		LastUsedAt:   lastUsedAt,
+		ExpiresAt:    expiresAt,
+		AllowedIPs:   splitAllowedIPs(allowedIPs),
 	}
 
 	return &integration, nil
@@ -225,8 +352,10 @@ func makeExternalAPIUsersFromRows(rows *sql.Rows) ([]ExternalAPIUser, error) {
 		var scopes string
 		var createdAt time.Time
 		var lastUsedAt *time.Time
+		var expiresAt *time.Time
+		var allowedIPs *string
 
-		err := rows.Scan(&id, &accessToken, &displayName, &displayColor, &scopes, &createdAt, &lastUsedAt)
+		err := rows.Scan(&id, &accessToken, &displayName, &displayColor, &scopes, &createdAt, &lastUsedAt, &expiresAt, &allowedIPs)
 		if err != nil {
 			log.Errorln(err)
 			return nil, err
@@ -241,6 +370,8 @@ func makeExternalAPIUsersFromRows(rows *sql.Rows) ([]ExternalAPIUser, error) {
 			Scopes:       strings.Split(scopes, ","),
 			LastUsedAt:   lastUsedAt,
 			IsBot:        true,
+			ExpiresAt:    expiresAt,
+			AllowedIPs:   splitAllowedIPs(allowedIPs),
 		}
 		integrations = append(integrations, integration)
 	}
@@ -248,6 +379,13 @@ func makeExternalAPIUsersFromRows(rows *sql.Rows) ([]ExternalAPIUser, error) {
 	return integrations, nil
 }
 
+func splitAllowedIPs(allowedIPs *string) []string {
+	if allowedIPs == nil || *allowedIPs == "" {
+		return nil
+	}
+	return strings.Split(*allowedIPs, ",")
+}
+
 // HasValidScopes will verify that all the scopes provided are valid.
 func HasValidScopes(scopes []string) bool {
 	for _, scope := range scopes {
@@ -0,0 +1,95 @@
+package user
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/core/data"
+)
+
+func TestMain(m *testing.M) {
+	dbFile, err := os.CreateTemp(os.TempDir(), "owncast-test-db.db")
+	if err != nil {
+		panic(err)
+	}
+
+	data.SetupPersistence(dbFile.Name())
+	SetupUsers()
+
+	os.Exit(m.Run())
+}
+
+func TestExternalAPIUserScopes(t *testing.T) {
+	if err := InsertExternalAPIUser("metrics-token", "dashboard", 1, []string{ScopeCanReadMetrics}, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if integration, err := GetExternalAPIUserForAccessTokenAndScope("metrics-token", ScopeCanReadMetrics); err != nil || integration.DisplayName != "dashboard" {
+		t.Error("expected the token to have the scope it was created with", err)
+	}
+
+	if _, err := GetExternalAPIUserForAccessTokenAndScope("metrics-token", ScopeCanModerateChat); err == nil {
+		t.Error("expected the token to not have a scope it was not created with")
+	}
+
+	if err := InsertExternalAPIUser("admin-token", "admin", 1, []string{ScopeHasAdminAccess}, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := GetExternalAPIUserForAccessTokenAndScope("admin-token", ScopeCanModerateChat); err != nil {
+		t.Error("expected admin access to include moderation", err)
+	}
+
+	if _, err := GetExternalAPIUserForAccessTokenAndScope("admin-token", ScopeCanSendChatMessages); err == nil {
+		t.Error("expected admin access to not include sending chat messages")
+	}
+}
+
+func TestRotateExternalAPIUserAccessToken(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	if err := InsertExternalAPIUser("old-token", "bot", 1, []string{ScopeCanReadChat}, &expiresAt, []string{"10.0.0.0/8"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RotateExternalAPIUserAccessToken("old-token", "new-token"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := GetExternalAPIUserForAccessTokenAndScope("old-token", ScopeCanReadChat); err == nil {
+		t.Error("expected the old token to stop working")
+	}
+
+	integration, err := GetExternalAPIUserForAccessTokenAndScope("new-token", ScopeCanReadChat)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if integration.ExpiresAt == nil || integration.IsExpired() {
+		t.Error("expected the new token to keep the expiry", integration.ExpiresAt)
+	}
+
+	if !integration.IsAllowedIPAddress("10.1.2.3") || integration.IsAllowedIPAddress("192.168.1.1") {
+		t.Error("expected the new token to keep the allowed addresses", integration.AllowedIPs)
+	}
+}
+
+func TestIsAllowedIPAddress(t *testing.T) {
+	integration := ExternalAPIUser{AllowedIPs: []string{"192.168.1.0/24", "2001:db8::1"}}
+
+	for address, allowed := range map[string]bool{
+		"192.168.1.20": true,
+		"192.168.2.20": false,
+		"2001:db8::1":  true,
+		"2001:db8::2":  false,
+		"not an ip":    false,
+	} {
+		if integration.IsAllowedIPAddress(address) != allowed {
+			t.Errorf("expected %s allowed to be %t", address, allowed)
+		}
+	}
+
+	if !(&ExternalAPIUser{}).IsAllowedIPAddress("203.0.113.1") {
+		t.Error("expected a token without allowed addresses to be usable from anywhere")
+	}
+}
@@ -0,0 +1,132 @@
+package user
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// SeenIPAddress is an IP address a chat user has connected from.
+type SeenIPAddress struct {
+	IPAddress string    `json:"ipAddress"`
+	LastSeen  time.Time `json:"lastSeen"`
+}
+
+// BanEvasionSuspect is a new chat user that connected from an IP address
+// recently used by a disabled user.
+type BanEvasionSuspect struct {
+	User         *User     `json:"user"`
+	DisabledUser *User     `json:"disabledUser"`
+	IPAddress    string    `json:"ipAddress"`
+	LastSeen     time.Time `json:"lastSeen"`
+}
+
+// SetIPAddressSeen will record that a user has connected from an IP address.
+func SetIPAddressSeen(userID string, address string) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	_, err := _datastore.DB.Exec("INSERT OR REPLACE INTO user_ip_addresses(user_id, ip_address, last_seen) VALUES(?, ?, ?)", userID, address, time.Now())
+	return errors.Wrap(err, "unable to save user ip address")
+}
+
+// GetIPAddressesForUser will return the IP addresses a user has been seen
+// connecting from.
+func GetIPAddressesForUser(userID string) ([]SeenIPAddress, error) {
+	rows, err := _datastore.DB.Query("SELECT ip_address, last_seen FROM user_ip_addresses WHERE user_id = ? ORDER BY last_seen DESC", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	addresses := make([]SeenIPAddress, 0)
+	for rows.Next() {
+		var address SeenIPAddress
+		if err := rows.Scan(&address.IPAddress, &address.LastSeen); err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, address)
+	}
+
+	return addresses, rows.Err()
+}
+
+// GetDisabledUsersSeenFromIPAddress will return the disabled users that have
+// connected from an IP address since a point in time.
+func GetDisabledUsersSeenFromIPAddress(address string, since time.Time) []*User {
+	query := "SELECT id, display_name, scopes, display_color, created_at, disabled_at, previous_names, namechanged_at FROM users WHERE disabled_at IS NOT NULL AND id IN (SELECT user_id FROM user_ip_addresses WHERE ip_address = ? AND last_seen >= ?)"
+
+	rows, err := _datastore.DB.Query(query, address, since)
+	if err != nil {
+		log.Errorln(err)
+		return nil
+	}
+	defer rows.Close()
+
+	return getUsersFromRows(rows)
+}
+
+// GetBanEvasionSuspects will return the enabled, unauthenticated users
+// created since a point in time that share an IP address with a disabled
+// user seen since another point in time.
+func GetBanEvasionSuspects(createdSince time.Time, disabledUserSeenSince time.Time) ([]BanEvasionSuspect, error) {
+	query := `SELECT suspect.user_id, disabled.user_id, suspect.ip_address, suspect.last_seen
+		FROM user_ip_addresses AS suspect
+		INNER JOIN user_ip_addresses AS disabled ON disabled.ip_address = suspect.ip_address AND disabled.user_id != suspect.user_id
+		INNER JOIN users AS suspect_user ON suspect_user.id = suspect.user_id
+		INNER JOIN users AS disabled_user ON disabled_user.id = disabled.user_id
+		WHERE suspect_user.disabled_at IS NULL AND suspect_user.authenticated_at IS NULL
+		AND disabled_user.disabled_at IS NOT NULL AND disabled.last_seen >= ?
+		ORDER BY suspect.last_seen DESC`
+
+	rows, err := _datastore.DB.Query(query, disabledUserSeenSince)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type match struct {
+		userID, disabledUserID, address string
+		lastSeen                        time.Time
+	}
+	var matches []match
+	for rows.Next() {
+		var m match
+		if err := rows.Scan(&m.userID, &m.disabledUserID, &m.address, &m.lastSeen); err != nil {
+			return nil, err
+		}
+		matches = append(matches, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	suspects := make([]BanEvasionSuspect, 0)
+	for _, m := range matches {
+		suspect := GetUserByID(m.userID)
+		disabledUser := GetUserByID(m.disabledUserID)
+		if suspect == nil || disabledUser == nil || suspect.IsModerator() || suspect.CreatedAt.Before(createdSince) {
+			continue
+		}
+
+		suspects = append(suspects, BanEvasionSuspect{
+			User:         suspect,
+			DisabledUser: disabledUser,
+			IPAddress:    m.address,
+			LastSeen:     m.lastSeen,
+		})
+	}
+
+	return suspects, nil
+}
+
+// RemoveIPAddressesSeenBefore will forget the IP addresses that users have
+// not connected from since a point in time.
+func RemoveIPAddressesSeenBefore(before time.Time) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	_, err := _datastore.DB.Exec("DELETE FROM user_ip_addresses WHERE last_seen < ?", before)
+	return err
+}
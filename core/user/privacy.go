@@ -0,0 +1,127 @@
+package user
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// The name shown on the messages of users who have deleted their data.
+const deletedUserDisplayName = "Deleted user"
+
+// GetAccessTokenCreationTimes will return when each of the access tokens
+// belonging to a user were created. The tokens themselves are secret.
+func GetAccessTokenCreationTimes(userID string) ([]time.Time, error) {
+	rows, err := _datastore.DB.Query("SELECT timestamp FROM user_access_tokens WHERE user_id = ? ORDER BY timestamp", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	times := []time.Time{}
+	for rows.Next() {
+		var t time.Time
+		if err := rows.Scan(&t); err != nil {
+			return nil, err
+		}
+		times = append(times, t)
+	}
+
+	return times, rows.Err()
+}
+
+// DeleteUserData will remove the access tokens, profile, roles, poll votes
+// and reactions of a user and anonymize them so the messages they sent can
+// no longer be tied to them. The user is kept so their messages remain in the chat history.
+func DeleteUserData(userID string) error {
+	if GetUserByID(userID) == nil {
+		return errors.New("user not found")
+	}
+
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	tx, err := _datastore.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	for _, query := range []string{
+		"DELETE FROM user_access_tokens WHERE user_id = ?",
+		"DELETE FROM user_profiles WHERE user_id = ?",
+		"DELETE FROM user_roles WHERE user_id = ?",
+		"DELETE FROM user_ip_addresses WHERE user_id = ?",
+		"DELETE FROM poll_votes WHERE user_id = ?",
+		"UPDATE users SET display_name = '" + deletedUserDisplayName + "', display_color = 0, previous_names = '', namechanged_at = NULL, scopes = NULL, authenticated_at = NULL WHERE id = ?",
+	} {
+		if _, err := tx.Exec(query, userID); err != nil {
+			return errors.Wrap(err, "unable to delete user data")
+		}
+	}
+
+	if err := removeReactions(tx, userID); err != nil {
+		return errors.Wrap(err, "unable to delete user reactions")
+	}
+
+	return tx.Commit()
+}
+
+// removeReactions will remove a user from the reactions saved with chat
+// messages, which list the IDs of the users who reacted with each emoji.
+func removeReactions(tx *sql.Tx, userID string) error {
+	rows, err := tx.Query("SELECT id, reactions FROM messages WHERE reactions LIKE ?", "%\""+userID+"\"%")
+	if err != nil {
+		return err
+	}
+
+	updated := map[string]string{}
+	for rows.Next() {
+		var messageID, reactionsJSON string
+		if err := rows.Scan(&messageID, &reactionsJSON); err != nil {
+			rows.Close()
+			return err
+		}
+
+		reactions := map[string][]string{}
+		if err := json.Unmarshal([]byte(reactionsJSON), &reactions); err != nil {
+			continue
+		}
+
+		for emoji, users := range reactions {
+			remaining := users[:0]
+			for _, user := range users {
+				if user != userID {
+					remaining = append(remaining, user)
+				}
+			}
+
+			if len(remaining) == 0 {
+				delete(reactions, emoji)
+			} else {
+				reactions[emoji] = remaining
+			}
+		}
+
+		reactionsWithoutUser, err := json.Marshal(reactions)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		updated[messageID] = string(reactionsWithoutUser)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for messageID, reactions := range updated {
+		if _, err := tx.Exec("UPDATE messages SET reactions = ? WHERE id = ?", reactions, messageID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
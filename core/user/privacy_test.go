@@ -0,0 +1,63 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/owncast/owncast/core/data"
+)
+
+func TestDeleteUserData(t *testing.T) {
+	data.CreateMessagesTable(_datastore.DB)
+	data.CreatePollsTables(_datastore.DB)
+
+	u, token, err := CreateAnonymousUser("privacy-tester")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := data.SavePollVote("poll", u.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	reactions := `{"👍":["` + u.ID + `","someone-else"],"🎉":["` + u.ID + `"]}`
+	if _, err := _datastore.DB.Exec("INSERT INTO messages(id, body, reactions) VALUES ('reacted', 'hello', ?)", reactions); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetProfile(u.ID, Profile{Bio: "hello"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := DeleteUserData(u.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if GetUserByToken(token) != nil {
+		t.Error("expected the access token to be removed")
+	}
+
+	deleted := GetUserByID(u.ID)
+	if deleted == nil {
+		t.Fatal("expected the user to be kept for their anonymized messages")
+	}
+
+	if deleted.DisplayName != deletedUserDisplayName || len(deleted.PreviousNames) != 1 || deleted.PreviousNames[0] != "" {
+		t.Error("expected the user to be anonymized", deleted.DisplayName, deleted.PreviousNames)
+	}
+
+	if profile, err := GetProfile(u.ID); err != nil || profile.Bio != "" {
+		t.Error("expected the profile to be removed", err)
+	}
+
+	if votes, err := data.GetPollVotesForUser(u.ID); err != nil || len(votes) != 0 {
+		t.Error("expected the poll votes to be removed", votes, err)
+	}
+
+	var remaining string
+	if err := _datastore.DB.QueryRow("SELECT reactions FROM messages WHERE id = 'reacted'").Scan(&remaining); err != nil {
+		t.Fatal(err)
+	}
+	if remaining != `{"👍":["someone-else"]}` {
+		t.Error("expected the reactions to be removed", remaining)
+	}
+}
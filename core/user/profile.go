@@ -0,0 +1,124 @@
+package user
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/url"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	maxProfileBioLength       = 500
+	maxProfilePronounsLength  = 32
+	maxProfileURLLength       = 512
+	maxProfileLinks           = 5
+	maxProfileLinkTitleLength = 64
+)
+
+// Profile is the optional public information a chat user shares about
+// themselves.
+type Profile struct {
+	Bio      string        `json:"bio,omitempty"`
+	Avatar   string        `json:"avatar,omitempty"`
+	Pronouns string        `json:"pronouns,omitempty"`
+	Links    []ProfileLink `json:"links,omitempty"`
+}
+
+// ProfileLink is a single link on a user's profile.
+type ProfileLink struct {
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+// Validate will verify the profile can be saved.
+func (p Profile) Validate() error {
+	if utf8.RuneCountInString(p.Bio) > maxProfileBioLength {
+		return errors.Errorf("bio must be %d characters or less", maxProfileBioLength)
+	}
+
+	if utf8.RuneCountInString(p.Pronouns) > maxProfilePronounsLength {
+		return errors.Errorf("pronouns must be %d characters or less", maxProfilePronounsLength)
+	}
+
+	if p.Avatar != "" && !isValidProfileURL(p.Avatar) {
+		return errors.New("avatar must be an http or https url")
+	}
+
+	if len(p.Links) > maxProfileLinks {
+		return errors.Errorf("a profile can have at most %d links", maxProfileLinks)
+	}
+
+	for _, link := range p.Links {
+		if !isValidProfileURL(link.URL) {
+			return errors.Errorf("%s is not an http or https url", link.URL)
+		}
+		if utf8.RuneCountInString(link.Title) > maxProfileLinkTitleLength {
+			return errors.Errorf("link titles must be %d characters or less", maxProfileLinkTitleLength)
+		}
+	}
+
+	return nil
+}
+
+func isValidProfileURL(value string) bool {
+	if len(value) > maxProfileURLLength {
+		return false
+	}
+
+	u, err := url.Parse(value)
+	if err != nil {
+		return false
+	}
+
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// GetProfile will return the profile of a single user. Users who have not
+// filled in a profile have an empty one.
+func GetProfile(userID string) (*Profile, error) {
+	var bio, avatar, pronouns, links sql.NullString
+
+	row := _datastore.DB.QueryRow("SELECT bio, avatar, pronouns, links FROM user_profiles WHERE user_id = ?", userID)
+	if err := row.Scan(&bio, &avatar, &pronouns, &links); err == sql.ErrNoRows {
+		return &Profile{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	profile := Profile{
+		Bio:      bio.String,
+		Avatar:   avatar.String,
+		Pronouns: pronouns.String,
+	}
+
+	if links.String != "" {
+		if err := json.Unmarshal([]byte(links.String), &profile.Links); err != nil {
+			return nil, errors.Wrap(err, "unable to read profile links")
+		}
+	}
+
+	return &profile, nil
+}
+
+// SetProfile will save the profile of a single user.
+func SetProfile(userID string, profile Profile) error {
+	if err := profile.Validate(); err != nil {
+		return err
+	}
+
+	links, err := json.Marshal(profile.Links)
+	if err != nil {
+		return err
+	}
+
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	_, err = _datastore.DB.Exec(`INSERT INTO user_profiles(user_id, bio, avatar, pronouns, links, updated_at) VALUES(?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(user_id) DO UPDATE SET bio = excluded.bio, avatar = excluded.avatar, pronouns = excluded.pronouns, links = excluded.links, updated_at = excluded.updated_at`,
+		userID, profile.Bio, profile.Avatar, profile.Pronouns, string(links))
+
+	return errors.Wrap(err, "unable to save profile")
+}
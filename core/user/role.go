@@ -0,0 +1,353 @@
+package user
+
+import (
+	"database/sql"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/owncast/owncast/utils"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/teris-io/shortid"
+)
+
+const (
+	// RolePermissionBypassSlowMode allows sending messages without waiting
+	// for the chat slow mode delay.
+	RolePermissionBypassSlowMode = "BYPASS_SLOW_MODE"
+	// RolePermissionPostLinks allows posting links when links are restricted.
+	RolePermissionPostLinks = "POST_LINKS"
+	// RolePermissionUseRestrictedEmoji allows using custom emoji that have
+	// been restricted.
+	RolePermissionUseRestrictedEmoji = "USE_RESTRICTED_EMOJI"
+)
+
+const maxRoleNameLength = 32
+
+var validRolePermissions = []string{
+	RolePermissionBypassSlowMode,
+	RolePermissionPostLinks,
+	RolePermissionUseRestrictedEmoji,
+}
+
+var roleColorRegex = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// ErrRoleNotFound is returned when a role does not exist.
+var ErrRoleNotFound = errors.New("role not found")
+
+// Role is an admin defined group of chat users with a badge and a set of
+// permissions.
+type Role struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Color       string    `json:"color,omitempty"`
+	Badge       string    `json:"badge,omitempty"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// HasPermission will return if the role grants a single permission.
+func (r Role) HasPermission(permission string) bool {
+	_, hasPermission := utils.FindInSlice(r.Permissions, permission)
+	return hasPermission
+}
+
+// Validate will verify the role can be saved.
+func (r Role) Validate() error {
+	name := strings.TrimSpace(r.Name)
+	if name == "" {
+		return errors.New("a role must have a name")
+	}
+
+	if utf8.RuneCountInString(name) > maxRoleNameLength {
+		return errors.Errorf("role names must be %d characters or less", maxRoleNameLength)
+	}
+
+	if strings.EqualFold(name, moderatorScopeKey) {
+		return errors.Errorf("%s is a reserved role name", name)
+	}
+
+	if r.Color != "" && !roleColorRegex.MatchString(r.Color) {
+		return errors.New("role color must be a hex color such as #ff0000")
+	}
+
+	if r.Badge != "" && !isValidBadgeURL(r.Badge) {
+		return errors.New("role badge must be an http or https url or a path on this server")
+	}
+
+	for _, permission := range r.Permissions {
+		if _, valid := utils.FindInSlice(validRolePermissions, permission); !valid {
+			return errors.Errorf("%s is not a valid role permission", permission)
+		}
+	}
+
+	return nil
+}
+
+func isValidBadgeURL(value string) bool {
+	if strings.HasPrefix(value, "/") && !strings.HasPrefix(value, "//") {
+		return len(value) <= maxProfileURLLength
+	}
+
+	return isValidProfileURL(value)
+}
+
+// HasPermission will return if any of the user's roles grants a single
+// permission. Moderators have every permission.
+func (u *User) HasPermission(permission string) bool {
+	if u.IsModerator() {
+		return true
+	}
+
+	for _, role := range u.Roles {
+		if role.HasPermission(permission) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasRole will return if the user has been assigned a role by ID.
+func (u *User) HasRole(roleID string) bool {
+	for _, role := range u.Roles {
+		if role.ID == roleID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetRoles will return all the roles that have been defined.
+func GetRoles() ([]Role, error) {
+	rows, err := _datastore.DB.Query("SELECT id, name, color, badge, permissions, created_at FROM roles ORDER BY created_at ASC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := make([]Role, 0)
+	for rows.Next() {
+		role, err := getRoleFromRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+
+	return roles, rows.Err()
+}
+
+// GetRole will return a single role by ID.
+func GetRole(id string) (*Role, error) {
+	row := _datastore.DB.QueryRow("SELECT id, name, color, badge, permissions, created_at FROM roles WHERE id = ?", id)
+	role, err := getRoleFromRow(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrRoleNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+// CreateRole will save a new role.
+func CreateRole(role Role) (*Role, error) {
+	role.Name = strings.TrimSpace(role.Name)
+	if err := role.Validate(); err != nil {
+		return nil, err
+	}
+
+	role.ID = shortid.MustGenerate()
+	role.CreatedAt = time.Now()
+	if role.Permissions == nil {
+		role.Permissions = []string{}
+	}
+
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	if _, err := _datastore.DB.Exec("INSERT INTO roles(id, name, color, badge, permissions, created_at) VALUES(?, ?, ?, ?, ?, ?)",
+		role.ID, role.Name, role.Color, role.Badge, strings.Join(role.Permissions, ","), role.CreatedAt); err != nil {
+		return nil, errors.Wrap(err, "unable to create role")
+	}
+
+	return &role, nil
+}
+
+// UpdateRole will save changes to the name, color, badge and permissions
+// of an existing role.
+func UpdateRole(role Role) error {
+	role.Name = strings.TrimSpace(role.Name)
+	if err := role.Validate(); err != nil {
+		return err
+	}
+
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	result, err := _datastore.DB.Exec("UPDATE roles SET name = ?, color = ?, badge = ?, permissions = ? WHERE id = ?",
+		role.Name, role.Color, role.Badge, strings.Join(role.Permissions, ","), role.ID)
+	if err != nil {
+		return errors.Wrap(err, "unable to update role")
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return ErrRoleNotFound
+	}
+
+	return nil
+}
+
+// DeleteRole will remove a role and unassign it from every user.
+func DeleteRole(id string) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	tx, err := _datastore.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	if _, err := tx.Exec("DELETE FROM user_roles WHERE role_id = ?", id); err != nil {
+		return err
+	}
+
+	result, err := tx.Exec("DELETE FROM roles WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return ErrRoleNotFound
+	}
+
+	return tx.Commit()
+}
+
+// SetRole will assign or unassign a role for a single user by ID.
+func SetRole(userID string, roleID string, assigned bool) error {
+	if GetUserByID(userID) == nil {
+		return errors.New("user not found when modifying roles")
+	}
+
+	if _, err := GetRole(roleID); err != nil {
+		return err
+	}
+
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	var err error
+	if assigned {
+		_, err = _datastore.DB.Exec("INSERT OR IGNORE INTO user_roles(user_id, role_id) VALUES(?, ?)", userID, roleID)
+	} else {
+		_, err = _datastore.DB.Exec("DELETE FROM user_roles WHERE user_id = ? AND role_id = ?", userID, roleID)
+	}
+
+	return errors.Wrap(err, "unable to change user roles")
+}
+
+// GetRolesForUser will return the roles assigned to a single user.
+func GetRolesForUser(userID string) []Role {
+	return GetRolesForUsers([]string{userID})[userID]
+}
+
+// GetRolesForUsers will return the roles assigned to each of the users,
+// keyed by user ID.
+func GetRolesForUsers(userIDs []string) map[string][]Role {
+	userRoles := map[string][]Role{}
+
+	// Stay well below the SQLite limit of query parameters.
+	const batchSize = 500
+	for start := 0; start < len(userIDs); start += batchSize {
+		end := start + batchSize
+		if end > len(userIDs) {
+			end = len(userIDs)
+		}
+
+		if err := addRolesForUsers(userIDs[start:end], userRoles); err != nil {
+			log.Errorln("unable to get user roles", err)
+			break
+		}
+	}
+
+	return userRoles
+}
+
+func addRolesForUsers(userIDs []string, userRoles map[string][]Role) error {
+	args := make([]interface{}, 0, len(userIDs))
+	for _, id := range userIDs {
+		args = append(args, id)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(userIDs)), ",")
+
+	// nolint:gosec
+	query := "SELECT user_roles.user_id, roles.id, roles.name, roles.color, roles.badge, roles.permissions, roles.created_at FROM user_roles INNER JOIN roles ON user_roles.role_id = roles.id WHERE user_roles.user_id IN (" + placeholders + ") ORDER BY roles.created_at ASC"
+	rows, err := _datastore.DB.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID string
+		var role Role
+		var color, badge, permissions sql.NullString
+		if err := rows.Scan(&userID, &role.ID, &role.Name, &color, &badge, &permissions, &role.CreatedAt); err != nil {
+			return err
+		}
+
+		role.Color = color.String
+		role.Badge = badge.String
+		role.Permissions = splitRolePermissions(permissions.String)
+		userRoles[userID] = append(userRoles[userID], role)
+	}
+
+	return rows.Err()
+}
+
+// GetUsersWithRole will return the users that have been assigned a role.
+func GetUsersWithRole(roleID string) []*User {
+	query := "SELECT id, display_name, scopes, display_color, created_at, disabled_at, previous_names, namechanged_at FROM users WHERE id IN (SELECT user_id FROM user_roles WHERE role_id = ?)"
+
+	rows, err := _datastore.DB.Query(query, roleID)
+	if err != nil {
+		log.Errorln(err)
+		return nil
+	}
+	defer rows.Close()
+
+	return getUsersFromRows(rows)
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func getRoleFromRow(row rowScanner) (Role, error) {
+	var role Role
+	var color, badge, permissions sql.NullString
+
+	if err := row.Scan(&role.ID, &role.Name, &color, &badge, &permissions, &role.CreatedAt); err != nil {
+		return role, err
+	}
+
+	role.Color = color.String
+	role.Badge = badge.String
+	role.Permissions = splitRolePermissions(permissions.String)
+
+	return role, nil
+}
+
+func splitRolePermissions(permissions string) []string {
+	if permissions == "" {
+		return []string{}
+	}
+
+	return strings.Split(permissions, ",")
+}
@@ -0,0 +1,40 @@
+package user
+
+import "testing"
+
+func TestRolePermissions(t *testing.T) {
+	if _, err := CreateRole(Role{Name: "VIP", Permissions: []string{"NOT_A_PERMISSION"}}); err == nil {
+		t.Error("expected a role with an unknown permission to be rejected")
+	}
+
+	role, err := CreateRole(Role{Name: "VIP", Color: "#ffd700", Badge: "/img/badges/vip.png", Permissions: []string{RolePermissionPostLinks}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u, _, err := CreateAnonymousUser("role-tester")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetRole(u.ID, role.ID, true); err != nil {
+		t.Fatal(err)
+	}
+
+	u = GetUserByID(u.ID)
+	if !u.HasRole(role.ID) || !u.HasPermission(RolePermissionPostLinks) {
+		t.Error("expected the user to have the assigned role and its permissions")
+	}
+
+	if u.HasPermission(RolePermissionBypassSlowMode) {
+		t.Error("expected the user to not have a permission the role does not grant")
+	}
+
+	if err := DeleteRole(role.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if u = GetUserByID(u.ID); len(u.Roles) != 0 {
+		t.Error("expected deleting a role to unassign it", u.Roles)
+	}
+}
@@ -37,6 +37,7 @@ type User struct {
 	IsBot           bool       `json:"isBot"`
 	AuthenticatedAt *time.Time `json:"-"`
 	Authenticated   bool       `json:"authenticated"`
+	Roles           []Role     `json:"roles,omitempty"`
 }
 
 // IsEnabled will return if this single user is enabled.
@@ -224,6 +225,7 @@ func GetUserByToken(token string) *User {
 		AuthenticatedAt: authenticatedAt,
 		Authenticated:   authenticatedAt != nil,
 		Scopes:          scopes,
+		Roles:           GetRolesForUser(u.ID),
 	}
 }
 
@@ -315,7 +317,7 @@ func GetUserByID(id string) *User {
 	_datastore.DbLock.Lock()
 	defer _datastore.DbLock.Unlock()
 
-	query := "SELECT id, display_name, display_color, created_at, disabled_at, previous_names, namechanged_at, scopes FROM users WHERE id = ?"
+	query := "SELECT id, display_name, display_color, created_at, disabled_at, previous_names, namechanged_at, authenticated_at, scopes FROM users WHERE id = ?"
 	row := _datastore.DB.QueryRow(query, id)
 	if row == nil {
 		log.Errorln(row)
@@ -424,9 +426,10 @@ func getUserFromRow(row *sql.Row) *User {
 	var disabledAt *time.Time
 	var previousUsernames string
 	var userNameChangedAt *time.Time
+	var authenticatedAt *time.Time
 	var scopesString *string
 
-	if err := row.Scan(&id, &displayName, &displayColor, &createdAt, &disabledAt, &previousUsernames, &userNameChangedAt, &scopesString); err != nil {
+	if err := row.Scan(&id, &displayName, &displayColor, &createdAt, &disabledAt, &previousUsernames, &userNameChangedAt, &authenticatedAt, &scopesString); err != nil {
 		return nil
 	}
 
@@ -436,13 +439,16 @@ func getUserFromRow(row *sql.Row) *User {
 	}
 
 	return &User{
-		ID:            id,
-		DisplayName:   displayName,
-		DisplayColor:  displayColor,
-		CreatedAt:     createdAt,
-		DisabledAt:    disabledAt,
-		PreviousNames: strings.Split(previousUsernames, ","),
-		NameChangedAt: userNameChangedAt,
-		Scopes:        scopes,
+		ID:              id,
+		DisplayName:     displayName,
+		DisplayColor:    displayColor,
+		CreatedAt:       createdAt,
+		DisabledAt:      disabledAt,
+		PreviousNames:   strings.Split(previousUsernames, ","),
+		NameChangedAt:   userNameChangedAt,
+		AuthenticatedAt: authenticatedAt,
+		Authenticated:   authenticatedAt != nil,
+		Scopes:          scopes,
+		Roles:           GetRolesForUser(id),
 	}
 }
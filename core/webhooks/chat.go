@@ -42,3 +42,14 @@ func SendChatEventUserJoined(event events.UserJoinedEvent) {
 
 	SendEventToWebhooks(webhookEvent)
 }
+
+// SendRaidProtectionEvent will send a chat raid protection started or ended
+// event to webhook destinations.
+func SendRaidProtectionEvent(eventType models.EventType, status interface{}) {
+	webhookEvent := WebhookEvent{
+		Type:      eventType,
+		EventData: status,
+	}
+
+	SendEventToWebhooks(webhookEvent)
+}
@@ -0,0 +1,190 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+	"github.com/teris-io/shortid"
+)
+
+const (
+	// A delivery is attempted this many times before it is given up on.
+	webhookMaxAttempts = 8
+	// The wait before the first retry, doubled for every retry after it.
+	webhookRetryBaseDelay = 30 * time.Second
+	webhookRetryMaxDelay  = time.Hour
+	// A webhook is disabled after this many deliveries in a row fail every
+	// attempt.
+	webhookDisableAfterFailures = 5
+	// How long a delivery being sent is hidden from the retry loop.
+	webhookDeliveryLease = 2 * time.Minute
+	// How often deliveries are checked to see if they are due a retry.
+	webhookRetryInterval = 15 * time.Second
+	// Finished deliveries are removed from the delivery log after this long.
+	webhookDeliveryRetention = 7 * 24 * time.Hour
+)
+
+// queueDelivery will save an event for a webhook so it can be retried until
+// it is delivered, and then try to send it.
+func queueDelivery(webhook models.Webhook, payload WebhookEvent) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.Errorln("unable to marshal webhook event", err)
+		return
+	}
+
+	if len(webhook.Filters) > 0 {
+		fields, err := eventFields(body)
+		if err != nil {
+			log.Errorln("unable to read webhook event", err)
+			return
+		}
+
+		if !matchesFilters(webhook.Filters, fields) {
+			log.Tracef("Event %s filtered out for Webhook %s", payload.Type, webhook.URL)
+			return
+		}
+	}
+
+	delivery, err := newDelivery(webhook.ID, payload.Type, body)
+	if err != nil {
+		log.Errorln(err)
+		return
+	}
+
+	addToQueue(webhook, delivery)
+}
+
+func newDelivery(webhookID int, eventType models.EventType, payload []byte) (models.WebhookDelivery, error) {
+	id, err := shortid.Generate()
+	if err != nil {
+		return models.WebhookDelivery{}, err
+	}
+
+	now := time.Now()
+	leaseEnd := now.Add(webhookDeliveryLease)
+	delivery := models.WebhookDelivery{
+		ID:            id,
+		WebhookID:     webhookID,
+		EventType:     eventType,
+		Payload:       payload,
+		Status:        models.WebhookDeliveryPending,
+		CreatedAt:     now,
+		NextAttemptAt: &leaseEnd,
+	}
+
+	return delivery, data.InsertWebhookDelivery(delivery)
+}
+
+// Redeliver will send the event of a previous delivery to its webhook again
+// as a new delivery.
+func Redeliver(deliveryID string) (*models.WebhookDelivery, error) {
+	previous, err := data.GetWebhookDelivery(deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook, err := data.GetWebhook(previous.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := newDelivery(webhook.ID, previous.EventType, previous.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	go addToQueue(*webhook, delivery)
+
+	return &delivery, nil
+}
+
+// recordAttempt will save the result of an attempt to send a delivery and
+// schedule a retry if it failed.
+func recordAttempt(job Job, result attemptResult) {
+	now := time.Now()
+	delivery := job.delivery
+	delivery.Attempts++
+	delivery.StatusCode = result.statusCode
+	delivery.LatencyMS = result.latency.Milliseconds()
+	delivery.Response = result.response
+	delivery.LastAttemptAt = &now
+	delivery.NextAttemptAt = nil
+	delivery.Error = ""
+
+	switch {
+	case result.err == nil:
+		delivery.Status = models.WebhookDeliveryDelivered
+		if err := data.SetWebhookAsUsed(job.webhook); err != nil {
+			log.Warnln(err)
+		}
+	case result.permanent:
+		// The webhook's configuration is at fault rather than the endpoint,
+		// so it does not count towards disabling the webhook.
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.Error = result.err.Error()
+	case delivery.Attempts < webhookMaxAttempts:
+		delivery.Status = models.WebhookDeliveryPending
+		delivery.Error = result.err.Error()
+		nextAttempt := now.Add(retryDelay(delivery.Attempts))
+		delivery.NextAttemptAt = &nextAttempt
+	default:
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.Error = result.err.Error()
+		disabled, err := data.SetWebhookDeliveryFailed(job.webhook.ID, webhookDisableAfterFailures)
+		if err != nil {
+			log.Warnln(err)
+		} else if disabled {
+			log.Warnf("Webhook %s has been disabled after %d deliveries in a row failed", job.webhook.URL, webhookDisableAfterFailures)
+		}
+	}
+
+	if err := data.UpdateWebhookDelivery(delivery); err != nil {
+		log.Errorln(err)
+	}
+}
+
+// retryDelay will return how long to wait after a number of failed attempts.
+func retryDelay(attempts int) time.Duration {
+	delay := webhookRetryBaseDelay
+	for i := 1; i < attempts && delay < webhookRetryMaxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > webhookRetryMaxDelay {
+		return webhookRetryMaxDelay
+	}
+	return delay
+}
+
+func retryDeliveries() {
+	lastPruned := time.Time{}
+
+	for {
+		deliveries, err := data.ClaimDueWebhookDeliveries(time.Now(), webhookDeliveryLease, 100)
+		if err != nil {
+			log.Errorln("unable to get webhook deliveries to retry", err)
+		}
+
+		for _, delivery := range deliveries {
+			webhook, err := data.GetWebhook(delivery.WebhookID)
+			if err != nil {
+				log.Warnln(err)
+				continue
+			}
+			addToQueue(*webhook, delivery)
+		}
+
+		if time.Since(lastPruned) > time.Hour {
+			if err := data.RemoveWebhookDeliveriesBefore(time.Now().Add(-webhookDeliveryRetention)); err != nil {
+				log.Errorln("unable to remove old webhook deliveries", err)
+			}
+			lastPruned = time.Now()
+		}
+
+		time.Sleep(webhookRetryInterval)
+	}
+}
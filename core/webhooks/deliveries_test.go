@@ -0,0 +1,222 @@
+package webhooks
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/models"
+)
+
+func TestMain(m *testing.M) {
+	dbFile, err := os.CreateTemp(os.TempDir(), "owncast-test-db.db")
+	if err != nil {
+		panic(err)
+	}
+
+	if err := data.SetupPersistence(dbFile.Name()); err != nil {
+		panic(err)
+	}
+
+	os.Exit(m.Run())
+}
+
+func TestRetryDelay(t *testing.T) {
+	expected := map[int]time.Duration{
+		1:  30 * time.Second,
+		2:  time.Minute,
+		3:  2 * time.Minute,
+		7:  32 * time.Minute,
+		8:  time.Hour,
+		20: time.Hour,
+	}
+
+	for attempts, delay := range expected {
+		if got := retryDelay(attempts); got != delay {
+			t.Errorf("retry delay after %d attempts is %s, expected %s", attempts, got, delay)
+		}
+	}
+}
+
+// insertTestWebhook will save a webhook that sends to a url and return it.
+func insertTestWebhook(t *testing.T, url string, options models.WebhookOptions) models.Webhook {
+	t.Helper()
+
+	id, _, err := data.InsertWebhook(url, []models.EventType{models.MessageSent}, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	webhook, err := data.GetWebhook(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return *webhook
+}
+
+func getTestDelivery(t *testing.T, id string) models.WebhookDelivery {
+	t.Helper()
+
+	delivery, err := data.GetWebhookDelivery(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return *delivery
+}
+
+func TestFailingDeliveriesAreRetriedAndDisableTheWebhook(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("unavailable"))
+	}))
+	defer server.Close()
+
+	webhook := insertTestWebhook(t, server.URL, models.WebhookOptions{})
+
+	for i := 1; i <= webhookDisableAfterFailures; i++ {
+		delivery, err := newDelivery(webhook.ID, models.MessageSent, testEvent)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for attempt := 1; attempt <= webhookMaxAttempts; attempt++ {
+			job := Job{webhook: webhook, delivery: getTestDelivery(t, delivery.ID)}
+			recordAttempt(job, sendWebhook(job))
+
+			saved := getTestDelivery(t, delivery.ID)
+			if saved.Attempts != attempt || saved.StatusCode != http.StatusInternalServerError || saved.Response != "unavailable" {
+				t.Fatalf("attempt %d was not recorded: %+v", attempt, saved)
+			}
+
+			if attempt < webhookMaxAttempts && (saved.Status != models.WebhookDeliveryPending || saved.NextAttemptAt == nil) {
+				t.Fatalf("expected attempt %d to be retried: %+v", attempt, saved)
+			}
+			if attempt == webhookMaxAttempts && saved.Status != models.WebhookDeliveryFailed {
+				t.Fatalf("expected the delivery to fail after %d attempts: %+v", attempt, saved)
+			}
+		}
+
+		updated, err := data.GetWebhook(webhook.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if disabled := updated.DisabledAt != nil; disabled != (i == webhookDisableAfterFailures) {
+			t.Fatalf("after %d failed deliveries the webhook disabled is %t", i, disabled)
+		}
+	}
+
+	if sent := atomic.LoadInt32(&requests); sent != webhookDisableAfterFailures*webhookMaxAttempts {
+		t.Errorf("expected %d requests, got %d", webhookDisableAfterFailures*webhookMaxAttempts, sent)
+	}
+
+	// Deliveries to a disabled webhook are not retried.
+	if _, err := newDelivery(webhook.ID, models.MessageSent, testEvent); err != nil {
+		t.Fatal(err)
+	}
+	claimed, err := data.ClaimDueWebhookDeliveries(time.Now().Add(time.Hour), webhookDeliveryLease, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, delivery := range claimed {
+		if delivery.WebhookID == webhook.ID {
+			t.Error("expected deliveries to a disabled webhook not to be claimed")
+		}
+	}
+}
+
+func TestClaimDueWebhookDeliveries(t *testing.T) {
+	webhook := insertTestWebhook(t, "http://localhost/claim", models.WebhookOptions{})
+
+	delivery, err := newDelivery(webhook.ID, models.MessageSent, testEvent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims := func(now time.Time) bool {
+		claimed, err := data.ClaimDueWebhookDeliveries(now, webhookDeliveryLease, 100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, c := range claimed {
+			if c.ID == delivery.ID {
+				return true
+			}
+		}
+		return false
+	}
+
+	// A new delivery is leased while it is first sent.
+	if claims(time.Now()) {
+		t.Error("expected a new delivery not to be claimed while it is being sent")
+	}
+
+	later := time.Now().Add(webhookDeliveryLease + time.Second)
+	if !claims(later) {
+		t.Fatal("expected the delivery to be claimed once its lease ended")
+	}
+	if claims(later) {
+		t.Error("expected a claimed delivery not to be claimed again during its lease")
+	}
+	if !claims(later.Add(webhookDeliveryLease + time.Second)) {
+		t.Error("expected the delivery to be claimed again once the new lease ended")
+	}
+}
+
+func TestRedeliver(t *testing.T) {
+	webhook := insertTestWebhook(t, "http://localhost/redeliver", models.WebhookOptions{})
+
+	previous, err := newDelivery(webhook.ID, models.MessageSent, testEvent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	queue = make(chan Job, 1)
+	defer func() { queue = nil }()
+
+	delivery, err := Redeliver(previous.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case job := <-queue:
+		if job.delivery.ID != delivery.ID || job.webhook.ID != webhook.ID {
+			t.Errorf("expected the new delivery to be queued, got %+v", job)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the redelivery was not queued")
+	}
+
+	saved := getTestDelivery(t, delivery.ID)
+	if saved.ID == previous.ID || string(saved.Payload) != string(testEvent) || saved.Status != models.WebhookDeliveryPending || saved.Attempts != 0 {
+		t.Errorf("expected a new pending delivery of the same event: %+v", saved)
+	}
+}
+
+func TestUnrenderableDeliveriesFailWithoutRetrying(t *testing.T) {
+	webhook := insertTestWebhook(t, "http://localhost/unrenderable", models.WebhookOptions{Template: "{{lower .eventData.missing}}"})
+
+	delivery, err := newDelivery(webhook.ID, models.MessageSent, testEvent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	job := Job{webhook: webhook, delivery: delivery}
+	recordAttempt(job, sendWebhook(job))
+
+	if saved := getTestDelivery(t, delivery.ID); saved.Status != models.WebhookDeliveryFailed || saved.NextAttemptAt != nil {
+		t.Errorf("expected the delivery to fail without a retry: %+v", saved)
+	}
+
+	if updated, _ := data.GetWebhook(webhook.ID); updated.FailureCount != 0 {
+		t.Error("expected a template error not to count against the webhook")
+	}
+}
@@ -0,0 +1,30 @@
+package webhooks
+
+import (
+	"time"
+
+	"github.com/owncast/owncast/models"
+)
+
+// WebhookFediverseEngagement is the webhook payload for a follow, like,
+// share or follow request from a fediverse account.
+type WebhookFediverseEngagement struct {
+	Name               string    `json:"name"`
+	Username           string    `json:"username"`
+	Image              *string   `json:"image,omitempty"`
+	ActorIRI           string    `json:"actorIri"`
+	ObjectIRI          string    `json:"objectIri,omitempty"`
+	IsLiveNotification bool      `json:"isLiveNotification"`
+	Timestamp          time.Time `json:"timestamp"`
+}
+
+// SendFediverseEvent will send webhook destinations an engagement from a
+// fediverse account.
+func SendFediverseEvent(eventType models.EventType, engagement WebhookFediverseEngagement) {
+	engagement.Timestamp = time.Now()
+
+	SendEventToWebhooks(WebhookEvent{
+		Type:      eventType,
+		EventData: engagement,
+	})
+}
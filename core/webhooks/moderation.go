@@ -0,0 +1,65 @@
+package webhooks
+
+import (
+	"time"
+
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/models"
+)
+
+// WebhookUserModeration is the webhook payload for a change to a chat user's
+// enabled or moderator status.
+type WebhookUserModeration struct {
+	User      *user.User `json:"user"`
+	Actor     string     `json:"actor,omitempty"`
+	Timestamp time.Time  `json:"timestamp"`
+}
+
+// WebhookIPAddressBan is the webhook payload for an IP address ban being
+// added or removed.
+type WebhookIPAddressBan struct {
+	IPAddress string     `json:"ipAddress"`
+	Reason    string     `json:"reason,omitempty"`
+	Actor     string     `json:"actor,omitempty"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	Timestamp time.Time  `json:"timestamp"`
+}
+
+// SendUserEnabledEvent will send webhook destinations a chat user being
+// banned or unbanned.
+func SendUserEnabledEvent(u *user.User, enabled bool, actor string) {
+	eventType := models.UserDisabled
+	if enabled {
+		eventType = models.UserEnabled
+	}
+
+	SendEventToWebhooks(WebhookEvent{
+		Type:      eventType,
+		EventData: WebhookUserModeration{User: u, Actor: actor, Timestamp: time.Now()},
+	})
+}
+
+// SendModeratorEvent will send webhook destinations a chat user being made a
+// moderator or no longer being one.
+func SendModeratorEvent(u *user.User, isModerator bool, actor string) {
+	eventType := models.ModeratorRevoked
+	if isModerator {
+		eventType = models.ModeratorGranted
+	}
+
+	SendEventToWebhooks(WebhookEvent{
+		Type:      eventType,
+		EventData: WebhookUserModeration{User: u, Actor: actor, Timestamp: time.Now()},
+	})
+}
+
+// SendIPAddressBanEvent will send webhook destinations an IP address ban
+// being added or removed.
+func SendIPAddressBanEvent(eventType models.EventType, ban WebhookIPAddressBan) {
+	ban.Timestamp = time.Now()
+
+	SendEventToWebhooks(WebhookEvent{
+		Type:      eventType,
+		EventData: ban,
+	})
+}
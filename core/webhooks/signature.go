@@ -0,0 +1,43 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Every webhook request is signed so receivers can verify it was sent by
+// this server. The signature is a hex encoded HMAC-SHA256, using the secret
+// of the webhook, of the timestamp header, a period and the request body.
+//
+// Receivers should compute the signature themselves and compare it in
+// constant time, reject requests with a timestamp more than five minutes
+// from their own clock and ignore delivery IDs they have already seen.
+const (
+	// DeliveryIDHeader is a unique ID for each event sent to a webhook.
+	DeliveryIDHeader = "X-Owncast-Webhook-Id"
+	// TimestampHeader is when the request was signed, in unix seconds.
+	TimestampHeader = "X-Owncast-Webhook-Timestamp"
+	// SignatureHeader is the signature of the request, prefixed with sha256=.
+	SignatureHeader = "X-Owncast-Webhook-Signature"
+)
+
+// signRequest will add the delivery and signature headers to a request.
+func signRequest(req *http.Request, secret string, deliveryID string, body []byte, now time.Time) {
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	req.Header.Set(DeliveryIDHeader, deliveryID)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, "sha256="+computeSignature(secret, timestamp, body))
+}
+
+func computeSignature(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
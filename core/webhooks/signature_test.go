@@ -0,0 +1,30 @@
+package webhooks
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSignRequest(t *testing.T) {
+	body := []byte(`{"type":"CHAT"}`)
+	req, err := http.NewRequest("POST", "https://example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signRequest(req, "secret", "abc123", body, time.Unix(1700000000, 0))
+
+	if id := req.Header.Get(DeliveryIDHeader); id != "abc123" {
+		t.Errorf("delivery id is %s, expected abc123", id)
+	}
+
+	if timestamp := req.Header.Get(TimestampHeader); timestamp != "1700000000" {
+		t.Errorf("timestamp is %s, expected 1700000000", timestamp)
+	}
+
+	expected := "sha256=a2dfbbcabf4a8207515a0e3fc711e4cf396bc44f485afff822fa03b43dd4365c"
+	if signature := req.Header.Get(SignatureHeader); signature != expected {
+		t.Errorf("signature is %s, expected %s", signature, expected)
+	}
+}
@@ -17,7 +17,54 @@ func SendStreamStatusEvent(eventType models.EventType) {
 			"name":        data.GetServerName(),
 			"summary":     data.GetServerSummary(),
 			"streamTitle": data.GetStreamTitle(),
+			"tags":        data.GetServerMetadataTags(),
 			"timestamp":   time.Now(),
 		},
 	})
 }
+
+// WebhookHealthAlert is the webhook payload for server resources or viewer
+// playback becoming unhealthy.
+type WebhookHealthAlert struct {
+	// Kind is one of cpu, memory, disk or playback.
+	Kind      string    `json:"kind"`
+	Value     float64   `json:"value"`
+	Threshold float64   `json:"threshold"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// SendStreamTitleChangedEvent will send webhook destinations the new stream title.
+func SendStreamTitleChangedEvent(previousTitle string, title string, actor string) {
+	SendEventToWebhooks(WebhookEvent{
+		Type: models.StreamTitleChanged,
+		EventData: map[string]interface{}{
+			"streamTitle":         title,
+			"previousStreamTitle": previousTitle,
+			"actor":               actor,
+			"timestamp":           time.Now(),
+		},
+	})
+}
+
+// SendTranscoderErrorEvent will send webhook destinations an error reported
+// by the video transcoder.
+func SendTranscoderErrorEvent(message string) {
+	SendEventToWebhooks(WebhookEvent{
+		Type: models.TranscoderError,
+		EventData: map[string]interface{}{
+			"message":   message,
+			"timestamp": time.Now(),
+		},
+	})
+}
+
+// SendHealthAlertEvent will send webhook destinations a health alert.
+func SendHealthAlertEvent(alert WebhookHealthAlert) {
+	alert.Timestamp = time.Now()
+
+	SendEventToWebhooks(WebhookEvent{
+		Type:      models.HealthAlert,
+		EventData: alert,
+	})
+}
@@ -0,0 +1,202 @@
+package webhooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/models"
+)
+
+var templateFuncs = template.FuncMap{
+	// json will encode a value so it can be placed in a JSON template.
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"join": func(v interface{}, sep string) string {
+		items, ok := v.([]interface{})
+		if !ok {
+			return fmt.Sprint(v)
+		}
+		values := make([]string, len(items))
+		for i, item := range items {
+			values[i] = fmt.Sprint(item)
+		}
+		return strings.Join(values, sep)
+	},
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// ValidateTemplate will verify a webhook template can be used by rendering
+// it with a sample chat event.
+func ValidateTemplate(text string) error {
+	if _, err := parseTemplate(text); err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(sampleEvent())
+	if err != nil {
+		return err
+	}
+
+	_, _, err = renderPayload(models.Webhook{WebhookOptions: models.WebhookOptions{Template: text}}, payload)
+	return err
+}
+
+// sampleEvent will return a chat event with every field set, to check
+// templates before they are used with real events.
+func sampleEvent() WebhookEvent {
+	now := time.Now()
+	return WebhookEvent{
+		Type: models.MessageSent,
+		EventData: &WebhookChatMessage{
+			User: &user.User{
+				ID:            "sample",
+				DisplayName:   "Viewer",
+				DisplayColor:  1,
+				CreatedAt:     now,
+				PreviousNames: []string{"Viewer"},
+				Scopes:        []string{"MODERATOR"},
+			},
+			ClientID:  1,
+			Body:      "Hello world",
+			RawBody:   "Hello world",
+			ID:        "sample",
+			Visible:   true,
+			Timestamp: &now,
+		},
+	}
+}
+
+func parseTemplate(text string) (*template.Template, error) {
+	return template.New("webhook").Funcs(templateFuncs).Parse(text)
+}
+
+// eventFields will return an event as generic values, so filters and
+// templates use the same field names as the JSON sent to webhooks.
+func eventFields(payload []byte) (map[string]interface{}, error) {
+	var fields map[string]interface{}
+	err := json.Unmarshal(payload, &fields)
+	return fields, err
+}
+
+// matchesFilters will return if an event meets all of the filters.
+func matchesFilters(filters []models.WebhookFilter, fields map[string]interface{}) bool {
+	for _, filter := range filters {
+		if !matchesFilter(filter, fields) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchesFilter(filter models.WebhookFilter, fields map[string]interface{}) bool {
+	value, exists := lookupField(fields, filter.Field)
+
+	switch filter.Operator {
+	case models.WebhookFilterExists:
+		return exists
+	case models.WebhookFilterNotExists:
+		return !exists
+	case models.WebhookFilterEquals:
+		return exists && fieldEquals(value, filter.Value)
+	case models.WebhookFilterNotEquals:
+		return !exists || !fieldEquals(value, filter.Value)
+	case models.WebhookFilterContains:
+		return exists && fieldContains(value, filter.Value)
+	case models.WebhookFilterNotContains:
+		return !exists || !fieldContains(value, filter.Value)
+	}
+
+	return false
+}
+
+// lookupField will return the value at a dotted path, where numbers select
+// an item from a list.
+func lookupField(fields map[string]interface{}, path string) (interface{}, bool) {
+	var current interface{} = fields
+
+	for _, key := range strings.Split(path, ".") {
+		switch v := current.(type) {
+		case map[string]interface{}:
+			value, ok := v[key]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, false
+			}
+			current = v[index]
+		default:
+			return nil, false
+		}
+	}
+
+	return current, current != nil
+}
+
+func fieldEquals(value interface{}, expected string) bool {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	}
+
+	return fmt.Sprint(value) == expected
+}
+
+func fieldContains(value interface{}, expected string) bool {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if fieldEquals(item, expected) {
+				return true
+			}
+		}
+		return false
+	case string:
+		return strings.Contains(strings.ToLower(v), strings.ToLower(expected))
+	}
+
+	return false
+}
+
+// renderPayload will return the request body and content type to send to a
+// webhook for an event.
+func renderPayload(webhook models.Webhook, payload []byte) ([]byte, string, error) {
+	contentType := webhook.ContentType
+	if contentType == "" {
+		contentType = models.WebhookContentTypeJSON
+	}
+
+	if webhook.Template == "" {
+		return payload, contentType, nil
+	}
+
+	tmpl, err := parseTemplate(webhook.Template)
+	if err != nil {
+		return nil, "", err
+	}
+
+	fields, err := eventFields(payload)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, fields); err != nil {
+		return nil, "", err
+	}
+
+	return body.Bytes(), contentType, nil
+}
@@ -0,0 +1,81 @@
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/owncast/owncast/models"
+)
+
+var testEvent = []byte(`{"type":"CHAT","eventData":{"user":{"displayName":"Mod","scopes":["MODERATOR"]},"body":"Hello World","visible":true,"tags":["music","art"]}}`)
+
+func TestMatchesFilters(t *testing.T) {
+	fields, err := eventFields(testEvent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		filter   models.WebhookFilter
+		expected bool
+	}{
+		{models.WebhookFilter{Field: "eventData.user.scopes", Operator: models.WebhookFilterContains, Value: "MODERATOR"}, true},
+		{models.WebhookFilter{Field: "eventData.user.scopes", Operator: models.WebhookFilterNotContains, Value: "MODERATOR"}, false},
+		{models.WebhookFilter{Field: "eventData.tags", Operator: models.WebhookFilterContains, Value: "art"}, true},
+		{models.WebhookFilter{Field: "eventData.tags.0", Operator: models.WebhookFilterEquals, Value: "music"}, true},
+		{models.WebhookFilter{Field: "eventData.body", Operator: models.WebhookFilterContains, Value: "world"}, true},
+		{models.WebhookFilter{Field: "eventData.visible", Operator: models.WebhookFilterEquals, Value: "true"}, true},
+		{models.WebhookFilter{Field: "type", Operator: models.WebhookFilterNotEquals, Value: "CHAT"}, false},
+		{models.WebhookFilter{Field: "eventData.missing", Operator: models.WebhookFilterExists}, false},
+		{models.WebhookFilter{Field: "eventData.missing", Operator: models.WebhookFilterNotEquals, Value: "x"}, true},
+	}
+
+	for _, test := range tests {
+		if matches := matchesFilters([]models.WebhookFilter{test.filter}, fields); matches != test.expected {
+			t.Errorf("%+v matched %t, expected %t", test.filter, matches, test.expected)
+		}
+	}
+}
+
+func TestRenderPayload(t *testing.T) {
+	webhook := models.Webhook{
+		WebhookOptions: models.WebhookOptions{
+			Template:    `{"text": {{json (printf "%s: %s" .eventData.user.displayName .eventData.body)}}}`,
+			ContentType: models.WebhookContentTypeJSON,
+		},
+	}
+
+	body, contentType, err := renderPayload(webhook, testEvent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := `{"text": "Mod: Hello World"}`; string(body) != expected {
+		t.Errorf("body is %s, expected %s", body, expected)
+	}
+
+	if contentType != models.WebhookContentTypeJSON {
+		t.Errorf("content type is %s", contentType)
+	}
+
+	webhook.Template = `text={{urlquery .eventData.body}}`
+	webhook.ContentType = models.WebhookContentTypeForm
+	body, _, err = renderPayload(webhook, testEvent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := `text=Hello+World`; string(body) != expected {
+		t.Errorf("body is %s, expected %s", body, expected)
+	}
+}
+
+func TestValidateTemplate(t *testing.T) {
+	if err := ValidateTemplate(`{"text": {{json (lower .eventData.user.displayName)}}}`); err != nil {
+		t.Error(err)
+	}
+
+	// Parses, but fails once it is run with an event.
+	if err := ValidateTemplate(`{{lower .eventData.missing}}`); err == nil {
+		t.Error("expected a template that can not be rendered to be invalid")
+	}
+}
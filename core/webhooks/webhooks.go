@@ -30,6 +30,6 @@ func SendEventToWebhooks(payload WebhookEvent) {
 	webhooks := data.GetWebhooksForEvent(payload.Type)
 
 	for _, webhook := range webhooks {
-		go addToQueue(webhook, payload)
+		go queueDelivery(webhook, payload)
 	}
 }
@@ -2,29 +2,47 @@ package webhooks
 
 import (
 	"bytes"
-	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
-	"github.com/owncast/owncast/core/data"
 	"github.com/owncast/owncast/models"
 )
 
 const (
 	// webhookWorkerPoolSize defines the number of concurrent HTTP webhook requests.
 	webhookWorkerPoolSize = 10
+
+	webhookRequestTimeout = 10 * time.Second
+
+	// The longest response body kept in the delivery log.
+	webhookResponseSnippetLength = 512
 )
 
-// Job struct bundling the webhook and the payload in one struct.
+// Job struct bundling the webhook and the delivery to send to it.
 type Job struct {
-	webhook models.Webhook
-	payload WebhookEvent
+	webhook  models.Webhook
+	delivery models.WebhookDelivery
+}
+
+// attemptResult is the outcome of a single attempt to send a delivery.
+type attemptResult struct {
+	statusCode int
+	latency    time.Duration
+	response   string
+	err        error
+	// permanent is set when retrying the delivery can not succeed, such as
+	// when the payload template can not be rendered.
+	permanent bool
 }
 
 var queue chan Job
 
-// InitWorkerPool starts n go routines that await webhook jobs.
+// InitWorkerPool starts n go routines that await webhook jobs, and another
+// that retries the deliveries that failed or were interrupted by a restart.
 func InitWorkerPool() {
 	queue = make(chan Job)
 
@@ -32,51 +50,65 @@ func InitWorkerPool() {
 	for i := 1; i <= webhookWorkerPoolSize; i++ {
 		go worker(i, queue)
 	}
+
+	go retryDeliveries()
 }
 
-func addToQueue(webhook models.Webhook, payload WebhookEvent) {
-	log.Tracef("Queued Event %s for Webhook %s", payload.Type, webhook.URL)
-	queue <- Job{webhook, payload}
+func addToQueue(webhook models.Webhook, delivery models.WebhookDelivery) {
+	log.Tracef("Queued Event %s for Webhook %s", delivery.EventType, webhook.URL)
+	queue <- Job{webhook, delivery}
 }
 
 func worker(workerID int, queue <-chan Job) {
 	log.Debugf("Started Webhook worker %d", workerID)
 
 	for job := range queue {
-		log.Debugf("Event %s sent to Webhook %s using worker %d", job.payload.Type, job.webhook.URL, workerID)
+		log.Debugf("Event %s sent to Webhook %s using worker %d", job.delivery.EventType, job.webhook.URL, workerID)
 
-		if err := sendWebhook(job); err != nil {
-			log.Errorf("Event: %s failed to send to webhook: %s Error: %s", job.payload.Type, job.webhook.URL, err)
+		result := sendWebhook(job)
+		if result.err != nil {
+			log.Errorf("Event: %s failed to send to webhook: %s Error: %s", job.delivery.EventType, job.webhook.URL, result.err)
 		}
-		log.Tracef("Done with Event %s to Webhook %s using worker %d", job.payload.Type, job.webhook.URL, workerID)
+		recordAttempt(job, result)
+
+		log.Tracef("Done with Event %s to Webhook %s using worker %d", job.delivery.EventType, job.webhook.URL, workerID)
 	}
 }
 
-func sendWebhook(job Job) error {
-	jsonText, err := json.Marshal(job.payload)
+func sendWebhook(job Job) attemptResult {
+	body, contentType, err := renderPayload(job.webhook, job.delivery.Payload)
 	if err != nil {
-		return err
+		return attemptResult{err: fmt.Errorf("unable to render webhook payload: %w", err), permanent: true}
 	}
 
-	req, err := http.NewRequest("POST", job.webhook.URL, bytes.NewReader(jsonText))
+	req, err := http.NewRequest("POST", job.webhook.URL, bytes.NewReader(body))
 	if err != nil {
-		return err
+		return attemptResult{err: err}
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", contentType)
+	signRequest(req, job.webhook.Secret, job.delivery.ID, body, time.Now())
 
-	client := &http.Client{}
+	client := &http.Client{Timeout: webhookRequestTimeout}
 
+	start := time.Now()
 	resp, err := client.Do(req)
 	if err != nil {
-		return err
+		return attemptResult{latency: time.Since(start), err: err}
 	}
 
 	defer resp.Body.Close()
 
-	if err := data.SetWebhookAsUsed(job.webhook); err != nil {
-		log.Warnln(err)
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseSnippetLength))
+	result := attemptResult{
+		statusCode: resp.StatusCode,
+		latency:    time.Since(start),
+		response:   string(snippet),
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		result.err = fmt.Errorf("webhook responded with status %d", resp.StatusCode)
 	}
 
-	return nil
+	return result
 }
@@ -50,12 +50,16 @@ type IpBan struct {
 	IpAddress string
 	Notes     sql.NullString
 	CreatedAt sql.NullTime
+	ExpiresAt sql.NullTime
+	BannedBy  sql.NullString
 }
 
 type Notification struct {
 	ID          int32
 	Channel     string
 	Destination string
+	UserID      sql.NullString
+	Topics      sql.NullString
 	CreatedAt   sql.NullTime
 }
 
@@ -60,24 +60,30 @@ SELECT count(*) FROM ap_accepted_activities WHERE iri = $1 AND actor = $2 AND TY
 UPDATE ap_followers SET inbox = $1, name = $2, username = $3, image = $4 WHERE iri = $5;
 
 -- name: BanIPAddress :exec
-INSERT INTO ip_bans(ip_address, notes) values($1, $2);
+INSERT OR REPLACE INTO ip_bans(ip_address, notes, expires_at, banned_by) values($1, $2, $3, $4);
 
 -- name: RemoveIPAddressBan :exec
 DELETE FROM ip_bans WHERE ip_address = $1;
 
--- name: IsIPAddressBlocked :one
-SELECT count(*) FROM ip_bans WHERE ip_address = $1;
-
 -- name: GetIPAddressBans :many
 SELECT * FROM ip_bans;
 -- name: AddNotification :exec
-INSERT INTO notifications (channel, destination) VALUES($1, $2);
+INSERT INTO notifications (channel, destination, user_id, topics) VALUES($1, $2, $3, $4);
 
 -- name: GetNotificationDestinationsForChannel :many
 SELECT destination FROM notifications WHERE channel = $1;
 
+-- name: GetNotificationDestinationsWithTopicsForChannel :many
+SELECT destination, topics FROM notifications WHERE channel = $1;
+
 -- name: RemoveNotificationDestinationForChannel :exec
 DELETE FROM notifications WHERE channel = $1 AND destination = $2;
+
+-- name: GetNotificationsForUser :many
+SELECT channel, destination, created_at FROM notifications WHERE user_id = $1;
+
+-- name: RemoveNotificationsForUser :exec
+DELETE FROM notifications WHERE user_id = $1;
 -- name: AddAuthForUser :exec
 INSERT INTO auth(user_id, token, type) values($1, $2, $3);
 
@@ -70,16 +70,23 @@ func (q *Queries) AddFollower(ctx context.Context, arg AddFollowerParams) error
 }
 
 const addNotification = `-- name: AddNotification :exec
-INSERT INTO notifications (channel, destination) VALUES($1, $2)
+INSERT INTO notifications (channel, destination, user_id, topics) VALUES($1, $2, $3, $4)
 `
 
 type AddNotificationParams struct {
 	Channel     string
 	Destination string
+	UserID      sql.NullString
+	Topics      sql.NullString
 }
 
 func (q *Queries) AddNotification(ctx context.Context, arg AddNotificationParams) error {
-	_, err := q.db.ExecContext(ctx, addNotification, arg.Channel, arg.Destination)
+	_, err := q.db.ExecContext(ctx, addNotification,
+		arg.Channel,
+		arg.Destination,
+		arg.UserID,
+		arg.Topics,
+	)
 	return err
 }
 
@@ -140,16 +147,23 @@ func (q *Queries) ApproveFederationFollower(ctx context.Context, arg ApproveFede
 }
 
 const banIPAddress = `-- name: BanIPAddress :exec
-INSERT INTO ip_bans(ip_address, notes) values($1, $2)
+INSERT OR REPLACE INTO ip_bans(ip_address, notes, expires_at, banned_by) values($1, $2, $3, $4)
 `
 
 type BanIPAddressParams struct {
 	IpAddress string
 	Notes     sql.NullString
+	ExpiresAt sql.NullTime
+	BannedBy  sql.NullString
 }
 
 func (q *Queries) BanIPAddress(ctx context.Context, arg BanIPAddressParams) error {
-	_, err := q.db.ExecContext(ctx, banIPAddress, arg.IpAddress, arg.Notes)
+	_, err := q.db.ExecContext(ctx, banIPAddress,
+		arg.IpAddress,
+		arg.Notes,
+		arg.ExpiresAt,
+		arg.BannedBy,
+	)
 	return err
 }
 
@@ -320,7 +334,7 @@ func (q *Queries) GetFollowerCount(ctx context.Context) (int64, error) {
 }
 
 const getIPAddressBans = `-- name: GetIPAddressBans :many
-SELECT ip_address, notes, created_at FROM ip_bans
+SELECT ip_address, notes, created_at, expires_at, banned_by FROM ip_bans
 `
 
 func (q *Queries) GetIPAddressBans(ctx context.Context) ([]IpBan, error) {
@@ -332,7 +346,13 @@ func (q *Queries) GetIPAddressBans(ctx context.Context) ([]IpBan, error) {
 	var items []IpBan
 	for rows.Next() {
 		var i IpBan
-		if err := rows.Scan(&i.IpAddress, &i.Notes, &i.CreatedAt); err != nil {
+		if err := rows.Scan(
+			&i.IpAddress,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+			&i.BannedBy,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -439,6 +459,71 @@ func (q *Queries) GetNotificationDestinationsForChannel(ctx context.Context, cha
 	return items, nil
 }
 
+const getNotificationDestinationsWithTopicsForChannel = `-- name: GetNotificationDestinationsWithTopicsForChannel :many
+SELECT destination, topics FROM notifications WHERE channel = $1
+`
+
+type GetNotificationDestinationsWithTopicsForChannelRow struct {
+	Destination string
+	Topics      sql.NullString
+}
+
+func (q *Queries) GetNotificationDestinationsWithTopicsForChannel(ctx context.Context, channel string) ([]GetNotificationDestinationsWithTopicsForChannelRow, error) {
+	rows, err := q.db.QueryContext(ctx, getNotificationDestinationsWithTopicsForChannel, channel)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetNotificationDestinationsWithTopicsForChannelRow
+	for rows.Next() {
+		var i GetNotificationDestinationsWithTopicsForChannelRow
+		if err := rows.Scan(&i.Destination, &i.Topics); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNotificationsForUser = `-- name: GetNotificationsForUser :many
+SELECT channel, destination, created_at FROM notifications WHERE user_id = $1
+`
+
+type GetNotificationsForUserRow struct {
+	Channel     string
+	Destination string
+	CreatedAt   sql.NullTime
+}
+
+func (q *Queries) GetNotificationsForUser(ctx context.Context, userID sql.NullString) ([]GetNotificationsForUserRow, error) {
+	rows, err := q.db.QueryContext(ctx, getNotificationsForUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetNotificationsForUserRow
+	for rows.Next() {
+		var i GetNotificationsForUserRow
+		if err := rows.Scan(&i.Channel, &i.Destination, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getObjectFromOutboxByID = `-- name: GetObjectFromOutboxByID :one
 SELECT value FROM ap_outbox WHERE iri = $1
 `
@@ -635,17 +720,6 @@ func (q *Queries) IsDisplayNameAvailable(ctx context.Context, displayName string
 	return count, err
 }
 
-const isIPAddressBlocked = `-- name: IsIPAddressBlocked :one
-SELECT count(*) FROM ip_bans WHERE ip_address = $1
-`
-
-func (q *Queries) IsIPAddressBlocked(ctx context.Context, ipAddress string) (int64, error) {
-	row := q.db.QueryRowContext(ctx, isIPAddressBlocked, ipAddress)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
-}
-
 const rejectFederationFollower = `-- name: RejectFederationFollower :exec
 UPDATE ap_followers SET approved_at = null, disabled_at = $1 WHERE iri = $2
 `
@@ -692,6 +766,15 @@ func (q *Queries) RemoveNotificationDestinationForChannel(ctx context.Context, a
 	return err
 }
 
+const removeNotificationsForUser = `-- name: RemoveNotificationsForUser :exec
+DELETE FROM notifications WHERE user_id = $1
+`
+
+func (q *Queries) RemoveNotificationsForUser(ctx context.Context, userID sql.NullString) error {
+	_, err := q.db.ExecContext(ctx, removeNotificationsForUser, userID)
+	return err
+}
+
 const setAccessTokenToOwner = `-- name: SetAccessTokenToOwner :exec
 UPDATE user_access_tokens SET user_id = $1 WHERE token = $2
 `
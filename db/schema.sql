@@ -40,13 +40,17 @@ CREATE TABLE IF NOT EXISTS ap_accepted_activities (
   CREATE TABLE IF NOT EXISTS ip_bans (
     "ip_address" TEXT NOT NULL PRIMARY KEY,
     "notes" TEXT,
-    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    "expires_at" TIMESTAMP,
+    "banned_by" TEXT
   );
 
 CREATE TABLE IF NOT EXISTS notifications (
     "id" INTEGER NOT NULL PRIMARY KEY,
 		"channel" TEXT NOT NULL,
 		"destination" TEXT NOT NULL,
+		"user_id" TEXT,
+		"topics" TEXT,
 		"created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP);
 		CREATE INDEX channel_index ON notifications (channel);
 
@@ -68,7 +72,9 @@ CREATE TABLE IF NOT EXISTS users (
 CREATE TABLE IF NOT EXISTS user_access_tokens (
   "token" TEXT NOT NULL PRIMARY KEY,
   "user_id" TEXT NOT NULL,
-  "timestamp" DATE DEFAULT CURRENT_TIMESTAMP NOT NULL
+  "timestamp" DATE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  "expires_at" TIMESTAMP,
+  "allowed_ips" TEXT DEFAULT ''
 );
 
 CREATE TABLE IF NOT EXISTS auth (
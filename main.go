@@ -11,6 +11,7 @@ import (
 	"github.com/owncast/owncast/config"
 	"github.com/owncast/owncast/core"
 	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/pubsub"
 	"github.com/owncast/owncast/metrics"
 	"github.com/owncast/owncast/router"
 	"github.com/owncast/owncast/utils"
@@ -27,6 +28,8 @@ var (
 	webServerPortOverride = flag.String("webserverport", "", "Force the web server to listen on a specific port")
 	webServerIPOverride   = flag.String("webserverip", "", "Force web server to listen on this IP address")
 	rtmpPortOverride      = flag.Int("rtmpport", 0, "Set listen port for the RTMP server")
+	trustedProxies        = flag.String("trustedproxies", "", "Comma separated IP addresses or CIDR ranges of reverse proxies allowed to set X-Forwarded-For")
+	pubsubAddress         = flag.String("pubsub", "", "Share chat and viewers with other Owncast nodes using this Redis compatible server, eg. redis://localhost:6379")
 )
 
 func main() {
@@ -88,6 +91,14 @@ func main() {
 
 	handleCommandLineFlags()
 
+	if err := utils.SetTrustedProxies(*trustedProxies); err != nil {
+		log.Fatalln("invalid trusted proxy list", err)
+	}
+
+	if err := pubsub.Setup(*pubsubAddress); err != nil {
+		log.Fatalln("failed to connect to the pub/sub server", err)
+	}
+
 	// starts the core
 	if err := core.Start(); err != nil {
 		log.Fatalln("failed to start the core package", err)
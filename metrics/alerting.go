@@ -1,8 +1,10 @@
 package metrics
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/owncast/owncast/core/webhooks"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -36,6 +38,7 @@ func handleCPUAlerting() {
 	avg := recentAverage(metrics.CPUUtilizations)
 	if avg > maxCPUAlertingThresholdPCT && !inCPUAlertingState {
 		log.Warnf(alertingError, "CPU", avg)
+		sendHealthAlert("cpu", avg, maxCPUAlertingThresholdPCT, fmt.Sprintf(alertingError, "CPU", avg))
 		inCPUAlertingState = true
 
 		resetTimer := time.NewTimer(errorResetDuration)
@@ -54,6 +57,7 @@ func handleRAMAlerting() {
 	avg := recentAverage(metrics.RAMUtilizations)
 	if avg > maxRAMAlertingThresholdPCT && !inRAMAlertingState {
 		log.Warnf(alertingError, "memory", avg)
+		sendHealthAlert("memory", avg, maxRAMAlertingThresholdPCT, fmt.Sprintf(alertingError, "memory", avg))
 		inRAMAlertingState = true
 
 		resetTimer := time.NewTimer(errorResetDuration)
@@ -73,6 +77,7 @@ func handleDiskAlerting() {
 
 	if avg > maxDiskAlertingThresholdPCT && !inDiskAlertingState {
 		log.Warnf(alertingError, "disk", avg)
+		sendHealthAlert("disk", avg, maxDiskAlertingThresholdPCT, fmt.Sprintf(alertingError, "disk", avg))
 		inDiskAlertingState = true
 
 		resetTimer := time.NewTimer(errorResetDuration)
@@ -83,6 +88,15 @@ func handleDiskAlerting() {
 	}
 }
 
+func sendHealthAlert(kind string, value float64, threshold float64, message string) {
+	webhooks.SendHealthAlertEvent(webhooks.WebhookHealthAlert{
+		Kind:      kind,
+		Value:     value,
+		Threshold: threshold,
+		Message:   message,
+	})
+}
+
 func recentAverage(values []TimestampedValue) float64 {
 	return (values[len(values)-1].Value + values[len(values)-2].Value) / 2
 }
@@ -46,6 +46,11 @@ func generateStreamHealthOverview() {
 		overview.Representation = representation
 	}
 
+	// Alert when viewers start having playback problems.
+	if !overview.Healthy && (metrics.streamHealthOverview == nil || metrics.streamHealthOverview.Healthy) {
+		sendHealthAlert("playback", float64(overview.HealthyPercentage), healthyPercentageMinValue, overview.Message)
+	}
+
 	metrics.streamHealthOverview = overview
 }
 
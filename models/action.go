@@ -0,0 +1,49 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	// ActionAnnouncement shows an announcement in chat.
+	ActionAnnouncement = "ANNOUNCEMENT"
+	// ActionStartPoll starts a chat poll.
+	ActionStartPoll = "START_POLL"
+	// ActionSwitchOfflineClip changes the clip shown while offline.
+	ActionSwitchOfflineClip = "SWITCH_OFFLINE_CLIP"
+	// ActionSetTags replaces the server tags.
+	ActionSetTags = "SET_TAGS"
+	// ActionSetChatDisabled disables or enables chat.
+	ActionSetChatDisabled = "SET_CHAT_DISABLED"
+	// ActionDisconnectBroadcaster disconnects the inbound stream.
+	ActionDisconnectBroadcaster = "DISCONNECT_BROADCASTER"
+)
+
+// ActionParameters are the values used by an action. Each action only reads
+// the parameters that apply to it.
+type ActionParameters struct {
+	// Text is the announcement to show.
+	Text string `json:"text,omitempty"`
+	// Question, Options and Duration in seconds describe a poll.
+	Question string   `json:"question,omitempty"`
+	Options  []string `json:"options,omitempty"`
+	Duration int      `json:"duration,omitempty"`
+	// Clip is the name of the offline clip to show. Empty is the default clip.
+	Clip string `json:"clip,omitempty"`
+	// Tags replace the server tags.
+	Tags []string `json:"tags,omitempty"`
+	// Disabled sets if chat is disabled. Chat is toggled if it is not set.
+	Disabled *bool `json:"disabled,omitempty"`
+}
+
+// ActionAuditEntry records an attempt to trigger an action.
+type ActionAuditEntry struct {
+	ID         int             `json:"id"`
+	Action     string          `json:"action"`
+	Actor      string          `json:"actor"`
+	Parameters json.RawMessage `json:"parameters"`
+	Success    bool            `json:"success"`
+	Result     string          `json:"result"`
+	Timestamp  time.Time       `json:"timestamp"`
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// Broadcast is a single past or current live stream.
+type Broadcast struct {
+	ID        int        `json:"id"`
+	StartedAt time.Time  `json:"startedAt"`
+	EndedAt   *time.Time `json:"endedAt,omitempty"`
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const maxChatCommandResponseLength = 500
+
+var chatCommandTriggerRegex = regexp.MustCompile(`^![a-z0-9_-]{1,32}$`)
+
+// ChatCommandVariables are replaced in a chat command response.
+var ChatCommandVariables = []string{
+	"{user}",
+	"{serverName}",
+	"{streamTitle}",
+	"{viewerCount}",
+	"{uptime}",
+}
+
+// ChatCommand is a response sent to chat when a message starts with its
+// trigger, such as !socials.
+type ChatCommand struct {
+	ID       string `json:"id"`
+	Trigger  string `json:"trigger"`
+	Response string `json:"response"`
+	// CooldownSeconds is how long after being used the command is ignored.
+	CooldownSeconds int `json:"cooldownSeconds"`
+	// RoleID limits the command to moderators and users with the role.
+	RoleID    string    `json:"roleId,omitempty"`
+	Enabled   bool      `json:"enabled"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// NormalizeChatCommandTrigger will return the lowercase form of a trigger
+// beginning with an exclamation mark.
+func NormalizeChatCommandTrigger(trigger string) string {
+	trigger = strings.ToLower(strings.TrimSpace(trigger))
+	if trigger != "" && !strings.HasPrefix(trigger, "!") {
+		trigger = "!" + trigger
+	}
+
+	return trigger
+}
+
+// Validate will verify the chat command can be saved.
+func (c ChatCommand) Validate() error {
+	if !chatCommandTriggerRegex.MatchString(c.Trigger) {
+		return errors.New("command triggers must be ! followed by up to 32 letters, numbers, dashes or underscores")
+	}
+
+	if strings.TrimSpace(c.Response) == "" {
+		return errors.New("a command must have a response")
+	}
+
+	if utf8.RuneCountInString(c.Response) > maxChatCommandResponseLength {
+		return fmt.Errorf("command responses must be %d characters or less", maxChatCommandResponseLength)
+	}
+
+	if c.CooldownSeconds < 0 || c.CooldownSeconds > 3600 {
+		return errors.New("command cooldowns must be between 0 and 3600 seconds")
+	}
+
+	return nil
+}
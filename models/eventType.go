@@ -24,4 +24,34 @@ const (
 	SystemMessageSent EventType = "SYSTEM"
 	// ChatActionSent is a generic chat action that can be used for anything that doesn't need specific handling or formatting.
 	ChatActionSent EventType = "CHAT_ACTION"
+	// RaidProtectionStarted is the event sent when chat is automatically protected from a raid.
+	RaidProtectionStarted EventType = "RAID_PROTECTION_STARTED"
+	// RaidProtectionEnded is the event sent when chat raid protection has ended.
+	RaidProtectionEnded EventType = "RAID_PROTECTION_ENDED"
+	// UserDisabled is the event sent when a chat user is banned.
+	UserDisabled EventType = "USER_DISABLED"
+	// UserEnabled is the event sent when a chat user is unbanned.
+	UserEnabled EventType = "USER_ENABLED"
+	// ModeratorGranted is the event sent when a chat user is made a moderator.
+	ModeratorGranted EventType = "MODERATOR_GRANTED"
+	// ModeratorRevoked is the event sent when a chat user is no longer a moderator.
+	ModeratorRevoked EventType = "MODERATOR_REVOKED"
+	// IPAddressBanned is the event sent when an IP address or range is banned.
+	IPAddressBanned EventType = "IP_ADDRESS_BANNED"
+	// IPAddressUnbanned is the event sent when an IP address ban is removed.
+	IPAddressUnbanned EventType = "IP_ADDRESS_UNBANNED"
+	// FediverseFollow is the event sent when a fediverse account follows this server.
+	FediverseFollow EventType = "FEDIVERSE_ENGAGEMENT_FOLLOW"
+	// FediverseLike is the event sent when a fediverse account likes a post from this server.
+	FediverseLike EventType = "FEDIVERSE_ENGAGEMENT_LIKE"
+	// FediverseRepost is the event sent when a fediverse account shares a post from this server.
+	FediverseRepost EventType = "FEDIVERSE_ENGAGEMENT_REPOST"
+	// FediverseFollowRequest is the event sent when a fediverse follow needs to be approved.
+	FediverseFollowRequest EventType = "FEDIVERSE_FOLLOW_REQUEST"
+	// StreamTitleChanged is the event sent when the stream title changes.
+	StreamTitleChanged EventType = "STREAM_TITLE_CHANGED"
+	// TranscoderError is the event sent when the video transcoder reports an error.
+	TranscoderError EventType = "TRANSCODER_ERROR"
+	// HealthAlert is the event sent when server resources or viewer playback become unhealthy.
+	HealthAlert EventType = "HEALTH_ALERT"
 )
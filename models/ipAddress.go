@@ -1,10 +1,37 @@
 package models
 
-import "time"
+import (
+	"net"
+	"strings"
+	"time"
+)
 
 // IPAddress is a simple representation of an IP address.
 type IPAddress struct {
-	IPAddress string    `json:"ipAddress"`
-	Notes     string    `json:"notes"`
-	CreatedAt time.Time `json:"createdAt"`
+	// IPAddress is a single address or a CIDR range of addresses.
+	IPAddress string     `json:"ipAddress"`
+	Notes     string     `json:"notes"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	BannedBy  string     `json:"bannedBy,omitempty"`
+}
+
+// IsExpired will return if a ban on this address has ended.
+func (i IPAddress) IsExpired() bool {
+	return i.ExpiresAt != nil && time.Now().After(*i.ExpiresAt)
+}
+
+// Contains will return if an address is this address or within this range.
+func (i IPAddress) Contains(address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return i.IPAddress == address
+	}
+
+	if strings.Contains(i.IPAddress, "/") {
+		_, network, err := net.ParseCIDR(i.IPAddress)
+		return err == nil && network.Contains(ip)
+	}
+
+	return ip.Equal(net.ParseIP(i.IPAddress))
 }
@@ -1,18 +1,23 @@
 package models
 
+import "time"
+
 // DiscordConfiguration represents the configuration for the discord
 // notification service.
 type DiscordConfiguration struct {
 	Enabled       bool   `json:"enabled"`
 	Webhook       string `json:"webhook,omitempty"`
 	GoLiveMessage string `json:"goLiveMessage,omitempty"`
+	// GoLiveTemplate is a Go text template for the go-live message.
+	GoLiveTemplate string `json:"goLiveTemplate,omitempty"`
 }
 
 // BrowserNotificationConfiguration represents the configuration for
 // browser notifications.
 type BrowserNotificationConfiguration struct {
-	Enabled       bool   `json:"enabled"`
-	GoLiveMessage string `json:"goLiveMessage,omitempty"`
+	Enabled        bool   `json:"enabled"`
+	GoLiveMessage  string `json:"goLiveMessage,omitempty"`
+	GoLiveTemplate string `json:"goLiveTemplate,omitempty"`
 }
 
 // TwitterConfiguration represents the configuration for Twitter access.
@@ -24,4 +29,106 @@ type TwitterConfiguration struct {
 	AccessTokenSecret string `json:"accessTokenSecret"`
 	BearerToken       string `json:"bearerToken"`
 	GoLiveMessage     string `json:"goLiveMessage,omitempty"`
+	GoLiveTemplate    string `json:"goLiveTemplate,omitempty"`
+}
+
+// MatrixConfiguration represents the configuration for posting to Matrix
+// rooms.
+type MatrixConfiguration struct {
+	Enabled bool `json:"enabled"`
+	// Homeserver is the base URL of the Matrix homeserver, such as https://matrix.org.
+	Homeserver  string `json:"homeserver,omitempty"`
+	AccessToken string `json:"accessToken,omitempty"`
+	// Rooms are room IDs or aliases to post to.
+	Rooms          []string `json:"rooms,omitempty"`
+	GoLiveMessage  string   `json:"goLiveMessage,omitempty"`
+	GoLiveTemplate string   `json:"goLiveTemplate,omitempty"`
+	// NotifyStreamEnded will also post StreamEndedMessage when the stream ends.
+	NotifyStreamEnded  bool   `json:"notifyStreamEnded"`
+	StreamEndedMessage string `json:"streamEndedMessage,omitempty"`
+}
+
+// EmailConfiguration represents the configuration for sending go-live
+// emails over SMTP.
+type EmailConfiguration struct {
+	Enabled     bool   `json:"enabled"`
+	SMTPHost    string `json:"smtpHost,omitempty"`
+	SMTPPort    int    `json:"smtpPort,omitempty"`
+	Username    string `json:"username,omitempty"`
+	Password    string `json:"password,omitempty"`
+	FromAddress string `json:"fromAddress,omitempty"`
+	// GoLiveSubject and GoLiveTemplate are Go text templates for the go-live
+	// email. They can use the same fields as other go-live message templates.
+	GoLiveSubject  string `json:"goLiveSubject,omitempty"`
+	GoLiveTemplate string `json:"goLiveTemplate,omitempty"`
+}
+
+// NtfyConfiguration represents the configuration for publishing to an ntfy
+// topic.
+type NtfyConfiguration struct {
+	Enabled bool `json:"enabled"`
+	// Server is the base URL of the ntfy server. The public server is used if
+	// it is empty.
+	Server      string `json:"server,omitempty"`
+	Topic       string `json:"topic,omitempty"`
+	AccessToken string `json:"accessToken,omitempty"`
+	// GoLiveTemplate is a Go text template for the go-live message.
+	GoLiveTemplate string `json:"goLiveTemplate,omitempty"`
+}
+
+// GotifyConfiguration represents the configuration for pushing to a Gotify
+// application.
+type GotifyConfiguration struct {
+	Enabled        bool   `json:"enabled"`
+	Server         string `json:"server,omitempty"`
+	AppToken       string `json:"appToken,omitempty"`
+	Priority       int    `json:"priority"`
+	GoLiveTemplate string `json:"goLiveTemplate,omitempty"`
+}
+
+// TelegramConfiguration represents the configuration for posting to
+// Telegram chats with a bot.
+type TelegramConfiguration struct {
+	Enabled  bool   `json:"enabled"`
+	BotToken string `json:"botToken,omitempty"`
+	// ChatIDs are chat IDs or @usernames of public channels to post to.
+	ChatIDs        []string `json:"chatIds,omitempty"`
+	GoLiveTemplate string   `json:"goLiveTemplate,omitempty"`
+}
+
+// MastodonConfiguration represents the configuration for posting a status
+// to a Mastodon account.
+type MastodonConfiguration struct {
+	Enabled     bool   `json:"enabled"`
+	Server      string `json:"server,omitempty"`
+	AccessToken string `json:"accessToken,omitempty"`
+	// Visibility is public, unlisted, private or direct.
+	Visibility     string `json:"visibility,omitempty"`
+	GoLiveTemplate string `json:"goLiveTemplate,omitempty"`
+}
+
+// NotificationThrottleConfiguration limits how often go-live notifications
+// are sent.
+type NotificationThrottleConfiguration struct {
+	// ReconnectSuppressionMinutes skips go-live notifications for a stream
+	// that starts less than this many minutes after an announced stream
+	// ended, such as when an encoder reconnects.
+	ReconnectSuppressionMinutes int `json:"reconnectSuppressionMinutes"`
+	// MinimumIntervals is the fewest minutes between go-live notifications
+	// for each channel, such as "twitter".
+	MinimumIntervals map[string]int `json:"minimumIntervals,omitempty"`
+}
+
+// BrowserPushDelivery summarizes sending a browser push notification to
+// every subscriber of a topic.
+type BrowserPushDelivery struct {
+	Topic       string    `json:"topic"`
+	SentAt      time.Time `json:"sentAt"`
+	DurationMs  int64     `json:"durationMs"`
+	Subscribers int       `json:"subscribers"`
+	Delivered   int       `json:"delivered"`
+	Failed      int       `json:"failed"`
+	// Removed is how many subscriptions the push service said no longer
+	// exist, which were deleted.
+	Removed int `json:"removed"`
 }
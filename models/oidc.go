@@ -0,0 +1,15 @@
+package models
+
+// OIDCConfiguration represents the configuration for logging in to chat
+// with an OpenID Connect identity provider.
+type OIDCConfiguration struct {
+	Enabled bool `json:"enabled"`
+	// Name of the identity provider shown to chat users.
+	Name         string   `json:"name,omitempty"`
+	Issuer       string   `json:"issuer"`
+	ClientID     string   `json:"clientId"`
+	ClientSecret string   `json:"clientSecret"`
+	Scopes       []string `json:"scopes,omitempty"`
+	// DisplayNameClaim is the claim used as the chat display name.
+	DisplayNameClaim string `json:"displayNameClaim,omitempty"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// Poll is a question asked in chat with a fixed set of options that each
+// chat user can vote on once.
+type Poll struct {
+	ID        string     `json:"id"`
+	Question  string     `json:"question"`
+	Options   []string   `json:"options"`
+	Votes     []int      `json:"votes"`
+	CreatedBy string     `json:"createdBy"`
+	CreatedAt time.Time  `json:"createdAt"`
+	EndsAt    time.Time  `json:"endsAt"`
+	ClosedAt  *time.Time `json:"closedAt,omitempty"`
+}
+
+// TotalVotes will return the number of votes cast across all options.
+func (p *Poll) TotalVotes() int {
+	total := 0
+	for _, count := range p.Votes {
+		total += count
+	}
+	return total
+}
+
+// PollVote is the option a single user voted for in a poll.
+type PollVote struct {
+	PollID    string    `json:"pollId"`
+	Option    int       `json:"option"`
+	Timestamp time.Time `json:"timestamp"`
+}
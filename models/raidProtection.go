@@ -0,0 +1,39 @@
+package models
+
+import "errors"
+
+// RaidProtectionConfiguration represents the thresholds that automatically
+// put chat into a protective state, and what that state restricts. A
+// threshold of zero is never exceeded.
+type RaidProtectionConfiguration struct {
+	Enabled bool `json:"enabled"`
+	// WindowSeconds is how far back registrations, connections and
+	// duplicate messages are counted.
+	WindowSeconds             int `json:"windowSeconds"`
+	NewUserThreshold          int `json:"newUserThreshold"`
+	ConnectionThreshold       int `json:"connectionThreshold"`
+	DuplicateMessageThreshold int `json:"duplicateMessageThreshold"`
+	// CooldownSeconds is how long chat stays protected after the last time
+	// a threshold was exceeded.
+	CooldownSeconds      int  `json:"cooldownSeconds"`
+	SlowModeDuration     int  `json:"slowModeDuration"`
+	BlockLinks           bool `json:"blockLinks"`
+	EstablishedUsersOnly bool `json:"establishedUsersOnly"`
+}
+
+// Validate will verify the thresholds and durations are usable.
+func (c RaidProtectionConfiguration) Validate() error {
+	if c.WindowSeconds < 1 || c.WindowSeconds > 300 {
+		return errors.New("the raid protection window must be between 1 and 300 seconds")
+	}
+
+	if c.CooldownSeconds < 10 || c.CooldownSeconds > 86400 {
+		return errors.New("the raid protection cooldown must be between 10 seconds and one day")
+	}
+
+	if c.NewUserThreshold < 0 || c.ConnectionThreshold < 0 || c.DuplicateMessageThreshold < 0 || c.SlowModeDuration < 0 {
+		return errors.New("raid protection thresholds and slow mode cannot be negative")
+	}
+
+	return nil
+}
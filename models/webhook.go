@@ -8,11 +8,15 @@ import (
 
 // Webhook is an event that is sent to 3rd party, external services with details about something that took place within an Owncast server.
 type Webhook struct {
-	ID        int         `json:"id"`
-	URL       string      `json:"url"`
-	Events    []EventType `json:"events"`
-	Timestamp time.Time   `json:"timestamp"`
-	LastUsed  *time.Time  `json:"lastUsed"`
+	ID           int         `json:"id"`
+	URL          string      `json:"url"`
+	Events       []EventType `json:"events"`
+	Timestamp    time.Time   `json:"timestamp"`
+	LastUsed     *time.Time  `json:"lastUsed"`
+	Secret       string      `json:"-"`
+	FailureCount int         `json:"failureCount"`
+	DisabledAt   *time.Time  `json:"disabledAt"`
+	WebhookOptions
 }
 
 // For an event to be seen as "valid" it must live in this slice.
@@ -23,6 +27,21 @@ var validEvents = []EventType{
 	VisibiltyToggled,
 	StreamStarted,
 	StreamStopped,
+	RaidProtectionStarted,
+	RaidProtectionEnded,
+	UserDisabled,
+	UserEnabled,
+	ModeratorGranted,
+	ModeratorRevoked,
+	IPAddressBanned,
+	IPAddressUnbanned,
+	FediverseFollow,
+	FediverseLike,
+	FediverseRepost,
+	FediverseFollowRequest,
+	StreamTitleChanged,
+	TranscoderError,
+	HealthAlert,
 }
 
 // HasValidEvents will verify that all the events provided are valid.
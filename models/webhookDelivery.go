@@ -0,0 +1,34 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	// WebhookDeliveryPending is a delivery that has not been sent successfully
+	// yet and will be attempted again.
+	WebhookDeliveryPending = "pending"
+	// WebhookDeliveryDelivered is a delivery the webhook accepted.
+	WebhookDeliveryDelivered = "delivered"
+	// WebhookDeliveryFailed is a delivery that failed every attempt.
+	WebhookDeliveryFailed = "failed"
+)
+
+// WebhookDelivery is a single event sent to a webhook and the result of the
+// latest attempt to send it.
+type WebhookDelivery struct {
+	ID            string          `json:"id"`
+	WebhookID     int             `json:"webhookId"`
+	EventType     EventType       `json:"eventType"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	StatusCode    int             `json:"statusCode,omitempty"`
+	LatencyMS     int64           `json:"latencyMs,omitempty"`
+	Response      string          `json:"response,omitempty"`
+	Error         string          `json:"error,omitempty"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	LastAttemptAt *time.Time      `json:"lastAttemptAt,omitempty"`
+	NextAttemptAt *time.Time      `json:"nextAttemptAt,omitempty"`
+}
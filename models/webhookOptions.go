@@ -0,0 +1,85 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/owncast/owncast/utils"
+)
+
+const (
+	// WebhookFilterEquals matches a field with exactly the value.
+	WebhookFilterEquals = "equals"
+	// WebhookFilterNotEquals matches a field without exactly the value.
+	WebhookFilterNotEquals = "notEquals"
+	// WebhookFilterContains matches a text field containing the value, or a
+	// list field with an item equal to the value.
+	WebhookFilterContains = "contains"
+	// WebhookFilterNotContains is the opposite of WebhookFilterContains.
+	WebhookFilterNotContains = "notContains"
+	// WebhookFilterExists matches a field that is set.
+	WebhookFilterExists = "exists"
+	// WebhookFilterNotExists matches a field that is not set.
+	WebhookFilterNotExists = "notExists"
+)
+
+const (
+	// WebhookContentTypeJSON is the default webhook request content type.
+	WebhookContentTypeJSON = "application/json"
+	// WebhookContentTypeForm is used for templates that produce form-encoded values.
+	WebhookContentTypeForm = "application/x-www-form-urlencoded"
+	// WebhookContentTypeText is used for templates that produce plain text.
+	WebhookContentTypeText = "text/plain"
+)
+
+const maxWebhookFilters = 20
+
+var (
+	validWebhookFilterOperators = []string{WebhookFilterEquals, WebhookFilterNotEquals, WebhookFilterContains, WebhookFilterNotContains, WebhookFilterExists, WebhookFilterNotExists}
+	validWebhookContentTypes    = []string{WebhookContentTypeJSON, WebhookContentTypeForm, WebhookContentTypeText}
+)
+
+// WebhookFilter is a condition an event must meet to be sent to a webhook.
+type WebhookFilter struct {
+	// Field is a dotted path into the event, such as eventData.user.scopes.
+	Field    string `json:"field"`
+	Operator string `json:"operator"`
+	Value    string `json:"value,omitempty"`
+}
+
+// WebhookOptions change which events are sent to a webhook and what is sent.
+type WebhookOptions struct {
+	// Filters must all match an event for it to be sent.
+	Filters []WebhookFilter `json:"filters"`
+	// Template is a Go text/template that replaces the event as the request
+	// body. It is given the event as it would otherwise be sent.
+	Template    string `json:"template,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// Validate will verify the webhook options can be saved. The template is
+// verified by the webhooks package.
+func (o WebhookOptions) Validate() error {
+	if len(o.Filters) > maxWebhookFilters {
+		return fmt.Errorf("a webhook can have up to %d filters", maxWebhookFilters)
+	}
+
+	for _, filter := range o.Filters {
+		if strings.TrimSpace(filter.Field) == "" {
+			return errors.New("webhook filters must have a field")
+		}
+
+		if _, valid := utils.FindInSlice(validWebhookFilterOperators, filter.Operator); !valid {
+			return fmt.Errorf("%s is not a valid webhook filter operator", filter.Operator)
+		}
+	}
+
+	if o.ContentType != "" {
+		if _, valid := utils.FindInSlice(validWebhookContentTypes, o.ContentType); !valid {
+			return fmt.Errorf("%s is not a supported webhook content type", o.ContentType)
+		}
+	}
+
+	return nil
+}
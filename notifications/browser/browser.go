@@ -2,12 +2,22 @@ package browser
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/http"
 
 	"github.com/SherClockHolmes/webpush-go"
 	"github.com/owncast/owncast/core/data"
 	"github.com/pkg/errors"
 )
 
+// How many seconds the push service should hold a notification for a
+// browser that is offline. Go-live notifications are stale quickly.
+var ttls = map[string]int{
+	"go-live":       120,
+	"reminders":     60 * 60,
+	"announcements": 24 * 60 * 60,
+}
+
 // Browser is an instance of the Browser service.
 type Browser struct {
 	datastore  *data.Datastore
@@ -35,8 +45,11 @@ func GenerateBrowserPushKeys() (string, string, error) {
 }
 
 // Send will send a browser push notification to the given subscription.
+// It returns true if the push service says the subscription no longer
+// exists and it should be removed.
 func (b *Browser) Send(
 	subscription string,
+	topic string,
 	title string,
 	body string,
 ) (bool, error) {
@@ -44,12 +57,14 @@ func (b *Browser) Send(
 		Title string `json:"title"`
 		Body  string `json:"body"`
 		Icon  string `json:"icon"`
+		Tag   string `json:"tag,omitempty"`
 	}
 
 	m := message{
 		Title: title,
 		Body:  body,
 		Icon:  "/logo/external",
+		Tag:   topic,
 	}
 
 	d, err := json.Marshal(m)
@@ -67,17 +82,24 @@ func (b *Browser) Send(
 	resp, err := webpush.SendNotification(d, s, &webpush.Options{
 		VAPIDPublicKey:  b.publicKey,
 		VAPIDPrivateKey: b.privateKey,
-		Topic:           "owncast-go-live",
-		TTL:             120,
+		// A newer notification with the same topic replaces one that has not
+		// been delivered yet.
+		Topic: "owncast-" + topic,
+		TTL:   ttls[topic],
 		// Not really the subscriber, but a contact point for the sender.
 		Subscriber: "owncast@owncast.online",
 	})
-	if resp.StatusCode == 410 {
-		return true, nil
-	} else if err != nil {
+	if err != nil {
 		return false, errors.Wrap(err, "error sending browser push notification")
 	}
 	defer resp.Body.Close()
 
-	return false, err
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return true, nil
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return false, fmt.Errorf("push service responded with %d", resp.StatusCode)
+	}
+
+	return false, nil
 }
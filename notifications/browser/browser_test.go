@@ -0,0 +1,83 @@
+package browser
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func subscription(t *testing.T, endpoint string) string {
+	_, x, y, err := elliptic.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	auth := make([]byte, 16)
+	if _, err := rand.Read(auth); err != nil {
+		t.Fatal(err)
+	}
+
+	s, _ := json.Marshal(map[string]interface{}{
+		"endpoint": endpoint,
+		"keys": map[string]string{
+			//nolint:staticcheck
+			"p256dh": base64.RawURLEncoding.EncodeToString(elliptic.Marshal(elliptic.P256(), x, y)),
+			"auth":   base64.RawURLEncoding.EncodeToString(auth),
+		},
+	})
+
+	return string(s)
+}
+
+func TestSend(t *testing.T) {
+	var topics []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		topics = append(topics, r.Header.Get("Topic"))
+
+		switch r.URL.Path {
+		case "/expired":
+			w.WriteHeader(http.StatusGone)
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer server.Close()
+
+	privateKey, publicKey, err := GenerateBrowserPushKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := New(nil, publicKey, privateKey)
+
+	for _, test := range []struct {
+		path string
+		gone bool
+		err  bool
+	}{
+		{"/ok", false, false},
+		{"/expired", true, false},
+		{"/missing", true, false},
+		{"/broken", false, true},
+	} {
+		gone, err := b.Send(subscription(t, server.URL+test.path), "announcements", "My Stream", "Hello")
+		if gone != test.gone || (err != nil) != test.err {
+			t.Errorf("%s: expected gone %v and error %v, got %v and %v", test.path, test.gone, test.err, gone, err)
+		}
+	}
+
+	if topics[0] != "owncast-announcements" {
+		t.Errorf("expected the push topic to be sent, got %q", topics[0])
+	}
+
+	if _, err := b.Send(subscription(t, "http://127.0.0.1:1/unreachable"), "go-live", "My Stream", "Hello"); err == nil {
+		t.Error("expected an error for an unreachable push service")
+	}
+}
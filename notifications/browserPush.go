@@ -0,0 +1,137 @@
+package notifications
+
+import (
+	"sync"
+	"time"
+
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/notifications/browser"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// How many browser push notifications are sent at the same time.
+const browserPushWorkers = 16
+
+func (n *Notifier) setupBrowserPush() (Channel, error) {
+	if !data.GetBrowserPushConfig().Enabled {
+		return nil, nil
+	}
+
+	browserNotifier, err := newBrowserPush(n.datastore)
+	if err != nil {
+		return nil, err
+	}
+
+	return browserPushChannel{browser: browserNotifier}, nil
+}
+
+func newBrowserPush(datastore *data.Datastore) (*browser.Browser, error) {
+	publicKey, err := data.GetBrowserPushPublicKey()
+	if err != nil || publicKey == "" {
+		return nil, errors.Wrap(err, "browser notifier disabled, failed to get browser push public key")
+	}
+
+	privateKey, err := data.GetBrowserPushPrivateKey()
+	if err != nil || privateKey == "" {
+		return nil, errors.Wrap(err, "browser notifier disabled, failed to get browser push private key")
+	}
+
+	browserNotifier, err := browser.New(datastore, publicKey, privateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating browser notifier")
+	}
+
+	return browserNotifier, nil
+}
+
+type browserPushChannel struct {
+	browser *browser.Browser
+}
+
+func (c browserPushChannel) Send(notification Notification) error {
+	message, err := browserPushTemplate().Render(notification)
+	if err != nil {
+		return err
+	}
+
+	_, err = sendBrowserPush(c.browser, GoLiveTopic, notification.ServerName, message)
+	return err
+}
+
+// SendTest will not push to every subscribed browser.
+func (c browserPushChannel) SendTest(notification Notification) error {
+	return errors.New("test notifications are not sent to subscribed browsers")
+}
+
+// SendBrowserPushAnnouncement will push a message to every browser
+// subscribed to a topic, such as announcements.
+func SendBrowserPushAnnouncement(topic string, title string, body string) (models.BrowserPushDelivery, error) {
+	if !data.GetBrowserPushConfig().Enabled {
+		return models.BrowserPushDelivery{}, errors.New("browser push notifications are not enabled")
+	}
+
+	browserNotifier, err := newBrowserPush(data.GetDatastore())
+	if err != nil {
+		return models.BrowserPushDelivery{}, err
+	}
+
+	return sendBrowserPush(browserNotifier, topic, title, body)
+}
+
+// sendBrowserPush will push to the subscribers of a topic a few at a time,
+// removing subscriptions that no longer exist, and save how it went.
+func sendBrowserPush(b *browser.Browser, topic string, title string, body string) (models.BrowserPushDelivery, error) {
+	delivery := models.BrowserPushDelivery{Topic: topic, SentAt: time.Now()}
+
+	destinations, err := GetNotificationDestinationsForTopic(BrowserPushNotification, topic)
+	if err != nil {
+		return delivery, errors.Wrap(err, "error getting browser push notification destinations")
+	}
+	delivery.Subscribers = len(destinations)
+
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	queue := make(chan string)
+
+	for i := 0; i < browserPushWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for destination := range queue {
+				gone, err := b.Send(destination, topic, title, body)
+				if gone {
+					err = RemoveNotificationForChannel(BrowserPushNotification, destination)
+				}
+				if err != nil {
+					log.Errorln(err)
+				}
+
+				lock.Lock()
+				switch {
+				case gone:
+					delivery.Removed++
+				case err != nil:
+					delivery.Failed++
+				default:
+					delivery.Delivered++
+				}
+				lock.Unlock()
+			}
+		}()
+	}
+
+	for _, destination := range destinations {
+		queue <- destination
+	}
+	close(queue)
+	wg.Wait()
+
+	delivery.DurationMs = time.Since(delivery.SentAt).Milliseconds()
+	if err := data.AddBrowserPushDelivery(delivery); err != nil {
+		log.Errorln(err)
+	}
+
+	return delivery, nil
+}
@@ -3,4 +3,19 @@ package notifications
 const (
 	// BrowserPushNotification represents a push notification for a browser.
 	BrowserPushNotification = "BROWSER_PUSH_NOTIFICATION"
+	// EmailNotification represents an email sent over SMTP.
+	EmailNotification = "EMAIL"
 )
+
+// Topics that browser push subscribers can choose to be sent.
+const (
+	// GoLiveTopic is sent when the stream goes live.
+	GoLiveTopic = "go-live"
+	// RemindersTopic is sent ahead of scheduled streams.
+	RemindersTopic = "reminders"
+	// AnnouncementsTopic is sent by the admin.
+	AnnouncementsTopic = "announcements"
+)
+
+// Topics are every topic a subscriber can choose.
+var Topics = []string{GoLiveTopic, RemindersTopic, AnnouncementsTopic}
@@ -0,0 +1,91 @@
+package email
+
+import (
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/owncast/owncast/models"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultGoLiveSubject is the subject of the go-live email when the admin
+	// has not set one.
+	DefaultGoLiveSubject = "{{.ServerName}} is live"
+	// DefaultGoLiveTemplate is the body of the go-live email when the admin
+	// has not set one.
+	DefaultGoLiveTemplate = "{{.ServerName}} is streaming now.\n{{if .StreamTitle}}\n{{.StreamTitle}}\n{{end}}\nWatch at {{.URL}}"
+)
+
+// Email is an instance of the email service.
+type Email struct {
+	config models.EmailConfiguration
+}
+
+// New will create a new instance of the email service.
+func New(config models.EmailConfiguration) (*Email, error) {
+	if err := Validate(config); err != nil {
+		return nil, err
+	}
+
+	return &Email{config: config}, nil
+}
+
+// Validate will return an error if emails can not be sent with a
+// configuration. The go-live templates are validated with the other
+// notification templates.
+func Validate(config models.EmailConfiguration) error {
+	if config.SMTPHost == "" || config.SMTPPort < 1 || config.SMTPPort > 65535 {
+		return errors.New("an smtp host and port are required")
+	}
+
+	if _, err := mail.ParseAddress(config.FromAddress); err != nil {
+		return errors.Wrap(err, "a valid from address is required")
+	}
+
+	return nil
+}
+
+// Send will send a plain text email. If unsubscribeURL is set the email can
+// be unsubscribed from with one click.
+func (e *Email) Send(to string, subject string, body string, unsubscribeURL string) error {
+	headers := []string{
+		"From: " + e.config.FromAddress,
+		"To: " + to,
+		"Subject: " + mime.QEncoding.Encode("utf-8", subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=utf-8",
+		"Content-Transfer-Encoding: 8bit",
+	}
+
+	if unsubscribeURL != "" {
+		headers = append(headers,
+			"List-Unsubscribe: <"+unsubscribeURL+">",
+			"List-Unsubscribe-Post: List-Unsubscribe=One-Click",
+		)
+		body += "\n\nUnsubscribe: " + unsubscribeURL
+	}
+
+	// SMTP requires CRLF line endings.
+	message := strings.Join(headers, "\r\n") + "\r\n\r\n" + strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n") + "\r\n"
+
+	var auth smtp.Auth
+	if e.config.Username != "" {
+		auth = smtp.PlainAuth("", e.config.Username, e.config.Password, e.config.SMTPHost)
+	}
+
+	from, _ := mail.ParseAddress(e.config.FromAddress)
+	address := net.JoinHostPort(e.config.SMTPHost, strconv.Itoa(e.config.SMTPPort))
+	if err := smtp.SendMail(address, auth, from.Address, []string{to}, []byte(message)); err != nil {
+		return fmt.Errorf("error sending email to %s: %w", to, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package email
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/models"
+)
+
+// smtpSink accepts a single email and returns its data.
+func smtpSink(t *testing.T) (int, <-chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	received := make(chan string, 1)
+	go func() {
+		defer listener.Close()
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+		reply("220 sink")
+
+		var message strings.Builder
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			command := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				reply("250 sink")
+			case command == "DATA":
+				reply("354 go ahead")
+				for {
+					dataLine, err := reader.ReadString('\n')
+					if err != nil || dataLine == ".\r\n" {
+						break
+					}
+					message.WriteString(dataLine)
+				}
+				received <- message.String()
+				reply("250 ok")
+			case command == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+
+	return listener.Addr().(*net.TCPAddr).Port, received
+}
+
+func TestSend(t *testing.T) {
+	port, received := smtpSink(t)
+
+	e, err := New(models.EmailConfiguration{
+		Enabled:     true,
+		SMTPHost:    "127.0.0.1",
+		SMTPPort:    port,
+		FromAddress: "Owncast <live@example.com>",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := e.Send("viewer@example.com", "My Stream is live", "My Stream: Games\nhttps://example.com", "https://example.com/unsubscribe"); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case message := <-received:
+		for _, expected := range []string{
+			"To: viewer@example.com\r\n",
+			"Subject: My Stream is live\r\n",
+			"List-Unsubscribe: <https://example.com/unsubscribe>\r\n",
+			"List-Unsubscribe-Post: List-Unsubscribe=One-Click\r\n",
+			"\r\n\r\nMy Stream: Games\r\nhttps://example.com\r\n",
+		} {
+			if !strings.Contains(message, expected) {
+				t.Errorf("expected %q in message:\n%s", expected, message)
+			}
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no email was received")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := models.EmailConfiguration{SMTPHost: "localhost", SMTPPort: 25, FromAddress: "live@example.com"}
+	if err := Validate(valid); err != nil {
+		t.Error(err)
+	}
+
+	invalid := []models.EmailConfiguration{
+		{SMTPPort: 25, FromAddress: "live@example.com"},
+		{SMTPHost: "localhost", SMTPPort: 70000, FromAddress: "live@example.com"},
+		{SMTPHost: "localhost", SMTPPort: 25, FromAddress: "nobody"},
+	}
+	for i, config := range invalid {
+		if err := Validate(config); err == nil {
+			t.Errorf("expected configuration %d to be invalid", i)
+		}
+	}
+}
+
+func TestTokens(t *testing.T) {
+	key := []byte("key")
+	now := time.Now()
+
+	signed, err := SignToken(key, Token{Purpose: ConfirmPurpose, Address: "viewer@example.com", Expires: now.Add(time.Hour).Unix()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := VerifyToken(key, signed, ConfirmPurpose, now)
+	if err != nil || token.Address != "viewer@example.com" {
+		t.Fatalf("expected a valid token, got %v %v", token, err)
+	}
+
+	if _, err := VerifyToken(key, signed, UnsubscribePurpose, now); err != ErrInvalidToken {
+		t.Error("a confirmation token should not unsubscribe")
+	}
+
+	if _, err := VerifyToken([]byte("other"), signed, ConfirmPurpose, now); err != ErrInvalidToken {
+		t.Error("a token signed with another key should be invalid")
+	}
+
+	if _, err := VerifyToken(key, signed, ConfirmPurpose, now.Add(2*time.Hour)); err != ErrInvalidToken {
+		t.Error("an expired token should be invalid")
+	}
+
+	tampered, _ := SignToken([]byte("other"), Token{Purpose: ConfirmPurpose, Address: "attacker@example.com"})
+	forged := strings.Split(tampered, ".")[0] + "." + strings.Split(signed, ".")[1]
+	if _, err := VerifyToken(key, forged, ConfirmPurpose, now); err != ErrInvalidToken {
+		t.Error("a token with a changed payload should be invalid")
+	}
+}
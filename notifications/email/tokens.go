@@ -0,0 +1,81 @@
+package email
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// ConfirmPurpose is a token that confirms a subscription.
+	ConfirmPurpose = "confirm"
+	// UnsubscribePurpose is a token that removes a subscription.
+	UnsubscribePurpose = "unsubscribe"
+)
+
+// ErrInvalidToken is returned when a token was not signed by this server,
+// is for something else or has expired.
+var ErrInvalidToken = errors.New("this link is invalid or has expired")
+
+// Token is the content of a signed confirmation or unsubscribe link.
+type Token struct {
+	Purpose string `json:"p"`
+	Address string `json:"a"`
+	UserID  string `json:"u,omitempty"`
+	// Expires is a unix timestamp. Zero never expires.
+	Expires int64 `json:"x,omitempty"`
+}
+
+// SignToken will return a token that can be placed in a link and verified
+// later with the same key.
+func SignToken(key []byte, token Token) (string, error) {
+	payload, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(sign(key, encoded)), nil
+}
+
+// VerifyToken will return the content of a token if it was signed with the
+// key, is for the purpose and has not expired.
+func VerifyToken(key []byte, signed string, purpose string, now time.Time) (Token, error) {
+	var token Token
+
+	parts := strings.Split(signed, ".")
+	if len(parts) != 2 {
+		return token, ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, sign(key, parts[0])) {
+		return token, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return token, ErrInvalidToken
+	}
+
+	if err := json.Unmarshal(payload, &token); err != nil {
+		return token, ErrInvalidToken
+	}
+
+	if token.Purpose != purpose || (token.Expires != 0 && now.Unix() > token.Expires) {
+		return token, ErrInvalidToken
+	}
+
+	return token, nil
+}
+
+func sign(key []byte, payload string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,294 @@
+package notifications
+
+import (
+	"net/mail"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/notifications/email"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// How long a confirmation link can be used for.
+	emailConfirmationLifetime = 24 * time.Hour
+	// How long to wait before sending another confirmation to an address.
+	emailConfirmationInterval = 10 * time.Minute
+	// The period the other confirmation limits apply to.
+	emailConfirmationWindow = time.Hour
+	// How many confirmations a single user or IP address can ask for.
+	emailConfirmationsPerRequester = 3
+	// How many confirmations can be sent in total, so the server is not
+	// used to send large amounts of email.
+	emailConfirmationsPerWindow = 100
+)
+
+type emailConfirmation struct {
+	address   string
+	userID    string
+	ipAddress string
+	sentAt    time.Time
+}
+
+var (
+	emailConfirmationsSent     []emailConfirmation
+	emailConfirmationsSentLock sync.Mutex
+)
+
+// RequestEmailSubscription will send a link to an address that subscribes
+// it to go-live emails once it is followed.
+func RequestEmailSubscription(address string, userID string, ipAddress string) error {
+	config := data.GetEmailConfig()
+	if !config.Enabled {
+		return errors.New("email notifications are not enabled")
+	}
+
+	parsed, err := mail.ParseAddress(strings.TrimSpace(address))
+	if err != nil {
+		return errors.New("a valid email address is required")
+	}
+	address = parsed.Address
+
+	if err := allowEmailConfirmation(emailConfirmation{
+		address:   strings.ToLower(address),
+		userID:    userID,
+		ipAddress: ipAddress,
+		sentAt:    time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	confirmURL, err := emailLink("/api/notifications/email/confirm", email.Token{
+		Purpose: email.ConfirmPurpose,
+		Address: address,
+		UserID:  userID,
+		Expires: time.Now().Add(emailConfirmationLifetime).Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	sender, err := email.New(config)
+	if err != nil {
+		return err
+	}
+
+	serverName := data.GetServerName()
+	body := "Follow this link to be emailed when " + serverName + " goes live:\n\n" + confirmURL + "\n\nIf you did not ask for this you can ignore this email."
+
+	return sender.Send(address, "Confirm your "+serverName+" notifications", body, "")
+}
+
+// ConfirmEmailSubscription will subscribe the address in a confirmation link
+// to go-live emails.
+func ConfirmEmailSubscription(token string) error {
+	key, err := data.GetEmailSigningKey()
+	if err != nil {
+		return err
+	}
+
+	confirmation, err := email.VerifyToken(key, token, email.ConfirmPurpose, time.Now())
+	if err != nil {
+		return err
+	}
+
+	destinations, err := GetNotificationDestinationsForChannel(EmailNotification)
+	if err != nil {
+		return err
+	}
+	for _, destination := range destinations {
+		if strings.EqualFold(destination, confirmation.Address) {
+			return nil
+		}
+	}
+
+	return AddNotification(EmailNotification, confirmation.Address, confirmation.UserID)
+}
+
+// UnsubscribeEmail will stop sending go-live emails to the address in an
+// unsubscribe link.
+func UnsubscribeEmail(token string) error {
+	key, err := data.GetEmailSigningKey()
+	if err != nil {
+		return err
+	}
+
+	unsubscribe, err := email.VerifyToken(key, token, email.UnsubscribePurpose, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return RemoveNotificationForChannel(EmailNotification, unsubscribe.Address)
+}
+
+// allowEmailConfirmation will return an error if a confirmation can not be
+// sent, otherwise it is counted towards the limits.
+func allowEmailConfirmation(confirmation emailConfirmation) error {
+	emailConfirmationsSentLock.Lock()
+	defer emailConfirmationsSentLock.Unlock()
+
+	recent := emailConfirmationsSent[:0]
+	for _, sent := range emailConfirmationsSent {
+		if confirmation.sentAt.Sub(sent.sentAt) < emailConfirmationWindow {
+			recent = append(recent, sent)
+		}
+	}
+	emailConfirmationsSent = recent
+
+	if len(emailConfirmationsSent) >= emailConfirmationsPerWindow {
+		return errors.New("too many confirmation emails have been sent, try again later")
+	}
+
+	var fromUser, fromIPAddress int
+	for _, sent := range emailConfirmationsSent {
+		if sent.address == confirmation.address && confirmation.sentAt.Sub(sent.sentAt) < emailConfirmationInterval {
+			return errors.New("a confirmation email was sent recently, check your inbox")
+		}
+		if sent.userID == confirmation.userID {
+			fromUser++
+		}
+		if sent.ipAddress == confirmation.ipAddress {
+			fromIPAddress++
+		}
+	}
+
+	if fromUser >= emailConfirmationsPerRequester || fromIPAddress >= emailConfirmationsPerRequester {
+		return errors.New("too many confirmation emails have been requested, try again later")
+	}
+
+	emailConfirmationsSent = append(emailConfirmationsSent, confirmation)
+	return nil
+}
+
+// emailLink will return a link to this server with a signed token.
+func emailLink(path string, token email.Token) (string, error) {
+	serverURL := data.GetServerURL()
+	if serverURL == "" {
+		return "", errors.New("the server url must be set to send emails")
+	}
+
+	key, err := data.GetEmailSigningKey()
+	if err != nil {
+		return "", err
+	}
+
+	signed, err := email.SignToken(key, token)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(serverURL, "/") + path + "?token=" + url.QueryEscape(signed), nil
+}
+
+func init() {
+	RegisterChannel("email", (*Notifier).setupEmail, emailTemplate)
+}
+
+func emailTemplate() MessageTemplate {
+	return MessageTemplate{Text: data.GetEmailConfig().GoLiveTemplate, Default: email.DefaultGoLiveTemplate}
+}
+
+func emailSubjectTemplate() MessageTemplate {
+	return MessageTemplate{Text: data.GetEmailConfig().GoLiveSubject, Default: email.DefaultGoLiveSubject}
+}
+
+// PreviewEmailSubject will render the go-live email subject with the current
+// stream details. The saved subject is used if text is empty.
+func PreviewEmailSubject(text string) (string, error) {
+	subjectTemplate := emailSubjectTemplate()
+	if text != "" {
+		subjectTemplate.Text = text
+	}
+
+	return renderEmailSubject(subjectTemplate, currentNotification())
+}
+
+func renderEmailSubject(subjectTemplate MessageTemplate, notification Notification) (string, error) {
+	subject, err := subjectTemplate.Render(notification)
+	if err != nil {
+		return "", err
+	}
+
+	// Subjects are a single line.
+	return strings.Join(strings.Fields(subject), " "), nil
+}
+
+func (n *Notifier) setupEmail() (Channel, error) {
+	emailConfig := data.GetEmailConfig()
+	if !emailConfig.Enabled {
+		return nil, nil
+	}
+
+	emailNotifier, err := email.New(emailConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating email notifier")
+	}
+
+	return emailChannel{email: emailNotifier}, nil
+}
+
+type emailChannel struct {
+	email *email.Email
+}
+
+func (c emailChannel) Send(notification Notification) error {
+	destinations, err := GetNotificationDestinationsForChannel(EmailNotification)
+	if err != nil {
+		return errors.Wrap(err, "error getting email notification destinations")
+	}
+
+	subject, body, err := c.render(notification)
+	if err != nil {
+		return err
+	}
+
+	for _, destination := range destinations {
+		unsubscribeURL, err := emailLink("/api/notifications/email/unsubscribe", email.Token{
+			Purpose: email.UnsubscribePurpose,
+			Address: destination,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := c.email.Send(destination, subject, body, unsubscribeURL); err != nil {
+			log.Errorln(err)
+		}
+	}
+
+	return nil
+}
+
+// SendTest will send the go-live email to the from address instead of
+// every subscriber.
+func (c emailChannel) SendTest(notification Notification) error {
+	from, err := mail.ParseAddress(data.GetEmailConfig().FromAddress)
+	if err != nil {
+		return err
+	}
+
+	subject, body, err := c.render(notification)
+	if err != nil {
+		return err
+	}
+
+	return c.email.Send(from.Address, subject, body, "")
+}
+
+func (c emailChannel) render(notification Notification) (string, string, error) {
+	subject, err := renderEmailSubject(emailSubjectTemplate(), notification)
+	if err != nil {
+		return "", "", err
+	}
+
+	body, err := emailTemplate().Render(notification)
+	if err != nil {
+		return "", "", err
+	}
+
+	return subject, body, nil
+}
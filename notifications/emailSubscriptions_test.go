@@ -0,0 +1,44 @@
+package notifications
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestAllowEmailConfirmation(t *testing.T) {
+	emailConfirmationsSent = nil
+	now := time.Now()
+
+	if err := allowEmailConfirmation(emailConfirmation{address: "a@example.com", userID: "user", ipAddress: "203.0.113.1", sentAt: now}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := allowEmailConfirmation(emailConfirmation{address: "a@example.com", userID: "other", ipAddress: "203.0.113.2", sentAt: now}); err == nil {
+		t.Error("expected a second confirmation to the same address to be refused")
+	}
+
+	for i := 0; i < emailConfirmationsPerRequester-1; i++ {
+		if err := allowEmailConfirmation(emailConfirmation{address: fmt.Sprintf("b%d@example.com", i), userID: fmt.Sprintf("user%d", i), ipAddress: "203.0.113.1", sentAt: now}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := allowEmailConfirmation(emailConfirmation{address: "c@example.com", userID: "new", ipAddress: "203.0.113.1", sentAt: now}); err == nil {
+		t.Error("expected the IP address limit to be enforced")
+	}
+
+	// Once the window has passed the requester can ask again.
+	later := now.Add(emailConfirmationWindow)
+	if err := allowEmailConfirmation(emailConfirmation{address: "c@example.com", userID: "new", ipAddress: "203.0.113.1", sentAt: later}); err != nil {
+		t.Error(err)
+	}
+
+	for i := len(emailConfirmationsSent); i < emailConfirmationsPerWindow; i++ {
+		if err := allowEmailConfirmation(emailConfirmation{address: fmt.Sprintf("d%d@example.com", i), userID: fmt.Sprintf("d%d", i), ipAddress: fmt.Sprintf("d%d", i), sentAt: later}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := allowEmailConfirmation(emailConfirmation{address: "e@example.com", userID: "e", ipAddress: "e", sentAt: later}); err == nil {
+		t.Error("expected the global limit to be enforced")
+	}
+}
@@ -0,0 +1,87 @@
+package gotify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Gotify is an instance of the Gotify service.
+type Gotify struct {
+	server   string
+	appToken string
+	priority int
+	client   *http.Client
+}
+
+// New will create a new instance of the Gotify service.
+func New(server, appToken string, priority int) (*Gotify, error) {
+	if server == "" || appToken == "" {
+		return nil, errors.New("a gotify server and application token are required")
+	}
+
+	return &Gotify{
+		server:   strings.TrimSuffix(server, "/"),
+		appToken: appToken,
+		priority: priority,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// Send will push a message to the application. Clients that open the
+// notification are sent to the link.
+func (g *Gotify) Send(title string, text string, link string) error {
+	type message struct {
+		Title    string                 `json:"title,omitempty"`
+		Message  string                 `json:"message"`
+		Priority int                    `json:"priority"`
+		Extras   map[string]interface{} `json:"extras,omitempty"`
+	}
+
+	msg := message{
+		Title:    title,
+		Message:  text,
+		Priority: g.priority,
+	}
+	if link != "" {
+		msg.Extras = map[string]interface{}{
+			"client::notification": map[string]interface{}{
+				"click": map[string]string{"url": link},
+			},
+		}
+	}
+
+	jsonText, err := json.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "error marshalling gotify message to json")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, g.server+"/message?token="+url.QueryEscape(g.appToken), bytes.NewReader(jsonText))
+	if err != nil {
+		return errors.Wrap(err, "error creating gotify request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "error sending gotify message")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var gotifyError struct {
+			Error       string `json:"error"`
+			Description string `json:"errorDescription"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&gotifyError)
+		return fmt.Errorf("gotify responded with %d %s %s", resp.StatusCode, gotifyError.Error, gotifyError.Description)
+	}
+
+	return nil
+}
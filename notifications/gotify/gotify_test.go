@@ -0,0 +1,46 @@
+package gotify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSend(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/message" || r.URL.Query().Get("token") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"Unauthorized","errorCode":401,"errorDescription":"you need to provide a valid access token"}`))
+			return
+		}
+
+		_ = json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer server.Close()
+
+	g, err := New(server.URL+"/", "token", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.Send("My Stream", "I've gone live!", "https://owncast.example"); err != nil {
+		t.Fatal(err)
+	}
+
+	if received["title"] != "My Stream" || received["message"] != "I've gone live!" || received["priority"] != float64(5) {
+		t.Errorf("unexpected message %v", received)
+	}
+
+	extras, _ := json.Marshal(received["extras"])
+	if !strings.Contains(string(extras), `"url":"https://owncast.example"`) {
+		t.Errorf("expected a click url in extras, got %s", extras)
+	}
+
+	bad, _ := New(server.URL, "wrong", 0)
+	if err := bad.Send("", "hello", ""); err == nil || !strings.Contains(err.Error(), "valid access token") {
+		t.Errorf("expected an error with the gotify error, got %v", err)
+	}
+}
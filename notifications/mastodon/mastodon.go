@@ -0,0 +1,79 @@
+package mastodon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Mastodon is an instance of the Mastodon service.
+type Mastodon struct {
+	server      string
+	accessToken string
+	visibility  string
+	client      *http.Client
+}
+
+// Visibilities are the status visibilities Mastodon accepts.
+var Visibilities = []string{"public", "unlisted", "private", "direct"}
+
+// New will create a new instance of the Mastodon service. Statuses are
+// public if visibility is empty.
+func New(server, accessToken, visibility string) (*Mastodon, error) {
+	if server == "" || accessToken == "" {
+		return nil, errors.New("a mastodon server and access token are required")
+	}
+
+	if visibility == "" {
+		visibility = "public"
+	}
+
+	return &Mastodon{
+		server:      strings.TrimSuffix(server, "/"),
+		accessToken: accessToken,
+		visibility:  visibility,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// Post will publish a status from the account the access token belongs to.
+func (m *Mastodon) Post(text string) error {
+	type status struct {
+		Status     string `json:"status"`
+		Visibility string `json:"visibility"`
+	}
+
+	jsonText, err := json.Marshal(status{Status: text, Visibility: m.visibility})
+	if err != nil {
+		return errors.Wrap(err, "error marshalling mastodon status to json")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, m.server+"/api/v1/statuses", bytes.NewReader(jsonText))
+	if err != nil {
+		return errors.Wrap(err, "error creating mastodon request")
+	}
+
+	req.Header.Set("Authorization", "Bearer "+m.accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "error posting mastodon status")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var mastodonError struct {
+			Error string `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&mastodonError)
+		return fmt.Errorf("mastodon responded with %d %s", resp.StatusCode, mastodonError.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package mastodon
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPost(t *testing.T) {
+	var status map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"The access token is invalid"}`))
+			return
+		}
+
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/statuses" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_ = json.NewDecoder(r.Body).Decode(&status)
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	m, err := New(server.URL+"/", "token", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Post("I've gone live!"); err != nil {
+		t.Fatal(err)
+	}
+
+	if status["status"] != "I've gone live!" || status["visibility"] != "public" {
+		t.Errorf("unexpected status %v", status)
+	}
+
+	bad, _ := New(server.URL, "wrong", "unlisted")
+	if err := bad.Post("hello"); err == nil || !strings.Contains(err.Error(), "access token is invalid") {
+		t.Errorf("expected an error with the mastodon error, got %v", err)
+	}
+}
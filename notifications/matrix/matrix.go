@@ -0,0 +1,182 @@
+package matrix
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Matrix is an instance of the Matrix service.
+type Matrix struct {
+	homeserver  string
+	accessToken string
+	rooms       []string
+	client      *http.Client
+}
+
+// Every message needs a transaction ID that is unique for the access token.
+var transactionCounter uint64
+
+// New will create a new instance of the Matrix service.
+func New(homeserver, accessToken string, rooms []string) (*Matrix, error) {
+	if homeserver == "" || accessToken == "" {
+		return nil, errors.New("a matrix homeserver and access token are required")
+	}
+
+	if len(rooms) == 0 {
+		return nil, errors.New("at least one matrix room is required")
+	}
+
+	return &Matrix{
+		homeserver:  strings.TrimSuffix(homeserver, "/"),
+		accessToken: accessToken,
+		rooms:       rooms,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// Send will post a message, with an optional link and JPEG thumbnail, to
+// every room.
+func (m *Matrix) Send(text string, link string, thumbnail []byte) error {
+	var thumbnailURI string
+	if len(thumbnail) > 0 {
+		uri, err := m.upload("thumbnail.jpg", "image/jpeg", thumbnail)
+		if err != nil {
+			return err
+		}
+		thumbnailURI = uri
+	}
+
+	body := text
+	formattedBody := strings.ReplaceAll(html.EscapeString(text), "\n", "<br>")
+	if link != "" {
+		body += "\n\n" + link
+		formattedBody += fmt.Sprintf(`<br><br><a href="%s">%s</a>`, html.EscapeString(link), html.EscapeString(link))
+	}
+
+	var sendErr error
+	for _, room := range m.rooms {
+		roomID, err := m.resolveRoom(room)
+		if err != nil {
+			sendErr = err
+			continue
+		}
+
+		if thumbnailURI != "" {
+			image := map[string]interface{}{
+				"msgtype": "m.image",
+				"body":    "thumbnail.jpg",
+				"url":     thumbnailURI,
+				"info": map[string]interface{}{
+					"mimetype": "image/jpeg",
+					"size":     len(thumbnail),
+				},
+			}
+			if err := m.sendEvent(roomID, image); err != nil {
+				sendErr = err
+				continue
+			}
+		}
+
+		message := map[string]interface{}{
+			"msgtype":        "m.text",
+			"body":           body,
+			"format":         "org.matrix.custom.html",
+			"formatted_body": formattedBody,
+		}
+		if err := m.sendEvent(roomID, message); err != nil {
+			sendErr = err
+		}
+	}
+
+	return sendErr
+}
+
+// upload will add media to the homeserver and return its mxc:// URI.
+func (m *Matrix) upload(filename string, contentType string, content []byte) (string, error) {
+	var response struct {
+		ContentURI string `json:"content_uri"`
+	}
+
+	endpoint := "/_matrix/media/v3/upload?filename=" + url.QueryEscape(filename)
+	if err := m.do(http.MethodPost, endpoint, contentType, bytes.NewReader(content), &response); err != nil {
+		return "", errors.Wrap(err, "error uploading matrix media")
+	}
+
+	return response.ContentURI, nil
+}
+
+// resolveRoom will return the ID of a room given its ID or alias.
+func (m *Matrix) resolveRoom(room string) (string, error) {
+	if !strings.HasPrefix(room, "#") {
+		return room, nil
+	}
+
+	var response struct {
+		RoomID string `json:"room_id"`
+	}
+
+	if err := m.do(http.MethodGet, "/_matrix/client/v3/directory/room/"+url.PathEscape(room), "", nil, &response); err != nil {
+		return "", errors.Wrap(err, "error resolving matrix room alias "+room)
+	}
+
+	return response.RoomID, nil
+}
+
+func (m *Matrix) sendEvent(roomID string, content interface{}) error {
+	jsonText, err := json.Marshal(content)
+	if err != nil {
+		return errors.Wrap(err, "error marshalling matrix message to json")
+	}
+
+	transactionID := fmt.Sprintf("owncast-%d-%d", time.Now().UnixNano(), atomic.AddUint64(&transactionCounter, 1))
+	endpoint := fmt.Sprintf("/_matrix/client/v3/rooms/%s/send/m.room.message/%s", url.PathEscape(roomID), transactionID)
+
+	if err := m.do(http.MethodPut, endpoint, "application/json", bytes.NewReader(jsonText), nil); err != nil {
+		return errors.Wrap(err, "error sending matrix message to "+roomID)
+	}
+
+	return nil
+}
+
+func (m *Matrix) do(method string, endpoint string, contentType string, body io.Reader, response interface{}) error {
+	req, err := http.NewRequest(method, m.homeserver+endpoint, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+m.accessToken)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var matrixError struct {
+			ErrCode string `json:"errcode"`
+			Error   string `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&matrixError)
+		return fmt.Errorf("matrix responded with %d %s %s", resp.StatusCode, matrixError.ErrCode, matrixError.Error)
+	}
+
+	if response == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(response)
+}
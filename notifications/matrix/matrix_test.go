@@ -0,0 +1,80 @@
+package matrix
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSend(t *testing.T) {
+	var mu sync.Mutex
+	var uploaded []byte
+	var events []map[string]interface{}
+	var rooms []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"errcode":"M_UNKNOWN_TOKEN","error":"Invalid access token"}`))
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/_matrix/media/v3/upload":
+			uploaded, _ = io.ReadAll(r.Body)
+			_, _ = w.Write([]byte(`{"content_uri":"mxc://example.com/thumb"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/_matrix/client/v3/directory/room/#stream:example.com":
+			_, _ = w.Write([]byte(`{"room_id":"!alias:example.com"}`))
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/_matrix/client/v3/rooms/"):
+			rooms = append(rooms, strings.Split(strings.TrimPrefix(r.URL.Path, "/_matrix/client/v3/rooms/"), "/")[0])
+			var event map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&event)
+			events = append(events, event)
+			_, _ = w.Write([]byte(`{"event_id":"$event"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	m, err := New(server.URL+"/", "token", []string{"!room:example.com", "#stream:example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Send("I've gone live!", "https://owncast.example", []byte("jpeg")); err != nil {
+		t.Fatal(err)
+	}
+
+	if string(uploaded) != "jpeg" {
+		t.Errorf("expected the thumbnail to be uploaded once, got %q", uploaded)
+	}
+
+	if len(events) != 4 {
+		t.Fatalf("expected an image and a message in each room, got %d events", len(events))
+	}
+
+	if rooms[0] != "!room:example.com" || rooms[2] != "!alias:example.com" {
+		t.Errorf("unexpected rooms %v", rooms)
+	}
+
+	if events[0]["msgtype"] != "m.image" || events[0]["url"] != "mxc://example.com/thumb" {
+		t.Errorf("unexpected image event %v", events[0])
+	}
+
+	if events[1]["body"] != "I've gone live!\n\nhttps://owncast.example" {
+		t.Errorf("unexpected message body %q", events[1]["body"])
+	}
+
+	bad, _ := New(server.URL, "wrong", []string{"!room:example.com"})
+	if err := bad.Send("hello", "", nil); err == nil || !strings.Contains(err.Error(), "M_UNKNOWN_TOKEN") {
+		t.Errorf("expected an error with the matrix error code, got %v", err)
+	}
+}
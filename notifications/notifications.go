@@ -1,14 +1,12 @@
 package notifications
 
 import (
-	"fmt"
-	"strings"
-
 	"github.com/owncast/owncast/config"
 	"github.com/owncast/owncast/core/data"
 	"github.com/owncast/owncast/models"
 	"github.com/owncast/owncast/notifications/browser"
 	"github.com/owncast/owncast/notifications/discord"
+	"github.com/owncast/owncast/notifications/matrix"
 	"github.com/owncast/owncast/notifications/twitter"
 	"github.com/owncast/owncast/utils"
 	"github.com/pkg/errors"
@@ -18,9 +16,45 @@ import (
 // Notifier is an instance of the live stream notifier.
 type Notifier struct {
 	datastore *data.Datastore
-	browser   *browser.Browser
-	discord   *discord.Discord
-	twitter   *twitter.Twitter
+	channels  []enabledChannel
+	// matrix is also told when the stream ends.
+	matrix *matrix.Matrix
+}
+
+// The default templates keep the go-live messages these channels sent
+// before they had templates.
+const (
+	browserPushGoLiveTemplate = "{{.GoLiveMessage}}"
+	discordGoLiveTemplate     = "{{.GoLiveMessage}}{{if .StreamTitle}}\n{{.StreamTitle}}{{end}}\n\n{{.URL}}"
+	twitterGoLiveTemplate     = "{{.GoLiveMessage}}{{if .StreamTitle}}\n{{.StreamTitle}}{{end}}\n{{.URL}}\n\n{{.Hashtags}}"
+	matrixGoLiveTemplate      = "{{.GoLiveMessage}}{{if .StreamTitle}}\n{{.StreamTitle}}{{end}}"
+)
+
+func init() {
+	RegisterChannel("browser", (*Notifier).setupBrowserPush, browserPushTemplate)
+	RegisterChannel("discord", (*Notifier).setupDiscord, discordTemplate)
+	RegisterChannel("twitter", (*Notifier).setupTwitter, twitterTemplate)
+	RegisterChannel("matrix", (*Notifier).setupMatrix, matrixTemplate)
+}
+
+func browserPushTemplate() MessageTemplate {
+	browserConfig := data.GetBrowserPushConfig()
+	return MessageTemplate{Text: browserConfig.GoLiveTemplate, Default: browserPushGoLiveTemplate, GoLiveMessage: browserConfig.GoLiveMessage}
+}
+
+func discordTemplate() MessageTemplate {
+	discordConfig := data.GetDiscordConfig()
+	return MessageTemplate{Text: discordConfig.GoLiveTemplate, Default: discordGoLiveTemplate, GoLiveMessage: discordConfig.GoLiveMessage}
+}
+
+func twitterTemplate() MessageTemplate {
+	twitterConfig := data.GetTwitterConfiguration()
+	return MessageTemplate{Text: twitterConfig.GoLiveTemplate, Default: twitterGoLiveTemplate, GoLiveMessage: twitterConfig.GoLiveMessage}
+}
+
+func matrixTemplate() MessageTemplate {
+	matrixConfig := data.GetMatrixConfig()
+	return MessageTemplate{Text: matrixConfig.GoLiveTemplate, Default: matrixGoLiveTemplate, GoLiveMessage: matrixConfig.GoLiveMessage}
 }
 
 // Setup will perform any pre-use setup for the notifier.
@@ -62,132 +96,90 @@ func New(datastore *data.Datastore) (*Notifier, error) {
 		datastore: datastore,
 	}
 
-	if err := notifier.setupBrowserPush(); err != nil {
-		log.Error(err)
-	}
-	if err := notifier.setupDiscord(); err != nil {
-		log.Error(err)
-	}
-	if err := notifier.setupTwitter(); err != nil {
-		log.Errorln(err)
-	}
+	notifier.setupChannels()
 
 	return &notifier, nil
 }
 
-func (n *Notifier) setupBrowserPush() error {
-	if data.GetBrowserPushConfig().Enabled {
-		publicKey, err := data.GetBrowserPushPublicKey()
-		if err != nil || publicKey == "" {
-			return errors.Wrap(err, "browser notifier disabled, failed to get browser push public key")
-		}
-
-		privateKey, err := data.GetBrowserPushPrivateKey()
-		if err != nil || privateKey == "" {
-			return errors.Wrap(err, "browser notifier disabled, failed to get browser push private key")
-		}
-
-		browserNotifier, err := browser.New(n.datastore, publicKey, privateKey)
-		if err != nil {
-			return errors.Wrap(err, "error creating browser notifier")
-		}
-		n.browser = browserNotifier
+func (n *Notifier) setupDiscord() (Channel, error) {
+	discordConfig := data.GetDiscordConfig()
+	if !discordConfig.Enabled || discordConfig.Webhook == "" {
+		return nil, nil
 	}
-	return nil
-}
 
-func (n *Notifier) notifyBrowserPush() {
-	destinations, err := GetNotificationDestinationsForChannel(BrowserPushNotification)
-	if err != nil {
-		log.Errorln("error getting browser push notification destinations", err)
+	var image string
+	if serverURL := data.GetServerURL(); serverURL != "" {
+		image = serverURL + "/images/owncast-logo.png"
 	}
-	for _, destination := range destinations {
-		unsubscribed, err := n.browser.Send(destination, data.GetServerName(), data.GetBrowserPushConfig().GoLiveMessage)
-		if unsubscribed {
-			// If the error is "unsubscribed", then remove the destination from the database.
-			if err := RemoveNotificationForChannel(BrowserPushNotification, destination); err != nil {
-				log.Errorln(err)
-			}
-		} else if err != nil {
-			log.Errorln(err)
-		}
+	discordNotifier, err := discord.New(
+		data.GetServerName(),
+		image,
+		discordConfig.Webhook,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating discord notifier")
 	}
-}
 
-func (n *Notifier) setupDiscord() error {
-	discordConfig := data.GetDiscordConfig()
-	if discordConfig.Enabled && discordConfig.Webhook != "" {
-		var image string
-		if serverURL := data.GetServerURL(); serverURL != "" {
-			image = serverURL + "/images/owncast-logo.png"
-		}
-		discordNotifier, err := discord.New(
-			data.GetServerName(),
-			image,
-			discordConfig.Webhook,
-		)
+	return ChannelFunc(func(notification Notification) error {
+		message, err := discordTemplate().Render(notification)
 		if err != nil {
-			return errors.Wrap(err, "error creating discord notifier")
+			return err
 		}
-		n.discord = discordNotifier
-	}
-	return nil
+
+		return errors.Wrap(discordNotifier.Send(message), "error sending discord message")
+	}), nil
 }
 
-func (n *Notifier) notifyDiscord() {
-	goLiveMessage := data.GetDiscordConfig().GoLiveMessage
-	streamTitle := data.GetStreamTitle()
-	if streamTitle != "" {
-		goLiveMessage += "\n" + streamTitle
+func (n *Notifier) setupTwitter() (Channel, error) {
+	twitterConfig := data.GetTwitterConfiguration()
+	if !twitterConfig.Enabled {
+		return nil, nil
 	}
-	message := fmt.Sprintf("%s\n\n%s", goLiveMessage, data.GetServerURL())
 
-	if err := n.discord.Send(message); err != nil {
-		log.Errorln("error sending discord message", err)
+	t, err := twitter.New(twitterConfig.APIKey, twitterConfig.APISecret, twitterConfig.AccessToken, twitterConfig.AccessTokenSecret, twitterConfig.BearerToken)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating twitter notifier")
 	}
-}
 
-func (n *Notifier) setupTwitter() error {
-	if twitterConfig := data.GetTwitterConfiguration(); twitterConfig.Enabled {
-		if t, err := twitter.New(twitterConfig.APIKey, twitterConfig.APISecret, twitterConfig.AccessToken, twitterConfig.AccessTokenSecret, twitterConfig.BearerToken); err == nil {
-			n.twitter = t
-		} else if err != nil {
-			return errors.Wrap(err, "error creating twitter notifier")
+	return ChannelFunc(func(notification Notification) error {
+		notification.Tags = utils.ShuffleStringSlice(append([]string(nil), notification.Tags...))
+		message, err := twitterTemplate().Render(notification)
+		if err != nil {
+			return err
 		}
-	}
-	return nil
+
+		return errors.Wrap(t.Notify(message), "error sending twitter message")
+	}), nil
 }
 
-func (n *Notifier) notifyTwitter() {
-	goLiveMessage := data.GetTwitterConfiguration().GoLiveMessage
-	streamTitle := data.GetStreamTitle()
-	if streamTitle != "" {
-		goLiveMessage += "\n" + streamTitle
-	}
-	tagString := ""
-	for _, tag := range utils.ShuffleStringSlice(data.GetServerMetadataTags()) {
-		tagString = fmt.Sprintf("%s #%s", tagString, tag)
+func (n *Notifier) setupMatrix() (Channel, error) {
+	matrixConfig := data.GetMatrixConfig()
+	if !matrixConfig.Enabled {
+		return nil, nil
 	}
-	tagString = strings.TrimSpace(tagString)
 
-	message := fmt.Sprintf("%s\n%s\n\n%s", goLiveMessage, data.GetServerURL(), tagString)
-
-	if err := n.twitter.Notify(message); err != nil {
-		log.Errorln("error sending twitter message", err)
+	matrixNotifier, err := matrix.New(matrixConfig.Homeserver, matrixConfig.AccessToken, matrixConfig.Rooms)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating matrix notifier")
 	}
-}
+	n.matrix = matrixNotifier
 
-// Notify will fire the different notification channels.
-func (n *Notifier) Notify() {
-	if n.browser != nil {
-		n.notifyBrowserPush()
-	}
+	return ChannelFunc(func(notification Notification) error {
+		message, err := matrixTemplate().Render(notification)
+		if err != nil {
+			return err
+		}
 
-	if n.discord != nil {
-		n.notifyDiscord()
-	}
+		return errors.Wrap(matrixNotifier.Send(message, notification.URL, notification.Thumbnail), "error sending matrix message")
+	}), nil
+}
 
-	if n.twitter != nil {
-		n.notifyTwitter()
+// NotifyStreamEnded will fire the notification channels that want to know
+// when the stream ends.
+func (n *Notifier) NotifyStreamEnded() {
+	if matrixConfig := data.GetMatrixConfig(); n.matrix != nil && matrixConfig.NotifyStreamEnded && matrixConfig.StreamEndedMessage != "" {
+		if err := n.matrix.Send(matrixConfig.StreamEndedMessage, "", nil); err != nil {
+			log.Errorln("error sending matrix message", err)
+		}
 	}
 }
@@ -0,0 +1,94 @@
+package ntfy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultServer is the public ntfy server.
+const DefaultServer = "https://ntfy.sh"
+
+// Ntfy is an instance of the ntfy service.
+type Ntfy struct {
+	server      string
+	topic       string
+	accessToken string
+	client      *http.Client
+}
+
+// New will create a new instance of the ntfy service. The public server is
+// used if server is empty.
+func New(server, topic, accessToken string) (*Ntfy, error) {
+	if topic == "" {
+		return nil, errors.New("an ntfy topic is required")
+	}
+
+	if server == "" {
+		server = DefaultServer
+	}
+
+	return &Ntfy{
+		server:      strings.TrimSuffix(server, "/"),
+		topic:       topic,
+		accessToken: accessToken,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// Send will publish a message to the topic. Subscribers that tap the
+// notification are sent to the link.
+func (n *Ntfy) Send(title string, text string, link string) error {
+	type message struct {
+		Topic   string   `json:"topic"`
+		Title   string   `json:"title,omitempty"`
+		Message string   `json:"message"`
+		Click   string   `json:"click,omitempty"`
+		Tags    []string `json:"tags,omitempty"`
+	}
+
+	msg := message{
+		Topic:   n.topic,
+		Title:   title,
+		Message: text,
+		Click:   link,
+		Tags:    []string{"red_circle"},
+	}
+
+	jsonText, err := json.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "error marshalling ntfy message to json")
+	}
+
+	// Publishing as JSON to the server root allows titles that are not ASCII.
+	req, err := http.NewRequest(http.MethodPost, n.server+"/", bytes.NewReader(jsonText))
+	if err != nil {
+		return errors.Wrap(err, "error creating ntfy request")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if n.accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+n.accessToken)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "error publishing ntfy message")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var ntfyError struct {
+			Error string `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&ntfyError)
+		return fmt.Errorf("ntfy responded with %d %s", resp.StatusCode, ntfyError.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package ntfy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSend(t *testing.T) {
+	var published map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"code":40301,"error":"forbidden"}`))
+			return
+		}
+
+		_ = json.NewDecoder(r.Body).Decode(&published)
+	}))
+	defer server.Close()
+
+	n, err := New(server.URL+"/", "stream", "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := n.Send("My Stream ✨", "I've gone live!", "https://owncast.example"); err != nil {
+		t.Fatal(err)
+	}
+
+	if published["topic"] != "stream" || published["title"] != "My Stream ✨" || published["message"] != "I've gone live!" || published["click"] != "https://owncast.example" {
+		t.Errorf("unexpected message %v", published)
+	}
+
+	bad, _ := New(server.URL, "stream", "")
+	if err := bad.Send("", "hello", ""); err == nil || !strings.Contains(err.Error(), "forbidden") {
+		t.Errorf("expected an error with the ntfy error, got %v", err)
+	}
+
+	if _, err := New("", "", ""); err == nil {
+		t.Error("expected a topic to be required")
+	}
+}
@@ -3,9 +3,12 @@ package notifications
 import (
 	"context"
 	"database/sql"
+	"strings"
+	"time"
 
 	"github.com/owncast/owncast/core/data"
 	"github.com/owncast/owncast/db"
+	"github.com/owncast/owncast/utils"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 )
@@ -17,6 +20,8 @@ func createNotificationsTable(db *sql.DB) {
     "id" INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
 		"channel" TEXT NOT NULL,
 		"destination" TEXT NOT NULL,
+		"user_id" TEXT,
+		"topics" TEXT,
 		"created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP);
 		CREATE INDEX channel_index ON notifications (channel);`
 
@@ -32,10 +37,19 @@ func createNotificationsTable(db *sql.DB) {
 }
 
 // AddNotification saves a new user notification destination.
-func AddNotification(channel, destination string) error {
+func AddNotification(channel, destination, userID string) error {
+	return AddNotificationForTopics(channel, destination, userID, nil)
+}
+
+// AddNotificationForTopics saves a new user notification destination that
+// is only sent the given topics. A destination without topics is only sent
+// go-live notifications.
+func AddNotificationForTopics(channel, destination, userID string, topics []string) error {
 	return data.GetDatastore().GetQueries().AddNotification(context.Background(), db.AddNotificationParams{
 		Channel:     channel,
 		Destination: destination,
+		UserID:      sql.NullString{String: userID, Valid: userID != ""},
+		Topics:      sql.NullString{String: strings.Join(topics, ","), Valid: len(topics) > 0},
 	})
 }
 
@@ -58,3 +72,80 @@ func GetNotificationDestinationsForChannel(channel string) ([]string, error) {
 
 	return result, nil
 }
+
+// GetNotificationDestinationsForTopic will return the destinations of a
+// channel that want to be sent a topic.
+func GetNotificationDestinationsForTopic(channel, topic string) ([]string, error) {
+	result, err := data.GetDatastore().GetQueries().GetNotificationDestinationsWithTopicsForChannel(context.Background(), channel)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to query notification destinations for channel "+channel)
+	}
+
+	destinations := []string{}
+	for _, row := range result {
+		if _, subscribed := utils.FindInSlice(topicsOrDefault(row.Topics), topic); subscribed {
+			destinations = append(destinations, row.Destination)
+		}
+	}
+
+	return destinations, nil
+}
+
+// GetNotificationTopicCounts will return how many destinations of a channel
+// want to be sent each topic.
+func GetNotificationTopicCounts(channel string) (map[string]int, error) {
+	result, err := data.GetDatastore().GetQueries().GetNotificationDestinationsWithTopicsForChannel(context.Background(), channel)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to query notification destinations for channel "+channel)
+	}
+
+	counts := map[string]int{}
+	for _, row := range result {
+		for _, topic := range topicsOrDefault(row.Topics) {
+			counts[topic]++
+		}
+	}
+
+	return counts, nil
+}
+
+func topicsOrDefault(topics sql.NullString) []string {
+	if !topics.Valid || topics.String == "" {
+		return []string{GoLiveTopic}
+	}
+
+	return strings.Split(topics.String, ",")
+}
+
+// Registration is a single notification destination registered by a user.
+type Registration struct {
+	Channel     string     `json:"channel"`
+	Destination string     `json:"destination"`
+	CreatedAt   *time.Time `json:"createdAt,omitempty"`
+}
+
+// GetNotificationsForUser will return the notification destinations a
+// single user has registered.
+func GetNotificationsForUser(userID string) ([]Registration, error) {
+	result, err := data.GetDatastore().GetQueries().GetNotificationsForUser(context.Background(), sql.NullString{String: userID, Valid: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to query notification destinations for user")
+	}
+
+	registrations := make([]Registration, 0, len(result))
+	for _, row := range result {
+		registration := Registration{Channel: row.Channel, Destination: row.Destination}
+		if row.CreatedAt.Valid {
+			registration.CreatedAt = &row.CreatedAt.Time
+		}
+		registrations = append(registrations, registration)
+	}
+
+	return registrations, nil
+}
+
+// RemoveNotificationsForUser will remove every notification destination a
+// single user has registered.
+func RemoveNotificationsForUser(userID string) error {
+	return data.GetDatastore().GetQueries().RemoveNotificationsForUser(context.Background(), sql.NullString{String: userID, Valid: true})
+}
@@ -0,0 +1,117 @@
+package notifications
+
+import (
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/notifications/gotify"
+	"github.com/owncast/owncast/notifications/mastodon"
+	"github.com/owncast/owncast/notifications/ntfy"
+	"github.com/owncast/owncast/notifications/telegram"
+	"github.com/pkg/errors"
+)
+
+func init() {
+	RegisterChannel("ntfy", setupNtfy, ntfyTemplate)
+	RegisterChannel("gotify", setupGotify, gotifyTemplate)
+	RegisterChannel("telegram", setupTelegram, telegramTemplate)
+	RegisterChannel("mastodon", setupMastodon, mastodonTemplate)
+}
+
+func ntfyTemplate() MessageTemplate {
+	return MessageTemplate{Text: data.GetNtfyConfig().GoLiveTemplate, Default: DefaultGoLiveTemplate}
+}
+
+func gotifyTemplate() MessageTemplate {
+	return MessageTemplate{Text: data.GetGotifyConfig().GoLiveTemplate, Default: DefaultGoLiveTemplate}
+}
+
+func telegramTemplate() MessageTemplate {
+	return MessageTemplate{Text: data.GetTelegramConfig().GoLiveTemplate, Default: DefaultGoLiveTemplate}
+}
+
+func mastodonTemplate() MessageTemplate {
+	return MessageTemplate{Text: data.GetMastodonConfig().GoLiveTemplate, Default: DefaultGoLiveTemplate}
+}
+
+func setupNtfy(_ *Notifier) (Channel, error) {
+	ntfyConfig := data.GetNtfyConfig()
+	if !ntfyConfig.Enabled {
+		return nil, nil
+	}
+
+	ntfyNotifier, err := ntfy.New(ntfyConfig.Server, ntfyConfig.Topic, ntfyConfig.AccessToken)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating ntfy notifier")
+	}
+
+	return ChannelFunc(func(notification Notification) error {
+		message, err := ntfyTemplate().Render(notification)
+		if err != nil {
+			return err
+		}
+
+		return errors.Wrap(ntfyNotifier.Send(notification.ServerName, message, notification.URL), "error sending ntfy message")
+	}), nil
+}
+
+func setupGotify(_ *Notifier) (Channel, error) {
+	gotifyConfig := data.GetGotifyConfig()
+	if !gotifyConfig.Enabled {
+		return nil, nil
+	}
+
+	gotifyNotifier, err := gotify.New(gotifyConfig.Server, gotifyConfig.AppToken, gotifyConfig.Priority)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating gotify notifier")
+	}
+
+	return ChannelFunc(func(notification Notification) error {
+		message, err := gotifyTemplate().Render(notification)
+		if err != nil {
+			return err
+		}
+
+		return errors.Wrap(gotifyNotifier.Send(notification.ServerName, message, notification.URL), "error sending gotify message")
+	}), nil
+}
+
+func setupTelegram(_ *Notifier) (Channel, error) {
+	telegramConfig := data.GetTelegramConfig()
+	if !telegramConfig.Enabled {
+		return nil, nil
+	}
+
+	telegramNotifier, err := telegram.New(telegramConfig.BotToken, telegramConfig.ChatIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating telegram notifier")
+	}
+
+	return ChannelFunc(func(notification Notification) error {
+		message, err := telegramTemplate().Render(notification)
+		if err != nil {
+			return err
+		}
+
+		return errors.Wrap(telegramNotifier.Send(message), "error sending telegram message")
+	}), nil
+}
+
+func setupMastodon(_ *Notifier) (Channel, error) {
+	mastodonConfig := data.GetMastodonConfig()
+	if !mastodonConfig.Enabled {
+		return nil, nil
+	}
+
+	mastodonNotifier, err := mastodon.New(mastodonConfig.Server, mastodonConfig.AccessToken, mastodonConfig.Visibility)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating mastodon notifier")
+	}
+
+	return ChannelFunc(func(notification Notification) error {
+		message, err := mastodonTemplate().Render(notification)
+		if err != nil {
+			return err
+		}
+
+		return errors.Wrap(mastodonNotifier.Post(message), "error posting mastodon status")
+	}), nil
+}
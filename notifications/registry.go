@@ -0,0 +1,141 @@
+package notifications
+
+import (
+	"time"
+
+	"github.com/owncast/owncast/core/data"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// Channel is a destination for go-live notifications, such as a chat
+// service or the viewers who registered for them.
+type Channel interface {
+	// Send will deliver a go-live notification.
+	Send(notification Notification) error
+}
+
+// ChannelFunc allows an ordinary function to be used as a Channel.
+type ChannelFunc func(notification Notification) error
+
+// Send will call f(notification).
+func (f ChannelFunc) Send(notification Notification) error {
+	return f(notification)
+}
+
+// Tester is implemented by channels that should send test notifications
+// somewhere other than their go-live destinations.
+type Tester interface {
+	SendTest(notification Notification) error
+}
+
+// ChannelSetup will return a channel if it is enabled, or nil if it is not.
+type ChannelSetup func(n *Notifier) (Channel, error)
+
+type registeredChannel struct {
+	name     string
+	setup    ChannelSetup
+	template TemplateSource
+}
+
+type enabledChannel struct {
+	name    string
+	channel Channel
+}
+
+var registeredChannels []registeredChannel
+
+// RegisterChannel will make a notification channel available to every
+// Notifier. It is expected to be called from init. The template can be nil
+// for channels without a go-live message template.
+func RegisterChannel(name string, setup ChannelSetup, template TemplateSource) {
+	for _, registered := range registeredChannels {
+		if registered.name == name {
+			panic("notification channel registered twice: " + name)
+		}
+	}
+
+	registeredChannels = append(registeredChannels, registeredChannel{name: name, setup: setup, template: template})
+}
+
+// GetChannelNames will return the names of every registered channel.
+func GetChannelNames() []string {
+	names := make([]string, 0, len(registeredChannels))
+	for _, registered := range registeredChannels {
+		names = append(names, registered.name)
+	}
+
+	return names
+}
+
+func (n *Notifier) setupChannels() {
+	for _, registered := range registeredChannels {
+		channel, err := registered.setup(n)
+		if err != nil {
+			log.Errorln(err)
+			continue
+		}
+		if channel != nil {
+			n.channels = append(n.channels, enabledChannel{name: registered.name, channel: channel})
+		}
+	}
+}
+
+// Notify will fire the different notification channels, skipping those
+// that sent a go-live notification more recently than their minimum
+// interval.
+func (n *Notifier) Notify() {
+	notification := currentNotification()
+	minimumIntervals := data.GetNotificationThrottleConfig().MinimumIntervals
+
+	for _, enabled := range n.channels {
+		if minutes := minimumIntervals[enabled.name]; minutes > 0 {
+			if lastSent := data.GetNotificationLastSent(enabled.name); time.Since(lastSent) < time.Duration(minutes)*time.Minute {
+				log.Debugln("Not sending", enabled.name, "go live notification, the last one was sent at", lastSent)
+				continue
+			}
+		}
+
+		if err := enabled.channel.Send(notification); err != nil {
+			log.Errorln(err)
+			continue
+		}
+
+		if err := data.SetNotificationLastSent(enabled.name, time.Now()); err != nil {
+			log.Errorln(err)
+		}
+	}
+}
+
+// IsReconnect will return true if a stream that ended at previousStreamEnded
+// ended recently enough that a new stream is likely the same broadcast
+// reconnecting.
+func IsReconnect(previousStreamEnded time.Time) bool {
+	minutes := data.GetNotificationThrottleConfig().ReconnectSuppressionMinutes
+	return minutes > 0 && time.Since(previousStreamEnded) < time.Duration(minutes)*time.Minute
+}
+
+// SendTestNotification will send the go-live notification to a single
+// channel so the admin can check its configuration.
+func (n *Notifier) SendTestNotification(name string) error {
+	notification := currentNotification()
+
+	for _, enabled := range n.channels {
+		if enabled.name != name {
+			continue
+		}
+
+		if tester, ok := enabled.channel.(Tester); ok {
+			return tester.SendTest(notification)
+		}
+		return enabled.channel.Send(notification)
+	}
+
+	for _, registered := range registeredChannels {
+		if registered.name == name {
+			return errors.New(name + " notifications are not enabled")
+		}
+	}
+
+	return errors.New("unknown notification channel: " + name)
+}
@@ -0,0 +1,91 @@
+package telegram
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const defaultAPIURL = "https://api.telegram.org"
+
+// Telegram is an instance of the Telegram bot service.
+type Telegram struct {
+	apiURL   string
+	botToken string
+	chatIDs  []string
+	client   *http.Client
+}
+
+// New will create a new instance of the Telegram bot service.
+func New(botToken string, chatIDs []string) (*Telegram, error) {
+	if botToken == "" {
+		return nil, errors.New("a telegram bot token is required")
+	}
+
+	if len(chatIDs) == 0 {
+		return nil, errors.New("at least one telegram chat is required")
+	}
+
+	return &Telegram{
+		apiURL:   defaultAPIURL,
+		botToken: botToken,
+		chatIDs:  chatIDs,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// Send will post a message to every chat. Chats can be IDs or the
+// @username of a public channel.
+func (t *Telegram) Send(text string) error {
+	type message struct {
+		ChatID string `json:"chat_id"`
+		Text   string `json:"text"`
+	}
+
+	var sendErr error
+	for _, chatID := range t.chatIDs {
+		jsonText, err := json.Marshal(message{ChatID: chatID, Text: text})
+		if err != nil {
+			return errors.Wrap(err, "error marshalling telegram message to json")
+		}
+
+		if err := t.do("sendMessage", jsonText); err != nil {
+			sendErr = errors.Wrap(err, "error sending telegram message to "+chatID)
+		}
+	}
+
+	return sendErr
+}
+
+func (t *Telegram) do(method string, body []byte) error {
+	endpoint := strings.TrimSuffix(t.apiURL, "/") + "/bot" + t.botToken + "/" + method
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		// The error includes the request URL, which contains the bot token.
+		return errors.New("unable to reach the telegram bot api")
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		OK          bool   `json:"ok"`
+		Description string `json:"description"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&response)
+
+	if !response.OK || resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("telegram responded with %d %s", resp.StatusCode, response.Description)
+	}
+
+	return nil
+}
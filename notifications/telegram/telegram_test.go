@@ -0,0 +1,42 @@
+package telegram
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSend(t *testing.T) {
+	var chats []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&message)
+
+		if r.URL.Path != "/bottoken/sendMessage" || message["chat_id"] == "@missing" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`))
+			return
+		}
+
+		chats = append(chats, message["chat_id"])
+		_, _ = w.Write([]byte(`{"ok":true,"result":{}}`))
+	}))
+	defer server.Close()
+
+	tg, err := New("token", []string{"-100123", "@missing", "@stream"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tg.apiURL = server.URL
+
+	err = tg.Send("I've gone live!")
+	if err == nil || !strings.Contains(err.Error(), "chat not found") {
+		t.Errorf("expected an error for the missing chat, got %v", err)
+	}
+
+	if len(chats) != 2 || chats[0] != "-100123" || chats[1] != "@stream" {
+		t.Errorf("expected the other chats to still be sent to, got %v", chats)
+	}
+}
@@ -0,0 +1,154 @@
+package notifications
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/owncast/owncast/config"
+	"github.com/owncast/owncast/core/data"
+	"github.com/pkg/errors"
+)
+
+// DefaultGoLiveTemplate is used by channels that do not set their own
+// go-live message template.
+const DefaultGoLiveTemplate = "{{.ServerName}} is live{{if .StreamTitle}}: {{.StreamTitle}}{{end}}\n\n{{.URL}}"
+
+// Notification is the content of a go-live notification. Message templates
+// can use its fields, such as {{.StreamTitle}}.
+type Notification struct {
+	ServerName   string
+	StreamerName string
+	StreamTitle  string
+	URL          string
+	ThumbnailURL string
+	Tags         []string
+	// GoLiveMessage is the go-live message configured for the channel the
+	// notification is rendered for, if it has one.
+	GoLiveMessage string
+	// Thumbnail is the most recent JPEG thumbnail, if there is one.
+	Thumbnail []byte `json:"-"`
+}
+
+// MessageTemplate is the go-live message template of a single channel.
+type MessageTemplate struct {
+	// Text is the template the admin saved, if any.
+	Text string
+	// Default is used when Text is empty.
+	Default string
+	// GoLiveMessage is available to the template as {{.GoLiveMessage}}.
+	GoLiveMessage string
+}
+
+// TemplateSource will return the current go-live message template of a
+// channel.
+type TemplateSource func() MessageTemplate
+
+// Render will return the go-live message for a notification.
+func (t MessageTemplate) Render(notification Notification) (string, error) {
+	text := t.Text
+	if text == "" {
+		text = t.Default
+	}
+
+	notification.GoLiveMessage = t.GoLiveMessage
+	return RenderTemplate(text, notification)
+}
+
+// Hashtags will return the tags as a space separated list of hashtags.
+func (n Notification) Hashtags() string {
+	hashtags := make([]string, 0, len(n.Tags))
+	for _, tag := range n.Tags {
+		hashtags = append(hashtags, "#"+tag)
+	}
+
+	return strings.Join(hashtags, " ")
+}
+
+func currentNotification() Notification {
+	// The thumbnail is optional, it may not have been generated yet.
+	thumbnail, _ := os.ReadFile(filepath.Join(config.WebRoot, "thumbnail.jpg"))
+
+	serverURL := data.GetServerURL()
+	var thumbnailURL string
+	if len(thumbnail) > 0 && serverURL != "" {
+		thumbnailURL = strings.TrimSuffix(serverURL, "/") + "/thumbnail.jpg"
+	}
+
+	return Notification{
+		ServerName:   data.GetServerName(),
+		StreamerName: data.GetFederationUsername(),
+		StreamTitle:  data.GetStreamTitle(),
+		URL:          serverURL,
+		ThumbnailURL: thumbnailURL,
+		Tags:         data.GetServerMetadataTags(),
+		Thumbnail:    thumbnail,
+	}
+}
+
+// PreviewGoLiveMessage will render the go-live message of a channel with the
+// current stream details. The channel's saved template is used if text is
+// empty.
+func PreviewGoLiveMessage(name string, text string) (string, error) {
+	for _, registered := range registeredChannels {
+		if registered.name != name {
+			continue
+		}
+
+		if registered.template == nil {
+			return "", errors.New(name + " notifications do not have a message template")
+		}
+
+		messageTemplate := registered.template()
+		if text != "" {
+			messageTemplate.Text = text
+		}
+
+		return messageTemplate.Render(currentNotification())
+	}
+
+	return "", errors.New("unknown notification channel: " + name)
+}
+
+// ValidateTemplate will return an error if a go-live message template can
+// not be used.
+func ValidateTemplate(text string) error {
+	if _, err := parseTemplate(text); err != nil {
+		return err
+	}
+
+	// Catch templates that use fields that do not exist.
+	_, err := RenderTemplate(text, Notification{})
+	return err
+}
+
+// RenderTemplate will return the go-live message for a notification. The
+// default template is used if text is empty.
+func RenderTemplate(text string, notification Notification) (string, error) {
+	tmpl, err := parseTemplate(text)
+	if err != nil {
+		return "", err
+	}
+
+	var message bytes.Buffer
+	if err := tmpl.Execute(&message, notification); err != nil {
+		return "", errors.Wrap(err, "error rendering go-live template")
+	}
+
+	return strings.TrimSpace(message.String()), nil
+}
+
+func parseTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		text = DefaultGoLiveTemplate
+	}
+
+	tmpl, err := template.New("goLive").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid go-live template")
+	}
+
+	return tmpl, nil
+}
@@ -0,0 +1,80 @@
+package notifications
+
+import "testing"
+
+func TestRenderTemplate(t *testing.T) {
+	notification := Notification{
+		ServerName:  "My Stream",
+		StreamTitle: "Games",
+		URL:         "https://owncast.example",
+		Tags:        []string{"gaming", "owncast"},
+	}
+
+	message, err := RenderTemplate("", notification)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if message != "My Stream is live: Games\n\nhttps://owncast.example" {
+		t.Errorf("unexpected default message %q", message)
+	}
+
+	message, err = RenderTemplate("{{.StreamTitle}} {{.Hashtags}}", notification)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if message != "Games #gaming #owncast" {
+		t.Errorf("unexpected message %q", message)
+	}
+
+	if err := ValidateTemplate("{{.StreamTitle"); err == nil {
+		t.Error("expected a template that does not parse to be invalid")
+	}
+
+	if err := ValidateTemplate("{{.Missing}}"); err == nil {
+		t.Error("expected a template with an unknown field to be invalid")
+	}
+}
+
+func TestDefaultChannelTemplates(t *testing.T) {
+	notification := Notification{
+		ServerName:  "My Stream",
+		StreamTitle: "Games",
+		URL:         "https://owncast.example",
+		Tags:        []string{"gaming"},
+	}
+
+	// These are the messages the channels sent before they had templates.
+	expected := map[string]string{
+		discordGoLiveTemplate: "I've gone live!\nGames\n\nhttps://owncast.example",
+		twitterGoLiveTemplate: "I've gone live!\nGames\nhttps://owncast.example\n\n#gaming",
+		matrixGoLiveTemplate:  "I've gone live!\nGames",
+	}
+
+	for text, message := range expected {
+		rendered, err := MessageTemplate{Default: text, GoLiveMessage: "I've gone live!"}.Render(notification)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rendered != message {
+			t.Errorf("expected %q, got %q", message, rendered)
+		}
+	}
+
+	rendered, err := MessageTemplate{Text: "{{.GoLiveMessage}} {{.StreamerName}}", Default: discordGoLiveTemplate, GoLiveMessage: "Live"}.Render(Notification{StreamerName: "streamer"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rendered != "Live streamer" {
+		t.Errorf("expected the saved template to be used, got %q", rendered)
+	}
+}
+
+func TestRenderEmailSubject(t *testing.T) {
+	subject, err := renderEmailSubject(MessageTemplate{Text: "{{.ServerName}}\n is live: {{.StreamTitle}}"}, Notification{ServerName: "My Stream", StreamTitle: "Games"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject != "My Stream is live: Games" {
+		t.Errorf("expected a single line subject, got %q", subject)
+	}
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+)
+
+type actorContextKey struct{}
+
+// withActor will record who made an authenticated request.
+func withActor(r *http.Request, actor string) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), actorContextKey{}, actor))
+}
+
+// GetActor will return who made an authenticated request: the admin, the
+// name of an integration or the display name of a moderator.
+func GetActor(r *http.Request) string {
+	actor, _ := r.Context().Value(actorContextKey{}).(string)
+	return actor
+}
@@ -49,7 +49,7 @@ func RequireAdminAuth(handler http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
-		handler(w, r)
+		handler(w, withActor(r, "admin"))
 	}
 }
 
@@ -84,10 +84,23 @@ func RequireExternalAPIAccessToken(scope string, handler ExternalAccessTokenHand
 			return
 		}
 
+		if integration.IsExpired() {
+			log.Debugln("access token for", integration.DisplayName, "has expired")
+			accessDenied(w)
+			return
+		}
+
+		clientIP := utils.GetClientIPAddress(r)
+		if !integration.IsAllowedIPAddress(clientIP) {
+			log.Debugln("access token for", integration.DisplayName, "used from address", clientIP, "that is not allowed")
+			accessDenied(w)
+			return
+		}
+
 		// All auth'ed 3rd party requests should have a wildcard CORS header.
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 
-		handler(*integration, w, r)
+		handler(*integration, w, withActor(r, integration.DisplayName))
 
 		if err := user.SetExternalAPIUserAccessTokenAsUsed(token); err != nil {
 			log.Debugln("token not found when updating last_used timestamp")
@@ -95,6 +108,15 @@ func RequireExternalAPIAccessToken(scope string, handler ExternalAccessTokenHand
 	})
 }
 
+// RequireExternalAPIAccessTokenForHandler will validate a 3rd party access
+// token before calling a handler that does not need to know which
+// integration made the request, such as those shared with the admin API.
+func RequireExternalAPIAccessTokenForHandler(scope string, handler http.HandlerFunc) http.HandlerFunc {
+	return RequireExternalAPIAccessToken(scope, func(_ user.ExternalAPIUser, w http.ResponseWriter, r *http.Request) {
+		handler(w, r)
+	})
+}
+
 // RequireUserAccessToken will validate a provided user's access token and make sure the associated user is enabled.
 // Not to be used for validating 3rd party access.
 func RequireUserAccessToken(handler UserAccessTokenHandlerFunc) http.HandlerFunc {
@@ -105,7 +127,7 @@ func RequireUserAccessToken(handler UserAccessTokenHandlerFunc) http.HandlerFunc
 			return
 		}
 
-		ipAddress := utils.GetIPAddressFromRequest(r)
+		ipAddress := utils.GetClientIPAddress(r)
 		// Check if this client's IP address is banned.
 		if blocked, err := data.IsIPAddressBanned(ipAddress); blocked {
 			log.Debugln("Client ip address has been blocked. Rejecting.")
@@ -143,6 +165,6 @@ func RequireUserModerationScopeAccesstoken(handler http.HandlerFunc) http.Handle
 			return
 		}
 
-		handler(w, r)
+		handler(w, withActor(r, user.DisplayName))
 	})
 }
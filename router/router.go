@@ -15,6 +15,8 @@ import (
 	"github.com/owncast/owncast/controllers/admin"
 	fediverseauth "github.com/owncast/owncast/controllers/auth/fediverse"
 	"github.com/owncast/owncast/controllers/auth/indieauth"
+	oidcauth "github.com/owncast/owncast/controllers/auth/oidc"
+	passwordauth "github.com/owncast/owncast/controllers/auth/password"
 	"github.com/owncast/owncast/core/chat"
 	"github.com/owncast/owncast/core/data"
 	"github.com/owncast/owncast/core/user"
@@ -42,6 +44,9 @@ func Start() error {
 	// chat rest api
 	http.HandleFunc("/api/chat", middleware.RequireUserAccessToken(controllers.GetChatMessages))
 
+	// read-only stream of chat events
+	http.HandleFunc("/api/chat/stream", controllers.GetChatEventStream)
+
 	// web config api
 	http.HandleFunc("/api/config", controllers.GetWebConfig)
 
@@ -78,6 +83,12 @@ func Start() error {
 	// register a new chat user
 	http.HandleFunc("/api/chat/register", controllers.RegisterAnonymousChatUser)
 
+	// Update the current user's profile
+	http.HandleFunc("/api/chat/profile", middleware.RequireUserAccessToken(controllers.SetUserProfile))
+
+	// Public profile of a single user
+	http.HandleFunc(utils.RestEndpoint("/api/users/{userId}", controllers.GetUserProfile))
+
 	// return remote follow details
 	http.HandleFunc("/api/remotefollow", controllers.RemoteFollow)
 
@@ -90,6 +101,10 @@ func Start() error {
 	// Register for notifications
 	http.HandleFunc("/api/notifications/register", middleware.RequireUserAccessToken(controllers.RegisterForLiveNotifications))
 
+	// Confirm or stop go-live emails from the links sent to an email address
+	http.HandleFunc("/api/notifications/email/confirm", controllers.ConfirmEmailNotifications)
+	http.HandleFunc("/api/notifications/email/unsubscribe", controllers.UnsubscribeEmailNotifications)
+
 	// Authenticated admin requests
 
 	// Current inbound broadcaster
@@ -143,12 +158,66 @@ func Start() error {
 	// Get a list of disabled users
 	http.HandleFunc("/api/admin/chat/users/disabled", middleware.RequireAdminAuth(admin.GetDisabledUsers))
 
+	// Get new users connecting from the IP address of a disabled user
+	http.HandleFunc("/api/admin/chat/users/suspectedbanevaders", middleware.RequireAdminAuth(admin.GetBanEvasionSuspects))
+
+	// Get all the chat commands
+	http.HandleFunc("/api/admin/chat/commands", middleware.RequireAdminAuth(admin.GetChatCommands))
+
+	// Add a chat command
+	http.HandleFunc("/api/admin/chat/commands/create", middleware.RequireAdminAuth(admin.CreateChatCommand))
+
+	// Change a chat command
+	http.HandleFunc("/api/admin/chat/commands/update", middleware.RequireAdminAuth(admin.UpdateChatCommand))
+
+	// Remove a chat command
+	http.HandleFunc("/api/admin/chat/commands/delete", middleware.RequireAdminAuth(admin.DeleteChatCommand))
+
+	// Get if chat is being protected from a raid
+	http.HandleFunc("/api/admin/chat/raidprotection", middleware.RequireAdminAuth(admin.GetRaidProtectionStatus))
+
+	// Protect chat from a raid
+	http.HandleFunc("/api/admin/chat/raidprotection/start", middleware.RequireAdminAuth(admin.StartRaidProtection))
+
+	// Stop protecting chat from a raid
+	http.HandleFunc("/api/admin/chat/raidprotection/end", middleware.RequireAdminAuth(admin.EndRaidProtection))
+
 	// Set moderator status for a user
 	http.HandleFunc("/api/admin/chat/users/setmoderator", middleware.RequireAdminAuth(admin.UpdateUserModerator))
 
 	// Get a list of moderator users
 	http.HandleFunc("/api/admin/chat/users/moderators", middleware.RequireAdminAuth(admin.GetModerators))
 
+	// Delete what is stored about a chat user and anonymize their messages
+	http.HandleFunc("/api/admin/chat/users/deletedata", middleware.RequireAdminAuth(admin.DeleteUserData))
+
+	// Set or remove a role for a chat user
+	http.HandleFunc("/api/admin/chat/users/setrole", middleware.RequireAdminAuth(admin.UpdateUserRole))
+
+	// Get, create, update and delete chat user roles
+	http.HandleFunc("/api/admin/chat/roles", middleware.RequireAdminAuth(admin.GetRoles))
+	http.HandleFunc("/api/admin/chat/roles/create", middleware.RequireAdminAuth(admin.CreateRole))
+	http.HandleFunc("/api/admin/chat/roles/update", middleware.RequireAdminAuth(admin.UpdateRole))
+	http.HandleFunc("/api/admin/chat/roles/delete", middleware.RequireAdminAuth(admin.DeleteRole))
+
+	// Get the users assigned a role
+	http.HandleFunc("/api/admin/chat/roles/users", middleware.RequireAdminAuth(admin.GetUsersWithRole))
+
+	// Get all past and current broadcasts
+	http.HandleFunc("/api/admin/broadcasts", middleware.RequireAdminAuth(admin.GetBroadcasts))
+
+	// Export the chat transcript of a broadcast
+	http.HandleFunc("/api/admin/chat/transcript", middleware.RequireAdminAuth(admin.GetChatTranscript))
+
+	// Get all chat polls and their results
+	http.HandleFunc("/api/admin/chat/polls", middleware.RequireAdminAuth(admin.GetPolls))
+
+	// Start a chat poll
+	http.HandleFunc("/api/admin/chat/polls/create", middleware.RequireAdminAuth(admin.CreatePoll))
+
+	// End a running chat poll
+	http.HandleFunc("/api/admin/chat/polls/end", middleware.RequireAdminAuth(admin.EndPoll))
+
 	// return followers
 	http.HandleFunc("/api/admin/followers", middleware.RequireAdminAuth(middleware.HandlePagination(controllers.GetFollowers)))
 
@@ -190,6 +259,27 @@ func Start() error {
 	// Enable/disable chat established user mode
 	http.HandleFunc("/api/admin/config/chat/establishedusermode", middleware.RequireAdminAuth(admin.SetEnableEstablishedChatUserMode))
 
+	// Set how many hours of chat messages are kept
+	http.HandleFunc("/api/admin/config/chat/retention", middleware.RequireAdminAuth(admin.SetChatRetentionHours))
+
+	// Set how many seconds chat users must wait between messages
+	http.HandleFunc("/api/admin/config/chat/slowmode", middleware.RequireAdminAuth(admin.SetChatSlowModeDuration))
+
+	// Restrict posting links to moderators and roles with the permission
+	http.HandleFunc("/api/admin/config/chat/restrictlinks", middleware.RequireAdminAuth(admin.SetChatLinksRestricted))
+
+	// Restrict custom emoji to moderators and roles with the permission
+	http.HandleFunc("/api/admin/config/chat/restrictedemoji", middleware.RequireAdminAuth(admin.SetChatRestrictedEmoji))
+
+	// Tell moderators about new users connecting from the IP address of a disabled user
+	http.HandleFunc("/api/admin/config/chat/banevasiondetection", middleware.RequireAdminAuth(admin.SetChatBanEvasionDetection))
+
+	// Set when chat is automatically protected from a raid and how
+	http.HandleFunc("/api/admin/config/chat/raidprotection", middleware.RequireAdminAuth(admin.SetChatRaidProtection))
+
+	// Set the integration that replies to chat commands
+	http.HandleFunc("/api/admin/config/chat/commandbot", middleware.RequireAdminAuth(admin.SetChatCommandBot))
+
 	// Set chat usernames that are not allowed
 	http.HandleFunc("/api/admin/config/chat/forbiddenusernames", middleware.RequireAdminAuth(admin.SetForbiddenUsernameList))
 
@@ -208,6 +298,30 @@ func Start() error {
 	// Create a single webhook
 	http.HandleFunc("/api/admin/webhooks/create", middleware.RequireAdminAuth(admin.CreateWebhook))
 
+	// Update a single webhook
+	http.HandleFunc("/api/admin/webhooks/update", middleware.RequireAdminAuth(admin.UpdateWebhook))
+
+	// Replace the secret used to sign requests to a single webhook
+	http.HandleFunc("/api/admin/webhooks/rotatesecret", middleware.RequireAdminAuth(admin.RotateWebhookSecret))
+
+	// Enable or disable a single webhook
+	http.HandleFunc("/api/admin/webhooks/setenabled", middleware.RequireAdminAuth(admin.SetWebhookEnabled))
+
+	// Return the most recent webhook deliveries
+	http.HandleFunc("/api/admin/webhooks/deliveries", middleware.RequireAdminAuth(admin.GetWebhookDeliveries))
+
+	// Send the event of a previous webhook delivery again
+	http.HandleFunc("/api/admin/webhooks/redeliver", middleware.RequireAdminAuth(admin.RedeliverWebhook))
+
+	// Trigger a server action such as an announcement or a poll
+	http.HandleFunc("/api/admin/actions/trigger", middleware.RequireAdminAuth(admin.TriggerAction))
+
+	// Return the most recent attempts to trigger actions
+	http.HandleFunc("/api/admin/actions/log", middleware.RequireAdminAuth(admin.GetActionAuditLog))
+
+	// Return the clips that can be shown while offline
+	http.HandleFunc("/api/admin/actions/offlineclips", middleware.RequireAdminAuth(admin.GetOfflineClips))
+
 	// Get all access tokens
 	http.HandleFunc("/api/admin/accesstokens", middleware.RequireAdminAuth(admin.GetExternalAPIUsers))
 
@@ -217,6 +331,9 @@ func Start() error {
 	// Create a single access token
 	http.HandleFunc("/api/admin/accesstokens/create", middleware.RequireAdminAuth(admin.CreateExternalAPIUser))
 
+	// Replace an access token without removing the integration
+	http.HandleFunc("/api/admin/accesstokens/rotate", middleware.RequireAdminAuth(admin.RotateExternalAPIUser))
+
 	// Return the auto-update features that are supported for this instance.
 	http.HandleFunc("/api/admin/update/options", middleware.RequireAdminAuth(admin.AutoUpdateOptions))
 
@@ -242,16 +359,28 @@ func Start() error {
 	http.HandleFunc("/api/integrations/chat/action", middleware.RequireExternalAPIAccessToken(user.ScopeCanSendSystemMessages, admin.SendChatAction))
 
 	// Hide chat message
-	http.HandleFunc("/api/integrations/chat/messagevisibility", middleware.RequireExternalAPIAccessToken(user.ScopeHasAdminAccess, admin.ExternalUpdateMessageVisibility))
+	http.HandleFunc("/api/integrations/chat/messagevisibility", middleware.RequireExternalAPIAccessToken(user.ScopeCanModerateChat, admin.ExternalUpdateMessageVisibility))
 
 	// Stream title
-	http.HandleFunc("/api/integrations/streamtitle", middleware.RequireExternalAPIAccessToken(user.ScopeHasAdminAccess, admin.ExternalSetStreamTitle))
+	http.HandleFunc("/api/integrations/streamtitle", middleware.RequireExternalAPIAccessToken(user.ScopeCanManageStream, admin.ExternalSetStreamTitle))
 
 	// Get chat history
-	http.HandleFunc("/api/integrations/chat", middleware.RequireExternalAPIAccessToken(user.ScopeHasAdminAccess, controllers.ExternalGetChatMessages))
+	http.HandleFunc("/api/integrations/chat", middleware.RequireExternalAPIAccessToken(user.ScopeCanReadChat, controllers.ExternalGetChatMessages))
+
+	// Stream chat events
+	http.HandleFunc("/api/integrations/chat/stream", middleware.RequireExternalAPIAccessToken(user.ScopeCanReadChat, controllers.ExternalGetChatEventStream))
 
 	// Connected clients
-	http.HandleFunc("/api/integrations/clients", middleware.RequireExternalAPIAccessToken(user.ScopeHasAdminAccess, admin.ExternalGetConnectedChatClients))
+	http.HandleFunc("/api/integrations/clients", middleware.RequireExternalAPIAccessToken(user.ScopeCanReadMetrics, admin.ExternalGetConnectedChatClients))
+
+	// Get all chat polls and their results
+	http.HandleFunc("/api/integrations/chat/polls", middleware.RequireExternalAPIAccessToken(user.ScopeCanReadChat, admin.ExternalGetPolls))
+
+	// Start a chat poll
+	http.HandleFunc("/api/integrations/chat/polls/create", middleware.RequireExternalAPIAccessToken(user.ScopeCanSendSystemMessages, admin.ExternalCreatePoll))
+
+	// End a running chat poll
+	http.HandleFunc("/api/integrations/chat/polls/end", middleware.RequireExternalAPIAccessToken(user.ScopeCanSendSystemMessages, admin.ExternalEndPoll))
 
 	// Logo path
 	http.HandleFunc("/api/admin/config/logo", middleware.RequireAdminAuth(admin.SetLogo))
@@ -315,6 +444,21 @@ func Start() error {
 	// Enable/disable a user
 	http.HandleFunc("/api/chat/users/setenabled", middleware.RequireUserModerationScopeAccesstoken(admin.UpdateUserEnabled))
 
+	// Get new users connecting from the IP address of a disabled user
+	http.HandleFunc("/api/chat/users/suspectedbanevaders", middleware.RequireUserModerationScopeAccesstoken(admin.GetBanEvasionSuspects))
+
+	// Protect chat from a raid
+	http.HandleFunc("/api/chat/raidprotection/start", middleware.RequireUserModerationScopeAccesstoken(admin.StartRaidProtection))
+
+	// Stop protecting chat from a raid
+	http.HandleFunc("/api/chat/raidprotection/end", middleware.RequireUserModerationScopeAccesstoken(admin.EndRaidProtection))
+
+	// Start a chat poll
+	http.HandleFunc("/api/chat/polls/create", middleware.RequireUserModerationScopeAccesstoken(admin.ModeratorCreatePoll))
+
+	// End a running chat poll
+	http.HandleFunc("/api/chat/polls/end", middleware.RequireUserModerationScopeAccesstoken(admin.EndPoll))
+
 	// Configure Federation features
 
 	// enable/disable federation features
@@ -350,6 +494,156 @@ func Start() error {
 	http.HandleFunc("/api/admin/config/notifications/discord", middleware.RequireAdminAuth(admin.SetDiscordNotificationConfiguration))
 	http.HandleFunc("/api/admin/config/notifications/browser", middleware.RequireAdminAuth(admin.SetBrowserNotificationConfiguration))
 	http.HandleFunc("/api/admin/config/notifications/twitter", middleware.RequireAdminAuth(admin.SetTwitterConfiguration))
+	http.HandleFunc("/api/admin/config/notifications/matrix", middleware.RequireAdminAuth(admin.SetMatrixNotificationConfiguration))
+	http.HandleFunc("/api/admin/config/notifications/email", middleware.RequireAdminAuth(admin.SetEmailNotificationConfiguration))
+	http.HandleFunc("/api/admin/config/notifications/ntfy", middleware.RequireAdminAuth(admin.SetNtfyNotificationConfiguration))
+	http.HandleFunc("/api/admin/config/notifications/gotify", middleware.RequireAdminAuth(admin.SetGotifyNotificationConfiguration))
+	http.HandleFunc("/api/admin/config/notifications/telegram", middleware.RequireAdminAuth(admin.SetTelegramNotificationConfiguration))
+	http.HandleFunc("/api/admin/config/notifications/mastodon", middleware.RequireAdminAuth(admin.SetMastodonNotificationConfiguration))
+
+	// Send the go-live notification to a single channel
+	http.HandleFunc("/api/admin/notifications/test", middleware.RequireAdminAuth(admin.SendTestNotification))
+
+	// Limit how often go-live notifications are sent
+	http.HandleFunc("/api/admin/config/notifications/throttle", middleware.RequireAdminAuth(admin.SetNotificationThrottleConfiguration))
+
+	// Render a channel's go-live message with the current stream details
+	http.HandleFunc("/api/admin/notifications/preview", middleware.RequireAdminAuth(admin.PreviewNotification))
+
+	// Browser push subscriber counts and recent deliveries
+	http.HandleFunc("/api/admin/notifications/browser/stats", middleware.RequireAdminAuth(admin.GetBrowserPushStats))
+
+	// Push an announcement or reminder to subscribed browsers
+	http.HandleFunc("/api/admin/notifications/browser/send", middleware.RequireAdminAuth(admin.SendBrowserPushAnnouncement))
+
+	// OpenID Connect login configuration
+	http.HandleFunc("/api/admin/config/auth/oidc", middleware.RequireAdminAuth(admin.SetOIDCConfiguration))
+
+	// The admin API is also available to integrations under /api/integrations
+	// using access tokens with the matching scope. The stream key, access
+	// tokens, other credentials and server updates remain admin only.
+
+	// Stream status, viewers and metrics
+	http.HandleFunc("/api/integrations/status", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanReadMetrics, admin.Status))
+	http.HandleFunc("/api/integrations/viewersOverTime", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanReadMetrics, admin.GetViewersOverTime))
+	http.HandleFunc("/api/integrations/viewers", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanReadMetrics, admin.GetActiveViewers))
+	http.HandleFunc("/api/integrations/hardwarestats", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanReadMetrics, admin.GetHardwareStats))
+	http.HandleFunc("/api/integrations/chat/clients", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanReadMetrics, admin.GetConnectedChatClients))
+	http.HandleFunc("/api/integrations/metrics/video", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanReadMetrics, admin.GetVideoPlaybackMetrics))
+	http.HandleFunc("/api/integrations/broadcasts", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanReadMetrics, admin.GetBroadcasts))
+	http.Handle("/api/integrations/prometheus", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanReadMetrics, promhttp.Handler().ServeHTTP))
+
+	// Chat history and moderation
+	http.HandleFunc("/api/integrations/chat/transcript", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanReadChat, admin.GetChatTranscript))
+	http.HandleFunc("/api/integrations/chat/messages", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanModerateChat, admin.GetChatMessages))
+	http.HandleFunc("/api/integrations/chat/updatemessagevisibility", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanModerateChat, admin.UpdateMessageVisibility))
+	http.HandleFunc("/api/integrations/chat/users/setenabled", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanModerateChat, admin.UpdateUserEnabled))
+	http.HandleFunc("/api/integrations/chat/users/ipbans/create", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanModerateChat, admin.BanIPAddress))
+	http.HandleFunc("/api/integrations/chat/users/ipbans/remove", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanModerateChat, admin.UnBanIPAddress))
+	http.HandleFunc("/api/integrations/chat/users/ipbans", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanModerateChat, admin.GetIPAddressBans))
+	http.HandleFunc("/api/integrations/chat/users/disabled", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanModerateChat, admin.GetDisabledUsers))
+	http.HandleFunc("/api/integrations/chat/users/suspectedbanevaders", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanModerateChat, admin.GetBanEvasionSuspects))
+	http.HandleFunc("/api/integrations/chat/commands", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanModerateChat, admin.GetChatCommands))
+	http.HandleFunc("/api/integrations/chat/raidprotection", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanModerateChat, admin.GetRaidProtectionStatus))
+	http.HandleFunc("/api/integrations/chat/raidprotection/start", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanModerateChat, admin.StartRaidProtection))
+	http.HandleFunc("/api/integrations/chat/raidprotection/end", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanModerateChat, admin.EndRaidProtection))
+	http.HandleFunc("/api/integrations/chat/users/moderators", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanModerateChat, admin.GetModerators))
+	http.HandleFunc("/api/integrations/chat/roles", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanModerateChat, admin.GetRoles))
+	http.HandleFunc("/api/integrations/chat/roles/users", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanModerateChat, admin.GetUsersWithRole))
+	http.HandleFunc("/api/integrations/config/chat/disable", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanModerateChat, admin.SetChatDisabled))
+	http.HandleFunc("/api/integrations/config/chat/establishedusermode", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanModerateChat, admin.SetEnableEstablishedChatUserMode))
+	http.HandleFunc("/api/integrations/config/chat/forbiddenusernames", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanModerateChat, admin.SetForbiddenUsernameList))
+	http.HandleFunc("/api/integrations/config/chat/slowmode", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanModerateChat, admin.SetChatSlowModeDuration))
+	http.HandleFunc("/api/integrations/config/chat/restrictlinks", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanModerateChat, admin.SetChatLinksRestricted))
+	http.HandleFunc("/api/integrations/config/chat/restrictedemoji", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanModerateChat, admin.SetChatRestrictedEmoji))
+	http.HandleFunc("/api/integrations/config/chat/banevasiondetection", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanModerateChat, admin.SetChatBanEvasionDetection))
+	http.HandleFunc("/api/integrations/config/chat/raidprotection", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanModerateChat, admin.SetChatRaidProtection))
+
+	// Stream metadata
+	http.HandleFunc("/api/integrations/disconnect", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanManageStream, admin.DisconnectInboundConnection))
+	http.HandleFunc("/api/integrations/config/streamtitle", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanManageStream, admin.SetStreamTitle))
+	http.HandleFunc("/api/integrations/config/name", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanManageStream, admin.SetServerName))
+	http.HandleFunc("/api/integrations/config/serversummary", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanManageStream, admin.SetServerSummary))
+	http.HandleFunc("/api/integrations/config/welcomemessage", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanManageStream, admin.SetServerWelcomeMessage))
+	http.HandleFunc("/api/integrations/config/pagecontent", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanManageStream, admin.SetExtraPageContent))
+	http.HandleFunc("/api/integrations/config/logo", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanManageStream, admin.SetLogo))
+	http.HandleFunc("/api/integrations/config/tags", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanManageStream, admin.SetTags))
+	http.HandleFunc("/api/integrations/config/nsfw", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanManageStream, admin.SetNSFW))
+	http.HandleFunc("/api/integrations/config/socialhandles", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanManageStream, admin.SetSocialHandles))
+
+	// Webhooks
+	http.HandleFunc("/api/integrations/webhooks", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanManageWebhooks, admin.GetWebhooks))
+	http.HandleFunc("/api/integrations/webhooks/delete", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanManageWebhooks, admin.DeleteWebhook))
+	http.HandleFunc("/api/integrations/webhooks/create", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanManageWebhooks, admin.CreateWebhook))
+	http.HandleFunc("/api/integrations/webhooks/update", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanManageWebhooks, admin.UpdateWebhook))
+	http.HandleFunc("/api/integrations/webhooks/rotatesecret", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanManageWebhooks, admin.RotateWebhookSecret))
+	http.HandleFunc("/api/integrations/webhooks/setenabled", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanManageWebhooks, admin.SetWebhookEnabled))
+	http.HandleFunc("/api/integrations/webhooks/deliveries", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanManageWebhooks, admin.GetWebhookDeliveries))
+	http.HandleFunc("/api/integrations/webhooks/redeliver", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanManageWebhooks, admin.RedeliverWebhook))
+
+	// Actions
+	http.HandleFunc("/api/integrations/actions/trigger", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanTriggerActions, admin.TriggerAction))
+	http.HandleFunc("/api/integrations/actions/offlineclips", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanTriggerActions, admin.GetOfflineClips))
+
+	// Fediverse followers
+	http.HandleFunc("/api/integrations/followers", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanManageFollowers, middleware.HandlePagination(controllers.GetFollowers)))
+	http.HandleFunc("/api/integrations/followers/pending", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanManageFollowers, admin.GetPendingFollowRequests))
+	http.HandleFunc("/api/integrations/followers/blocked", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanManageFollowers, admin.GetBlockedAndRejectedFollowers))
+	http.HandleFunc("/api/integrations/followers/approve", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeCanManageFollowers, admin.ApproveFollower))
+
+	// Server configuration
+	http.HandleFunc("/api/integrations/serverconfig", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.GetServerConfigForIntegrations))
+	http.HandleFunc("/api/integrations/logs", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.GetLogs))
+	http.HandleFunc("/api/integrations/logs/warnings", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.GetWarnings))
+	http.HandleFunc("/api/integrations/config/chat/joinmessagesenabled", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetChatJoinMessagesEnabled))
+	http.HandleFunc("/api/integrations/config/chat/retention", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetChatRetentionHours))
+	http.HandleFunc("/api/integrations/config/chat/suggestedusernames", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetSuggestedUsernameList))
+	http.HandleFunc("/api/integrations/chat/users/setmoderator", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.UpdateUserModerator))
+	http.HandleFunc("/api/integrations/chat/users/setrole", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.UpdateUserRole))
+	http.HandleFunc("/api/integrations/chat/roles/create", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.CreateRole))
+	http.HandleFunc("/api/integrations/chat/roles/update", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.UpdateRole))
+	http.HandleFunc("/api/integrations/chat/roles/delete", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.DeleteRole))
+	http.HandleFunc("/api/integrations/chat/commands/create", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.CreateChatCommand))
+	http.HandleFunc("/api/integrations/chat/commands/update", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.UpdateChatCommand))
+	http.HandleFunc("/api/integrations/chat/commands/delete", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.DeleteChatCommand))
+	http.HandleFunc("/api/integrations/config/chat/commandbot", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetChatCommandBot))
+	http.HandleFunc("/api/integrations/config/video/codec", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetVideoCodec))
+	http.HandleFunc("/api/integrations/config/video/streamlatencylevel", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetStreamLatencyLevel))
+	http.HandleFunc("/api/integrations/config/video/streamoutputvariants", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetStreamOutputVariants))
+	http.HandleFunc("/api/integrations/config/ffmpegpath", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetFfmpegPath))
+	http.HandleFunc("/api/integrations/config/webserverport", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetWebServerPort))
+	http.HandleFunc("/api/integrations/config/webserverip", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetWebServerIP))
+	http.HandleFunc("/api/integrations/config/rtmpserverport", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetRTMPServerPort))
+	http.HandleFunc("/api/integrations/config/sockethostoverride", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetSocketHostOverride))
+	http.HandleFunc("/api/integrations/config/directoryenabled", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetDirectoryEnabled))
+	http.HandleFunc("/api/integrations/config/s3", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetS3Configuration))
+	http.HandleFunc("/api/integrations/config/serverurl", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetServerURL))
+	http.HandleFunc("/api/integrations/yp/reset", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.ResetYPRegistration))
+	http.HandleFunc("/api/integrations/config/externalactions", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetExternalActions))
+	http.HandleFunc("/api/integrations/config/customstyles", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetCustomStyles))
+	http.HandleFunc("/api/integrations/config/federation/enable", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetFederationEnabled))
+	http.HandleFunc("/api/integrations/config/federation/private", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetFederationActivityPrivate))
+	http.HandleFunc("/api/integrations/config/federation/showengagement", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetFederationShowEngagement))
+	http.HandleFunc("/api/integrations/config/federation/username", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetFederationUsername))
+	http.HandleFunc("/api/integrations/config/federation/livemessage", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetFederationGoLiveMessage))
+	http.HandleFunc("/api/integrations/config/federation/blockdomains", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetFederationBlockDomains))
+	http.HandleFunc("/api/integrations/federation/send", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SendFederatedMessage))
+	http.HandleFunc("/api/integrations/federation/actions", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, middleware.HandlePagination(admin.GetFederatedActions)))
+	http.HandleFunc("/api/integrations/config/notifications/discord", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetDiscordNotificationConfiguration))
+	http.HandleFunc("/api/integrations/config/notifications/browser", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetBrowserNotificationConfiguration))
+	http.HandleFunc("/api/integrations/config/notifications/twitter", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetTwitterConfiguration))
+	http.HandleFunc("/api/integrations/config/notifications/matrix", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetMatrixNotificationConfiguration))
+	http.HandleFunc("/api/integrations/config/notifications/email", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetEmailNotificationConfiguration))
+	http.HandleFunc("/api/integrations/config/notifications/ntfy", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetNtfyNotificationConfiguration))
+	http.HandleFunc("/api/integrations/config/notifications/gotify", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetGotifyNotificationConfiguration))
+	http.HandleFunc("/api/integrations/config/notifications/telegram", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetTelegramNotificationConfiguration))
+	http.HandleFunc("/api/integrations/config/notifications/mastodon", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetMastodonNotificationConfiguration))
+	http.HandleFunc("/api/integrations/notifications/test", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SendTestNotification))
+	http.HandleFunc("/api/integrations/config/notifications/throttle", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetNotificationThrottleConfiguration))
+	http.HandleFunc("/api/integrations/notifications/preview", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.PreviewNotification))
+	http.HandleFunc("/api/integrations/notifications/browser/stats", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.GetBrowserPushStats))
+	http.HandleFunc("/api/integrations/notifications/browser/send", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SendBrowserPushAnnouncement))
+	http.HandleFunc("/api/integrations/config/auth/oidc", middleware.RequireExternalAPIAccessTokenForHandler(user.ScopeHasAdminAccess, admin.SetOIDCConfiguration))
 
 	// Auth
 
@@ -358,6 +652,20 @@ func Start() error {
 	http.HandleFunc("/api/auth/indieauth/callback", indieauth.HandleRedirect)
 	http.HandleFunc("/api/auth/provider/indieauth", indieauth.HandleAuthEndpoint)
 
+	http.HandleFunc("/api/auth/oidc", middleware.RequireUserAccessToken(oidcauth.StartAuthFlow))
+	http.HandleFunc("/api/auth/oidc/callback", oidcauth.HandleRedirect)
+
+	http.HandleFunc("/api/auth/password/register", middleware.RequireUserAccessToken(passwordauth.Register))
+	http.HandleFunc("/api/auth/password/login", middleware.RequireUserAccessToken(passwordauth.Login))
+	http.HandleFunc("/api/auth/password/change", middleware.RequireUserAccessToken(passwordauth.ChangePassword))
+
+	// Every way the current user can log back in
+	http.HandleFunc("/api/auth/methods", middleware.RequireUserAccessToken(controllers.GetLoginMethods))
+
+	// Export or delete everything stored about the current chat user
+	http.HandleFunc("/api/chat/account/export", middleware.RequireUserAccessToken(controllers.ExportChatUserData))
+	http.HandleFunc("/api/chat/account/delete", middleware.RequireUserAccessToken(controllers.DeleteChatUserData))
+
 	http.HandleFunc("/api/auth/fediverse", middleware.RequireUserAccessToken(fediverseauth.RegisterFediverseOTPRequest))
 	http.HandleFunc("/api/auth/fediverse/verify", fediverseauth.VerifyFediverseOTPRequest)
 
@@ -5,6 +5,8 @@ import (
 	"encoding/hex"
 	"net"
 	"net/http"
+	"strings"
+	"sync"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -35,3 +37,82 @@ func GetIPAddressFromRequest(req *http.Request) string {
 
 	return ip
 }
+
+var (
+	trustedProxies     []*net.IPNet
+	trustedProxiesLock sync.RWMutex
+)
+
+// SetTrustedProxies sets the comma separated list of IP addresses or CIDR
+// ranges of reverse proxies whose forwarded client addresses are trusted.
+func SetTrustedProxies(proxies string) error {
+	networks := []*net.IPNet{}
+	for _, proxy := range strings.Split(proxies, ",") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return err
+		}
+		networks = append(networks, network)
+	}
+
+	trustedProxiesLock.Lock()
+	defer trustedProxiesLock.Unlock()
+	trustedProxies = networks
+
+	return nil
+}
+
+func isTrustedProxy(ip net.IP) bool {
+	trustedProxiesLock.RLock()
+	defer trustedProxiesLock.RUnlock()
+
+	for _, network := range trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetClientIPAddress returns the address of the client that made a request.
+// Forwarded addresses are only used when the request came from a trusted
+// proxy, so clients cannot spoof their address for access control.
+func GetClientIPAddress(req *http.Request) string {
+	ip, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		ip = req.RemoteAddr
+	}
+
+	remote := net.ParseIP(ip)
+	if remote == nil || !isTrustedProxy(remote) {
+		return ip
+	}
+
+	// Walk the forwarded chain from the closest hop, skipping our own proxies.
+	forwarded := strings.Split(req.Header.Get("X-Forwarded-For"), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		address := net.ParseIP(strings.TrimSpace(forwarded[i]))
+		if address == nil {
+			break
+		}
+		ip = address.String()
+		if !isTrustedProxy(address) {
+			break
+		}
+	}
+
+	return ip
+}
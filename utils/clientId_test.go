@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetClientIPAddress(t *testing.T) {
+	if err := SetTrustedProxies("10.0.0.0/8, 192.168.1.1"); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = SetTrustedProxies("") }()
+
+	tests := []struct {
+		remote    string
+		forwarded string
+		want      string
+	}{
+		{"203.0.113.5:1234", "1.2.3.4", "203.0.113.5"},
+		{"10.1.2.3:1234", "1.2.3.4", "1.2.3.4"},
+		{"10.1.2.3:1234", "6.6.6.6, 1.2.3.4, 192.168.1.1", "1.2.3.4"},
+		{"192.168.1.1:1234", "", "192.168.1.1"},
+		{"10.1.2.3:1234", "not-an-ip", "10.1.2.3"},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.RemoteAddr = test.remote
+		if test.forwarded != "" {
+			req.Header.Set("X-Forwarded-For", test.forwarded)
+		}
+
+		if got := GetClientIPAddress(req); got != test.want {
+			t.Errorf("remote %s forwarded %q: got %s, want %s", test.remote, test.forwarded, got, test.want)
+		}
+	}
+}
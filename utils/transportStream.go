@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"errors"
+)
+
+const (
+	transportStreamPacketSize = 188
+	transportStreamSyncByte   = 0x47
+	// Presentation timestamps count at 90kHz.
+	transportStreamClockRate = 90000
+)
+
+type transportStreamTimestamps struct {
+	min, max int64
+	count    int
+	video    bool
+}
+
+// GetTransportStreamDuration will return the duration in seconds of an
+// MPEG transport stream, such as an HLS segment, using the presentation
+// timestamps of its video, or audio if it has no video.
+func GetTransportStreamDuration(data []byte) (float64, error) {
+	streams := map[int]*transportStreamTimestamps{}
+
+	for offset := 0; offset+transportStreamPacketSize <= len(data); offset += transportStreamPacketSize {
+		packet := data[offset : offset+transportStreamPacketSize]
+		if packet[0] != transportStreamSyncByte {
+			return 0, errors.New("not an mpeg transport stream")
+		}
+
+		// Only packets that start a PES packet have a timestamp.
+		if packet[1]&0x40 == 0 {
+			continue
+		}
+
+		pid := int(packet[1]&0x1f)<<8 | int(packet[2])
+		adaptationField := (packet[3] >> 4) & 0x03
+		payload := packet[4:]
+		if adaptationField&0x02 != 0 {
+			if int(packet[4])+1 > len(payload) {
+				continue
+			}
+			payload = payload[int(packet[4])+1:]
+		}
+		if adaptationField&0x01 == 0 {
+			continue
+		}
+
+		pts, video, ok := parsePESTimestamp(payload)
+		if !ok {
+			continue
+		}
+
+		stream, exists := streams[pid]
+		if !exists {
+			stream = &transportStreamTimestamps{min: pts, max: pts, video: video}
+			streams[pid] = stream
+		}
+		if pts < stream.min {
+			stream.min = pts
+		}
+		if pts > stream.max {
+			stream.max = pts
+		}
+		stream.count++
+	}
+
+	var selected *transportStreamTimestamps
+	for _, stream := range streams {
+		if selected == nil || (stream.video && !selected.video) || (stream.video == selected.video && stream.count > selected.count) {
+			selected = stream
+		}
+	}
+
+	if selected == nil || selected.count < 2 {
+		return 0, errors.New("unable to find timestamps in the transport stream")
+	}
+
+	// The last frame is shown for as long as the average frame.
+	span := selected.max - selected.min
+	span += span / int64(selected.count-1)
+
+	return float64(span) / transportStreamClockRate, nil
+}
+
+// parsePESTimestamp will return the presentation timestamp of an audio or
+// video PES packet, and if it is video.
+func parsePESTimestamp(payload []byte) (int64, bool, bool) {
+	if len(payload) < 14 || payload[0] != 0 || payload[1] != 0 || payload[2] != 1 {
+		return 0, false, false
+	}
+
+	streamID := payload[3]
+	video := streamID&0xf0 == 0xe0
+	audio := streamID&0xe0 == 0xc0
+	if !video && !audio {
+		return 0, false, false
+	}
+
+	// The PTS is present if the first of the PTS DTS flags is set.
+	if payload[7]&0x80 == 0 {
+		return 0, false, false
+	}
+
+	b := payload[9:14]
+	pts := int64(b[0]>>1&0x07)<<30 | int64(b[1])<<22 | int64(b[2]>>1)<<15 | int64(b[3])<<7 | int64(b[4]>>1)
+
+	return pts, video, true
+}
@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"math"
+	"os"
+	"testing"
+)
+
+func TestGetTransportStreamDuration(t *testing.T) {
+	segment, err := os.ReadFile("../static/offline.ts")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	duration, err := GetTransportStreamDuration(segment)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The default offline clip is 8 seconds long.
+	if math.Abs(duration-8) > 0.1 {
+		t.Errorf("expected the offline clip to be about 8 seconds, got %f", duration)
+	}
+
+	if _, err := GetTransportStreamDuration([]byte("not a video")); err == nil {
+		t.Error("expected an error for data that is not a transport stream")
+	}
+}